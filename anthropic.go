@@ -2,7 +2,11 @@ package lingo
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/anthropics/anthropic-sdk-go"
@@ -27,10 +31,17 @@ func init() {
 type AnthropicConfig struct {
 	// APIKey is the Anthropic API key (required)
 	APIKey string
-	// Timeout is the request timeout (default: 60s)
+	// Timeout is the request timeout (default: the model's recommended timeout,
+	// reported by Describe)
 	Timeout time.Duration
 	// RateLimiter is the optional rate limit configuration
 	RateLimiter *RateLimitConfig
+	// DefaultMaxTokens is applied to models that haven't set max tokens explicitly
+	DefaultMaxTokens int
+	// DefaultTemperature is applied to models that haven't set a temperature
+	// explicitly (optional; 0 is sent as a temperature of 0). Ignored when
+	// extended thinking is enabled.
+	DefaultTemperature *float64
 }
 
 // Implement ProviderConfig interface
@@ -45,20 +56,52 @@ func (c *AnthropicConfig) rateLimitConfig() *RateLimitConfig { return c.RateLimi
 
 // anthropicOptions contains options for standard Anthropic models
 type anthropicOptions struct {
-	modelVersion string // Optional: override model name with specific version (e.g., "latest")
-	maxTokens    int
-	temperature  float64
-	topP         float64
-	topK         int
-	systemPrompt string
+	modelVersion     string // Optional: override model name with specific version (e.g., "latest")
+	maxTokens        int
+	temperature      float64
+	topP             float64
+	topK             int
+	systemPrompt     string
+	endUserID        string // Opaque end-user identifier sent as metadata.user_id
+	assistantPrefill string // Text the assistant's reply is forced to start with
+
+	// Track explicitly set options so config-level defaults don't override them
+	maxTokensSet   bool
+	temperatureSet bool
 }
 
+func (o *anthropicOptions) setMaxTokens(n int)       { o.maxTokens = n; o.maxTokensSet = true }
+func (o *anthropicOptions) setTemperature(t float64) { o.temperature = t; o.temperatureSet = true }
+func (o *anthropicOptions) explicitOptions() explicitOptions {
+	return explicitOptions{maxTokens: o.maxTokensSet, temperature: o.temperatureSet}
+}
+func (o *anthropicOptions) explicitTemperature() (float64, bool) {
+	return o.temperature, o.temperatureSet
+}
+func (o *anthropicOptions) endUser() string                { return o.endUserID }
+func (o *anthropicOptions) prefill() string                { return o.assistantPrefill }
+func (o *anthropicOptions) baseOptions() *anthropicOptions { return o }
+
 // anthropicThinkingOptions contains options for models that support extended thinking
 type anthropicThinkingOptions struct {
 	anthropicOptions
 	thinkingBudget int // Must be >= 1024 and less than maxTokens
 }
 
+func (o *anthropicThinkingOptions) budget() int { return o.thinkingBudget }
+func (o *anthropicThinkingOptions) setThinking(enabled bool) {
+	switch {
+	case !enabled:
+		o.thinkingBudget = 0
+	case o.thinkingBudget == 0:
+		o.thinkingBudget = defaultThinkingBudget
+	}
+}
+
+// defaultThinkingBudget is the extended thinking budget set by WithThinking,
+// the smallest the API accepts
+const defaultThinkingBudget = 1024
+
 // ============================================================================
 // STANDARD MODELS (Claude 3.5 series and earlier)
 // ============================================================================
@@ -78,15 +121,20 @@ func (m *Claude35Sonnet) SystemPrompt() string   { return m.systemPrompt }
 func (m *Claude35Sonnet) supportsThinking() bool { return false }
 
 func (m *Claude35Sonnet) WithVersion(v string) *Claude35Sonnet      { m.modelVersion = v; return m }
-func (m *Claude35Sonnet) WithMaxTokens(n int) *Claude35Sonnet       { m.maxTokens = n; return m }
-func (m *Claude35Sonnet) WithTemperature(t float64) *Claude35Sonnet { m.temperature = t; return m }
+func (m *Claude35Sonnet) WithMaxTokens(n int) *Claude35Sonnet       { m.setMaxTokens(n); return m }
+func (m *Claude35Sonnet) WithTemperature(t float64) *Claude35Sonnet { m.setTemperature(t); return m }
 func (m *Claude35Sonnet) WithTopP(p float64) *Claude35Sonnet        { m.topP = p; return m }
 func (m *Claude35Sonnet) WithTopK(k int) *Claude35Sonnet            { m.topK = k; return m }
 func (m *Claude35Sonnet) WithSystemPrompt(s string) *Claude35Sonnet { m.systemPrompt = s; return m }
+func (m *Claude35Sonnet) WithEndUserID(id string) *Claude35Sonnet   { m.endUserID = id; return m }
+func (m *Claude35Sonnet) WithAssistantPrefill(text string) *Claude35Sonnet {
+	m.assistantPrefill = text
+	return m
+}
 
 // NewClaude35Sonnet creates a new Claude 3.5 Sonnet model with default options
 func NewClaude35Sonnet() *Claude35Sonnet {
-	return &Claude35Sonnet{anthropicOptions{maxTokens: 4096, temperature: 1.0}}
+	return &Claude35Sonnet{anthropicOptions{maxTokens: defaultMaxTokens("claude-3-5-sonnet-20241022"), temperature: 1.0}}
 }
 
 // Claude35Haiku represents the Claude 3.5 Haiku model
@@ -104,15 +152,20 @@ func (m *Claude35Haiku) SystemPrompt() string   { return m.systemPrompt }
 func (m *Claude35Haiku) supportsThinking() bool { return false }
 
 func (m *Claude35Haiku) WithVersion(v string) *Claude35Haiku      { m.modelVersion = v; return m }
-func (m *Claude35Haiku) WithMaxTokens(n int) *Claude35Haiku       { m.maxTokens = n; return m }
-func (m *Claude35Haiku) WithTemperature(t float64) *Claude35Haiku { m.temperature = t; return m }
+func (m *Claude35Haiku) WithMaxTokens(n int) *Claude35Haiku       { m.setMaxTokens(n); return m }
+func (m *Claude35Haiku) WithTemperature(t float64) *Claude35Haiku { m.setTemperature(t); return m }
 func (m *Claude35Haiku) WithTopP(p float64) *Claude35Haiku        { m.topP = p; return m }
 func (m *Claude35Haiku) WithTopK(k int) *Claude35Haiku            { m.topK = k; return m }
 func (m *Claude35Haiku) WithSystemPrompt(s string) *Claude35Haiku { m.systemPrompt = s; return m }
+func (m *Claude35Haiku) WithEndUserID(id string) *Claude35Haiku   { m.endUserID = id; return m }
+func (m *Claude35Haiku) WithAssistantPrefill(text string) *Claude35Haiku {
+	m.assistantPrefill = text
+	return m
+}
 
 // NewClaude35Haiku creates a new Claude 3.5 Haiku model with default options
 func NewClaude35Haiku() *Claude35Haiku {
-	return &Claude35Haiku{anthropicOptions{maxTokens: 4096, temperature: 1.0}}
+	return &Claude35Haiku{anthropicOptions{maxTokens: defaultMaxTokens("claude-3-5-haiku-20241022"), temperature: 1.0}}
 }
 
 // Claude3Opus represents the Claude 3 Opus model
@@ -130,15 +183,20 @@ func (m *Claude3Opus) SystemPrompt() string   { return m.systemPrompt }
 func (m *Claude3Opus) supportsThinking() bool { return false }
 
 func (m *Claude3Opus) WithVersion(v string) *Claude3Opus      { m.modelVersion = v; return m }
-func (m *Claude3Opus) WithMaxTokens(n int) *Claude3Opus       { m.maxTokens = n; return m }
-func (m *Claude3Opus) WithTemperature(t float64) *Claude3Opus { m.temperature = t; return m }
+func (m *Claude3Opus) WithMaxTokens(n int) *Claude3Opus       { m.setMaxTokens(n); return m }
+func (m *Claude3Opus) WithTemperature(t float64) *Claude3Opus { m.setTemperature(t); return m }
 func (m *Claude3Opus) WithTopP(p float64) *Claude3Opus        { m.topP = p; return m }
 func (m *Claude3Opus) WithTopK(k int) *Claude3Opus            { m.topK = k; return m }
 func (m *Claude3Opus) WithSystemPrompt(s string) *Claude3Opus { m.systemPrompt = s; return m }
+func (m *Claude3Opus) WithEndUserID(id string) *Claude3Opus   { m.endUserID = id; return m }
+func (m *Claude3Opus) WithAssistantPrefill(text string) *Claude3Opus {
+	m.assistantPrefill = text
+	return m
+}
 
 // NewClaude3Opus creates a new Claude 3 Opus model with default options
 func NewClaude3Opus() *Claude3Opus {
-	return &Claude3Opus{anthropicOptions{maxTokens: 4096, temperature: 1.0}}
+	return &Claude3Opus{anthropicOptions{maxTokens: defaultMaxTokens("claude-3-opus-20240229"), temperature: 1.0}}
 }
 
 // Claude3Haiku represents the Claude 3 Haiku model
@@ -149,15 +207,20 @@ func (m *Claude3Haiku) Provider() ProviderType { return ProviderAnthropic }
 func (m *Claude3Haiku) SystemPrompt() string   { return m.systemPrompt }
 func (m *Claude3Haiku) supportsThinking() bool { return false }
 
-func (m *Claude3Haiku) WithMaxTokens(n int) *Claude3Haiku       { m.maxTokens = n; return m }
-func (m *Claude3Haiku) WithTemperature(t float64) *Claude3Haiku { m.temperature = t; return m }
+func (m *Claude3Haiku) WithMaxTokens(n int) *Claude3Haiku       { m.setMaxTokens(n); return m }
+func (m *Claude3Haiku) WithTemperature(t float64) *Claude3Haiku { m.setTemperature(t); return m }
 func (m *Claude3Haiku) WithTopP(p float64) *Claude3Haiku        { m.topP = p; return m }
 func (m *Claude3Haiku) WithTopK(k int) *Claude3Haiku            { m.topK = k; return m }
 func (m *Claude3Haiku) WithSystemPrompt(s string) *Claude3Haiku { m.systemPrompt = s; return m }
+func (m *Claude3Haiku) WithEndUserID(id string) *Claude3Haiku   { m.endUserID = id; return m }
+func (m *Claude3Haiku) WithAssistantPrefill(text string) *Claude3Haiku {
+	m.assistantPrefill = text
+	return m
+}
 
 // NewClaude3Haiku creates a new Claude 3 Haiku model with default options
 func NewClaude3Haiku() *Claude3Haiku {
-	return &Claude3Haiku{anthropicOptions{maxTokens: 4096, temperature: 1.0}}
+	return &Claude3Haiku{anthropicOptions{maxTokens: defaultMaxTokens("claude-3-haiku-20240307"), temperature: 1.0}}
 }
 
 // Claude3Sonnet represents the Claude 3 Sonnet model
@@ -168,15 +231,20 @@ func (m *Claude3Sonnet) Provider() ProviderType { return ProviderAnthropic }
 func (m *Claude3Sonnet) SystemPrompt() string   { return m.systemPrompt }
 func (m *Claude3Sonnet) supportsThinking() bool { return false }
 
-func (m *Claude3Sonnet) WithMaxTokens(n int) *Claude3Sonnet       { m.maxTokens = n; return m }
-func (m *Claude3Sonnet) WithTemperature(t float64) *Claude3Sonnet { m.temperature = t; return m }
+func (m *Claude3Sonnet) WithMaxTokens(n int) *Claude3Sonnet       { m.setMaxTokens(n); return m }
+func (m *Claude3Sonnet) WithTemperature(t float64) *Claude3Sonnet { m.setTemperature(t); return m }
 func (m *Claude3Sonnet) WithTopP(p float64) *Claude3Sonnet        { m.topP = p; return m }
 func (m *Claude3Sonnet) WithTopK(k int) *Claude3Sonnet            { m.topK = k; return m }
 func (m *Claude3Sonnet) WithSystemPrompt(s string) *Claude3Sonnet { m.systemPrompt = s; return m }
+func (m *Claude3Sonnet) WithEndUserID(id string) *Claude3Sonnet   { m.endUserID = id; return m }
+func (m *Claude3Sonnet) WithAssistantPrefill(text string) *Claude3Sonnet {
+	m.assistantPrefill = text
+	return m
+}
 
 // NewClaude3Sonnet creates a new Claude 3 Sonnet model with default options
 func NewClaude3Sonnet() *Claude3Sonnet {
-	return &Claude3Sonnet{anthropicOptions{maxTokens: 4096, temperature: 1.0}}
+	return &Claude3Sonnet{anthropicOptions{maxTokens: defaultMaxTokens("claude-3-sonnet-20240229"), temperature: 1.0}}
 }
 
 // ============================================================================
@@ -198,17 +266,23 @@ func (m *Claude37Sonnet) SystemPrompt() string   { return m.systemPrompt }
 func (m *Claude37Sonnet) supportsThinking() bool { return true }
 
 func (m *Claude37Sonnet) WithVersion(v string) *Claude37Sonnet      { m.modelVersion = v; return m }
-func (m *Claude37Sonnet) WithMaxTokens(n int) *Claude37Sonnet       { m.maxTokens = n; return m }
-func (m *Claude37Sonnet) WithTemperature(t float64) *Claude37Sonnet { m.temperature = t; return m }
+func (m *Claude37Sonnet) WithMaxTokens(n int) *Claude37Sonnet       { m.setMaxTokens(n); return m }
+func (m *Claude37Sonnet) WithTemperature(t float64) *Claude37Sonnet { m.setTemperature(t); return m }
 func (m *Claude37Sonnet) WithTopP(p float64) *Claude37Sonnet        { m.topP = p; return m }
 func (m *Claude37Sonnet) WithTopK(k int) *Claude37Sonnet            { m.topK = k; return m }
 func (m *Claude37Sonnet) WithSystemPrompt(s string) *Claude37Sonnet { m.systemPrompt = s; return m }
+func (m *Claude37Sonnet) WithEndUserID(id string) *Claude37Sonnet   { m.endUserID = id; return m }
+func (m *Claude37Sonnet) WithAssistantPrefill(text string) *Claude37Sonnet {
+	m.assistantPrefill = text
+	return m
+}
 func (m *Claude37Sonnet) WithThinkingBudget(n int) *Claude37Sonnet  { m.thinkingBudget = n; return m }
+func (m *Claude37Sonnet) WithThinking(enabled bool) *Claude37Sonnet { m.setThinking(enabled); return m }
 
 // NewClaude37Sonnet creates a new Claude 3.7 Sonnet model with default options
 func NewClaude37Sonnet() *Claude37Sonnet {
 	return &Claude37Sonnet{anthropicThinkingOptions{
-		anthropicOptions: anthropicOptions{maxTokens: 8192, temperature: 1.0},
+		anthropicOptions: anthropicOptions{maxTokens: defaultMaxTokens("claude-3-7-sonnet-20250219"), temperature: 1.0},
 	}}
 }
 
@@ -220,17 +294,23 @@ func (m *ClaudeSonnet4) Provider() ProviderType { return ProviderAnthropic }
 func (m *ClaudeSonnet4) SystemPrompt() string   { return m.systemPrompt }
 func (m *ClaudeSonnet4) supportsThinking() bool { return true }
 
-func (m *ClaudeSonnet4) WithMaxTokens(n int) *ClaudeSonnet4       { m.maxTokens = n; return m }
-func (m *ClaudeSonnet4) WithTemperature(t float64) *ClaudeSonnet4 { m.temperature = t; return m }
+func (m *ClaudeSonnet4) WithMaxTokens(n int) *ClaudeSonnet4       { m.setMaxTokens(n); return m }
+func (m *ClaudeSonnet4) WithTemperature(t float64) *ClaudeSonnet4 { m.setTemperature(t); return m }
 func (m *ClaudeSonnet4) WithTopP(p float64) *ClaudeSonnet4        { m.topP = p; return m }
 func (m *ClaudeSonnet4) WithTopK(k int) *ClaudeSonnet4            { m.topK = k; return m }
 func (m *ClaudeSonnet4) WithSystemPrompt(s string) *ClaudeSonnet4 { m.systemPrompt = s; return m }
+func (m *ClaudeSonnet4) WithEndUserID(id string) *ClaudeSonnet4   { m.endUserID = id; return m }
+func (m *ClaudeSonnet4) WithAssistantPrefill(text string) *ClaudeSonnet4 {
+	m.assistantPrefill = text
+	return m
+}
 func (m *ClaudeSonnet4) WithThinkingBudget(n int) *ClaudeSonnet4  { m.thinkingBudget = n; return m }
+func (m *ClaudeSonnet4) WithThinking(enabled bool) *ClaudeSonnet4 { m.setThinking(enabled); return m }
 
 // NewClaudeSonnet4 creates a new Claude Sonnet 4 model with default options
 func NewClaudeSonnet4() *ClaudeSonnet4 {
 	return &ClaudeSonnet4{anthropicThinkingOptions{
-		anthropicOptions: anthropicOptions{maxTokens: 8192, temperature: 1.0},
+		anthropicOptions: anthropicOptions{maxTokens: defaultMaxTokens("claude-sonnet-4-20250514"), temperature: 1.0},
 	}}
 }
 
@@ -242,17 +322,23 @@ func (m *ClaudeOpus4) Provider() ProviderType { return ProviderAnthropic }
 func (m *ClaudeOpus4) SystemPrompt() string   { return m.systemPrompt }
 func (m *ClaudeOpus4) supportsThinking() bool { return true }
 
-func (m *ClaudeOpus4) WithMaxTokens(n int) *ClaudeOpus4       { m.maxTokens = n; return m }
-func (m *ClaudeOpus4) WithTemperature(t float64) *ClaudeOpus4 { m.temperature = t; return m }
+func (m *ClaudeOpus4) WithMaxTokens(n int) *ClaudeOpus4       { m.setMaxTokens(n); return m }
+func (m *ClaudeOpus4) WithTemperature(t float64) *ClaudeOpus4 { m.setTemperature(t); return m }
 func (m *ClaudeOpus4) WithTopP(p float64) *ClaudeOpus4        { m.topP = p; return m }
 func (m *ClaudeOpus4) WithTopK(k int) *ClaudeOpus4            { m.topK = k; return m }
 func (m *ClaudeOpus4) WithSystemPrompt(s string) *ClaudeOpus4 { m.systemPrompt = s; return m }
+func (m *ClaudeOpus4) WithEndUserID(id string) *ClaudeOpus4   { m.endUserID = id; return m }
+func (m *ClaudeOpus4) WithAssistantPrefill(text string) *ClaudeOpus4 {
+	m.assistantPrefill = text
+	return m
+}
 func (m *ClaudeOpus4) WithThinkingBudget(n int) *ClaudeOpus4  { m.thinkingBudget = n; return m }
+func (m *ClaudeOpus4) WithThinking(enabled bool) *ClaudeOpus4 { m.setThinking(enabled); return m }
 
 // NewClaudeOpus4 creates a new Claude Opus 4 model with default options
 func NewClaudeOpus4() *ClaudeOpus4 {
 	return &ClaudeOpus4{anthropicThinkingOptions{
-		anthropicOptions: anthropicOptions{maxTokens: 8192, temperature: 1.0},
+		anthropicOptions: anthropicOptions{maxTokens: defaultMaxTokens("claude-opus-4-20250514"), temperature: 1.0},
 	}}
 }
 
@@ -264,17 +350,23 @@ func (m *ClaudeSonnet45) Provider() ProviderType { return ProviderAnthropic }
 func (m *ClaudeSonnet45) SystemPrompt() string   { return m.systemPrompt }
 func (m *ClaudeSonnet45) supportsThinking() bool { return true }
 
-func (m *ClaudeSonnet45) WithMaxTokens(n int) *ClaudeSonnet45       { m.maxTokens = n; return m }
-func (m *ClaudeSonnet45) WithTemperature(t float64) *ClaudeSonnet45 { m.temperature = t; return m }
+func (m *ClaudeSonnet45) WithMaxTokens(n int) *ClaudeSonnet45       { m.setMaxTokens(n); return m }
+func (m *ClaudeSonnet45) WithTemperature(t float64) *ClaudeSonnet45 { m.setTemperature(t); return m }
 func (m *ClaudeSonnet45) WithTopP(p float64) *ClaudeSonnet45        { m.topP = p; return m }
 func (m *ClaudeSonnet45) WithTopK(k int) *ClaudeSonnet45            { m.topK = k; return m }
 func (m *ClaudeSonnet45) WithSystemPrompt(s string) *ClaudeSonnet45 { m.systemPrompt = s; return m }
+func (m *ClaudeSonnet45) WithEndUserID(id string) *ClaudeSonnet45   { m.endUserID = id; return m }
+func (m *ClaudeSonnet45) WithAssistantPrefill(text string) *ClaudeSonnet45 {
+	m.assistantPrefill = text
+	return m
+}
 func (m *ClaudeSonnet45) WithThinkingBudget(n int) *ClaudeSonnet45  { m.thinkingBudget = n; return m }
+func (m *ClaudeSonnet45) WithThinking(enabled bool) *ClaudeSonnet45 { m.setThinking(enabled); return m }
 
 // NewClaudeSonnet45 creates a new Claude Sonnet 4.5 model with default options
 func NewClaudeSonnet45() *ClaudeSonnet45 {
 	return &ClaudeSonnet45{anthropicThinkingOptions{
-		anthropicOptions: anthropicOptions{maxTokens: 8192, temperature: 1.0},
+		anthropicOptions: anthropicOptions{maxTokens: defaultMaxTokens("claude-sonnet-4-5-20250929"), temperature: 1.0},
 	}}
 }
 
@@ -286,17 +378,23 @@ func (m *ClaudeOpus45) Provider() ProviderType { return ProviderAnthropic }
 func (m *ClaudeOpus45) SystemPrompt() string   { return m.systemPrompt }
 func (m *ClaudeOpus45) supportsThinking() bool { return true }
 
-func (m *ClaudeOpus45) WithMaxTokens(n int) *ClaudeOpus45       { m.maxTokens = n; return m }
-func (m *ClaudeOpus45) WithTemperature(t float64) *ClaudeOpus45 { m.temperature = t; return m }
+func (m *ClaudeOpus45) WithMaxTokens(n int) *ClaudeOpus45       { m.setMaxTokens(n); return m }
+func (m *ClaudeOpus45) WithTemperature(t float64) *ClaudeOpus45 { m.setTemperature(t); return m }
 func (m *ClaudeOpus45) WithTopP(p float64) *ClaudeOpus45        { m.topP = p; return m }
 func (m *ClaudeOpus45) WithTopK(k int) *ClaudeOpus45            { m.topK = k; return m }
 func (m *ClaudeOpus45) WithSystemPrompt(s string) *ClaudeOpus45 { m.systemPrompt = s; return m }
+func (m *ClaudeOpus45) WithEndUserID(id string) *ClaudeOpus45   { m.endUserID = id; return m }
+func (m *ClaudeOpus45) WithAssistantPrefill(text string) *ClaudeOpus45 {
+	m.assistantPrefill = text
+	return m
+}
 func (m *ClaudeOpus45) WithThinkingBudget(n int) *ClaudeOpus45  { m.thinkingBudget = n; return m }
+func (m *ClaudeOpus45) WithThinking(enabled bool) *ClaudeOpus45 { m.setThinking(enabled); return m }
 
 // NewClaudeOpus45 creates a new Claude Opus 4.5 model with default options
 func NewClaudeOpus45() *ClaudeOpus45 {
 	return &ClaudeOpus45{anthropicThinkingOptions{
-		anthropicOptions: anthropicOptions{maxTokens: 8192, temperature: 1.0},
+		anthropicOptions: anthropicOptions{maxTokens: defaultMaxTokens("claude-opus-4-5-20251124"), temperature: 1.0},
 	}}
 }
 
@@ -308,17 +406,23 @@ func (m *ClaudeHaiku45) Provider() ProviderType { return ProviderAnthropic }
 func (m *ClaudeHaiku45) SystemPrompt() string   { return m.systemPrompt }
 func (m *ClaudeHaiku45) supportsThinking() bool { return true }
 
-func (m *ClaudeHaiku45) WithMaxTokens(n int) *ClaudeHaiku45       { m.maxTokens = n; return m }
-func (m *ClaudeHaiku45) WithTemperature(t float64) *ClaudeHaiku45 { m.temperature = t; return m }
+func (m *ClaudeHaiku45) WithMaxTokens(n int) *ClaudeHaiku45       { m.setMaxTokens(n); return m }
+func (m *ClaudeHaiku45) WithTemperature(t float64) *ClaudeHaiku45 { m.setTemperature(t); return m }
 func (m *ClaudeHaiku45) WithTopP(p float64) *ClaudeHaiku45        { m.topP = p; return m }
 func (m *ClaudeHaiku45) WithTopK(k int) *ClaudeHaiku45            { m.topK = k; return m }
 func (m *ClaudeHaiku45) WithSystemPrompt(s string) *ClaudeHaiku45 { m.systemPrompt = s; return m }
+func (m *ClaudeHaiku45) WithEndUserID(id string) *ClaudeHaiku45   { m.endUserID = id; return m }
+func (m *ClaudeHaiku45) WithAssistantPrefill(text string) *ClaudeHaiku45 {
+	m.assistantPrefill = text
+	return m
+}
 func (m *ClaudeHaiku45) WithThinkingBudget(n int) *ClaudeHaiku45  { m.thinkingBudget = n; return m }
+func (m *ClaudeHaiku45) WithThinking(enabled bool) *ClaudeHaiku45 { m.setThinking(enabled); return m }
 
 // NewClaudeHaiku45 creates a new Claude Haiku 4.5 model with default options
 func NewClaudeHaiku45() *ClaudeHaiku45 {
 	return &ClaudeHaiku45{anthropicThinkingOptions{
-		anthropicOptions: anthropicOptions{maxTokens: 8192, temperature: 1.0},
+		anthropicOptions: anthropicOptions{maxTokens: defaultMaxTokens("claude-haiku-4-5-20251015"), temperature: 1.0},
 	}}
 }
 
@@ -326,6 +430,37 @@ func NewClaudeHaiku45() *ClaudeHaiku45 {
 // ANTHROPIC PROVIDER CLIENT
 // ============================================================================
 
+// anthropicEndUserModel is an interface for models that carry an end-user identifier
+type anthropicEndUserModel interface {
+	endUser() string
+}
+
+// anthropicPrefillModel is an interface for models that carry an assistant prefill
+type anthropicPrefillModel interface {
+	prefill() string
+}
+
+// anthropicPrefill returns the assistant prefill of a model, or "" if it has
+// none. Trailing whitespace is removed since the API rejects it in a final
+// assistant turn.
+func anthropicPrefill(model Model) string {
+	if m, ok := model.(anthropicPrefillModel); ok {
+		return strings.TrimRight(m.prefill(), " \t\r\n")
+	}
+	return ""
+}
+
+// anthropicOptionsModel is an interface for models that carry Anthropic options
+type anthropicOptionsModel interface {
+	baseOptions() *anthropicOptions
+}
+
+// anthropicBudgetModel is an interface for models that carry an extended
+// thinking budget
+type anthropicBudgetModel interface {
+	budget() int
+}
+
 // anthropicThinkingModel is an interface for models that support extended thinking
 type anthropicThinkingModel interface {
 	Model
@@ -335,9 +470,17 @@ type anthropicThinkingModel interface {
 // anthropicClient implements the Provider interface for Anthropic
 type anthropicClient struct {
 	client      anthropic.Client
-	timeout     time.Duration
+	timeout     time.Duration // Zero uses the model's recommended timeout
 	logger      Logger
 	rateLimiter *rateLimiter
+
+	defaultMaxTokens   int
+	defaultTemperature *float64
+
+	// Assistant prefills of submitted batches, by batch and custom ID, to
+	// restore in their results like the synchronous path does
+	batchPrefillsMu sync.Mutex
+	batchPrefills   map[string]map[string]string
 }
 
 // newAnthropicClient creates a new Anthropic client using the official SDK
@@ -348,30 +491,122 @@ func newAnthropicClient(config *AnthropicConfig, logger Logger) (*anthropicClien
 
 	client := anthropic.NewClient(option.WithAPIKey(config.APIKey))
 
-	timeout := config.Timeout
-	if timeout == 0 {
-		timeout = defaultTimeout()
-	}
-
 	return &anthropicClient{
-		client:      client,
-		timeout:     timeout,
-		logger:      logger,
-		rateLimiter: newRateLimiter(config.RateLimiter, logger),
+		client:             client,
+		timeout:            config.Timeout,
+		logger:             logger,
+		rateLimiter:        newRateLimiter(ProviderAnthropic, config.RateLimiter, logger),
+		defaultMaxTokens:   config.DefaultMaxTokens,
+		defaultTemperature: config.DefaultTemperature,
 	}, nil
 }
 
 // Generate generates text using Anthropic's API
-func (c *anthropicClient) Generate(ctx context.Context, model Model, prompt string) (*GenerationResponse, error) {
+func (c *anthropicClient) Generate(ctx context.Context, model Model, prompt string, genOpts ...GenerateOption) (*GenerationResponse, error) {
 	// Verify model is for Anthropic
 	if model.Provider() != ProviderAnthropic {
 		return nil, fmt.Errorf("model %s is not an Anthropic model", model.ModelName())
 	}
 
+	reqOpts := newGenerateOptions(genOpts)
+	logger := reqOpts.logger(c.logger)
+
 	// Set timeout
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(c.timeout, ProviderAnthropic, model.ModelName()))
 	defer cancel()
 
+	params, hasThinking, err := c.buildParams(model, reqOpts.systemPromptFor(model), prompt)
+	if err != nil {
+		return nil, err
+	}
+	c.applyDeterminism(&params, model, hasThinking, reqOpts)
+	if len(reqOpts.stopSequences) > 0 {
+		params.StopSequences = reqOpts.stopSequences
+	}
+	body, err := reqOpts.encodeRequest(params)
+	if err != nil {
+		return nil, err
+	}
+	reqOpts.inspectRequest(ProviderAnthropic, body)
+	if reqOpts.dryRun {
+		return dryRunResponse(model, body)
+	}
+
+	logger.Debug().
+		Str("model", model.ModelName()).
+		Bool("has_thinking", hasThinking).
+		Float("temperature", params.Temperature.Value).
+		Float("top_p", params.TopP.Value).
+		Msg("Making Anthropic API request")
+
+	// Make request with rate limit handling
+	var resp *anthropic.Message
+	var httpResp *http.Response
+	reqOptions := []option.RequestOption{option.WithResponseInto(&httpResp)}
+	if reqOpts.noRetry {
+		reqOptions = append(reqOptions, option.WithMaxRetries(0))
+	}
+	extra, err := reqOpts.extraBodyFields(params)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range extra {
+		reqOptions = append(reqOptions, option.WithJSONSet(k, v))
+	}
+	err = c.rateLimiter.ExecuteRequest(ctx, model.ModelName(), reqOpts, func() error {
+		var reqErr error
+		resp, reqErr = c.client.Messages.New(ctx, params, reqOptions...)
+		return reqErr
+	})
+	if err != nil {
+		logger.Error().
+			Err(err).
+			Str("model", model.ModelName()).
+			Str("prompt_preview", reqOpts.promptPreview(prompt)).
+			Msg("Anthropic generation failed")
+		return nil, fmt.Errorf("anthropic generation failed: %w", err)
+	}
+
+	result, err := buildAnthropicResponse(resp, reqOpts.minimalResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	// The reply continues the prefill, so restore it to return the full text.
+	// A refusal has no reply to continue.
+	if !result.Refused() {
+		result.Text = anthropicPrefill(model) + result.Text
+	}
+
+	// Keep the request ID for correlating support tickets, and the rate
+	// limit headers for adaptive throttling
+	if httpResp != nil && !reqOpts.minimalResponse {
+		if requestID := httpResp.Header.Get("request-id"); requestID != "" {
+			result.Metadata["request_id"] = requestID
+		}
+		addRateLimitHeaders(result.Metadata, httpResp.Header)
+	}
+
+	if reqOpts.includeRaw {
+		result.RawJSON = json.RawMessage(resp.RawJSON())
+	}
+
+	logger.Debug().
+		Str("model", string(resp.Model)).
+		Int64("input_tokens", resp.Usage.InputTokens).
+		Int64("output_tokens", resp.Usage.OutputTokens).
+		Int64("cache_read_input_tokens", resp.Usage.CacheReadInputTokens).
+		Int64("cache_creation_input_tokens", resp.Usage.CacheCreationInputTokens).
+		Int("total_tokens", result.Usage.TotalTokens).
+		Bool("has_thinking", result.Metadata["thinking"] != "").
+		Msg("Anthropic generation completed")
+
+	return result, nil
+}
+
+// buildParams builds the Messages API parameters for a model and prompt.
+// Reports whether extended thinking is enabled.
+func (c *anthropicClient) buildParams(model Model, system, prompt string) (anthropic.MessageNewParams, bool, error) {
 	// Build request parameters
 	params := anthropic.MessageNewParams{
 		Model:     anthropic.Model(model.ModelName()),
@@ -381,211 +616,114 @@ func (c *anthropicClient) Generate(ctx context.Context, model Model, prompt stri
 		},
 	}
 
+	// End with an assistant turn so the reply continues from the prefill
+	if prefill := anthropicPrefill(model); prefill != "" {
+		params.Messages = append(params.Messages, anthropic.NewAssistantMessage(anthropic.NewTextBlock(prefill)))
+	}
+
 	// Add system prompt if provided
-	if model.SystemPrompt() != "" {
+	if system != "" {
 		params.System = []anthropic.TextBlockParam{
-			{Text: model.SystemPrompt()},
+			{Text: system},
 		}
 	}
 
-	// Apply options based on model type
-	var hasThinking bool
-	switch m := model.(type) {
-	// Standard models
-	case *Claude35Sonnet:
-		if m.maxTokens > 0 {
-			params.MaxTokens = int64(m.maxTokens)
-		}
-		if m.temperature > 0 {
-			params.Temperature = anthropic.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = anthropic.Float(m.topP)
-		}
-		if m.topK > 0 {
-			params.TopK = anthropic.Int(int64(m.topK))
-		}
-	case *Claude35Haiku:
-		if m.maxTokens > 0 {
-			params.MaxTokens = int64(m.maxTokens)
-		}
-		if m.temperature > 0 {
-			params.Temperature = anthropic.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = anthropic.Float(m.topP)
-		}
-		if m.topK > 0 {
-			params.TopK = anthropic.Int(int64(m.topK))
-		}
-	case *Claude3Opus:
-		if m.maxTokens > 0 {
-			params.MaxTokens = int64(m.maxTokens)
-		}
-		if m.temperature > 0 {
-			params.Temperature = anthropic.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = anthropic.Float(m.topP)
-		}
-		if m.topK > 0 {
-			params.TopK = anthropic.Int(int64(m.topK))
-		}
-	case *Claude3Haiku:
-		if m.maxTokens > 0 {
-			params.MaxTokens = int64(m.maxTokens)
-		}
-		if m.temperature > 0 {
-			params.Temperature = anthropic.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = anthropic.Float(m.topP)
-		}
-		if m.topK > 0 {
-			params.TopK = anthropic.Int(int64(m.topK))
-		}
-	case *Claude3Sonnet:
-		if m.maxTokens > 0 {
-			params.MaxTokens = int64(m.maxTokens)
-		}
-		if m.temperature > 0 {
-			params.Temperature = anthropic.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = anthropic.Float(m.topP)
-		}
-		if m.topK > 0 {
-			params.TopK = anthropic.Int(int64(m.topK))
-		}
+	// Apply the model's own options
+	if m, ok := model.(anthropicOptionsModel); ok {
+		m.baseOptions().applyTo(&params)
+	}
+	var budget int
+	if m, ok := model.(anthropicBudgetModel); ok {
+		budget = m.budget()
+	}
+	hasThinking := budget > 0
+	if hasThinking {
+		params.Thinking = anthropic.ThinkingConfigParamOfEnabled(int64(budget))
+	}
 
-	// Extended thinking models
-	case *Claude37Sonnet:
-		if m.maxTokens > 0 {
-			params.MaxTokens = int64(m.maxTokens)
-		}
-		if m.temperature > 0 {
-			params.Temperature = anthropic.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = anthropic.Float(m.topP)
-		}
-		if m.topK > 0 {
-			params.TopK = anthropic.Int(int64(m.topK))
-		}
-		if m.thinkingBudget > 0 {
-			hasThinking = true
-			params.Thinking = anthropic.ThinkingConfigParamOfEnabled(int64(m.thinkingBudget))
-		}
-	case *ClaudeSonnet4:
-		if m.maxTokens > 0 {
-			params.MaxTokens = int64(m.maxTokens)
-		}
-		if m.temperature > 0 {
-			params.Temperature = anthropic.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = anthropic.Float(m.topP)
-		}
-		if m.topK > 0 {
-			params.TopK = anthropic.Int(int64(m.topK))
-		}
-		if m.thinkingBudget > 0 {
-			hasThinking = true
-			params.Thinking = anthropic.ThinkingConfigParamOfEnabled(int64(m.thinkingBudget))
-		}
-	case *ClaudeOpus4:
-		if m.maxTokens > 0 {
-			params.MaxTokens = int64(m.maxTokens)
-		}
-		if m.temperature > 0 {
-			params.Temperature = anthropic.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = anthropic.Float(m.topP)
-		}
-		if m.topK > 0 {
-			params.TopK = anthropic.Int(int64(m.topK))
-		}
-		if m.thinkingBudget > 0 {
-			hasThinking = true
-			params.Thinking = anthropic.ThinkingConfigParamOfEnabled(int64(m.thinkingBudget))
-		}
-	case *ClaudeSonnet45:
-		if m.maxTokens > 0 {
-			params.MaxTokens = int64(m.maxTokens)
-		}
-		if m.temperature > 0 {
-			params.Temperature = anthropic.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = anthropic.Float(m.topP)
-		}
-		if m.topK > 0 {
-			params.TopK = anthropic.Int(int64(m.topK))
-		}
-		if m.thinkingBudget > 0 {
-			hasThinking = true
-			params.Thinking = anthropic.ThinkingConfigParamOfEnabled(int64(m.thinkingBudget))
-		}
-	case *ClaudeOpus45:
-		if m.maxTokens > 0 {
-			params.MaxTokens = int64(m.maxTokens)
-		}
-		if m.temperature > 0 {
-			params.Temperature = anthropic.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = anthropic.Float(m.topP)
-		}
-		if m.topK > 0 {
-			params.TopK = anthropic.Int(int64(m.topK))
-		}
-		if m.thinkingBudget > 0 {
-			hasThinking = true
-			params.Thinking = anthropic.ThinkingConfigParamOfEnabled(int64(m.thinkingBudget))
-		}
-	case *ClaudeHaiku45:
-		if m.maxTokens > 0 {
-			params.MaxTokens = int64(m.maxTokens)
-		}
-		if m.temperature > 0 {
-			params.Temperature = anthropic.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = anthropic.Float(m.topP)
-		}
-		if m.topK > 0 {
-			params.TopK = anthropic.Int(int64(m.topK))
+	// The Messages API rejects an assistant prefill with extended thinking
+	if hasThinking && anthropicPrefill(model) != "" {
+		return anthropic.MessageNewParams{}, false, fmt.Errorf("%w: an assistant prefill can't be combined with extended thinking", ErrOptionOutOfRange)
+	}
+
+	// Attach the end-user identifier for abuse tracking
+	if m, ok := model.(anthropicEndUserModel); ok && m.endUser() != "" {
+		params.Metadata = anthropic.MetadataParam{UserID: anthropic.String(m.endUser())}
+	}
+
+	// Apply config-level defaults to options the model didn't set explicitly.
+	// Extended thinking requires the default temperature, so skip it there.
+	if m, ok := model.(explicitOptionsModel); ok {
+		explicit := m.explicitOptions()
+		if !explicit.maxTokens && c.defaultMaxTokens > 0 {
+			params.MaxTokens = int64(c.defaultMaxTokens)
 		}
-		if m.thinkingBudget > 0 {
-			hasThinking = true
-			params.Thinking = anthropic.ThinkingConfigParamOfEnabled(int64(m.thinkingBudget))
+		if !explicit.temperature && c.defaultTemperature != nil && !hasThinking {
+			params.Temperature = anthropic.Float(*c.defaultTemperature)
 		}
 	}
 
-	c.logger.Debug().
-		Str("model", model.ModelName()).
-		Bool("has_thinking", hasThinking).
-		Msg("Making Anthropic API request")
+	if err := validateSampling(ProviderAnthropic, params.Temperature.Value, 1, params.TopP.Value); err != nil {
+		return anthropic.MessageNewParams{}, false, err
+	}
+	// The thinking budget is part of max_tokens, so it must leave room for the reply
+	if hasThinking && int64(budget) >= params.MaxTokens {
+		return anthropic.MessageNewParams{}, false, fmt.Errorf("%w: thinking budget %d must be less than max tokens %d", ErrOptionOutOfRange, budget, params.MaxTokens)
+	}
+	return params, hasThinking, nil
+}
 
-	// Make request with rate limit handling
-	var resp *anthropic.Message
-	err := c.rateLimiter.Execute(ctx, func() error {
-		var reqErr error
-		resp, reqErr = c.client.Messages.New(ctx, params)
-		return reqErr
-	})
-	if err != nil {
-		c.logger.Error().
-			Err(err).
-			Str("model", model.ModelName()).
-			Str("prompt_preview", truncateString(prompt, 100)).
-			Msg("Anthropic generation failed")
-		return nil, fmt.Errorf("anthropic generation failed: %w", err)
+// applyTo sets the sampling options of a model on the request
+func (o *anthropicOptions) applyTo(params *anthropic.MessageNewParams) {
+	if o.maxTokens > 0 {
+		params.MaxTokens = int64(o.maxTokens)
+	}
+	if o.temperatureSet || o.temperature > 0 {
+		params.Temperature = anthropic.Float(o.temperature)
+	}
+	if o.topP > 0 {
+		params.TopP = anthropic.Float(o.topP)
 	}
+	if o.topK > 0 {
+		params.TopK = anthropic.Int(int64(o.topK))
+	}
+}
 
-	if len(resp.Content) == 0 {
-		return nil, fmt.Errorf("no response content returned from Anthropic")
+// applyDeterminism sets a temperature of 0 in deterministic mode. Anthropic has
+// no seed parameter, and extended thinking requires the default temperature.
+func (c *anthropicClient) applyDeterminism(params *anthropic.MessageNewParams, model Model, hasThinking bool, reqOpts *generateOptions) {
+	if _, ok := reqOpts.samplingSeed(); ok {
+		warnNotDeterministic(c.logger, model, "Anthropic doesn't accept a seed")
+	}
+	if !reqOpts.deterministic {
+		return
+	}
+	if hasThinking {
+		warnNotDeterministic(c.logger, model, "extended thinking requires the default temperature")
+		return
+	}
+	params.Temperature = anthropic.Float(0)
+}
+
+// buildAnthropicResponse converts a Messages API response into a GenerationResponse
+func buildAnthropicResponse(resp *anthropic.Message, minimal bool) (*GenerationResponse, error) {
+	// Anthropic reports cache reads and writes separately from input tokens;
+	// fold them back in so PromptTokens covers the whole prompt like the other
+	// providers. Anthropic bills extended thinking as output tokens without a
+	// separate count, so ReasoningTokens is left at zero.
+	promptTokens := resp.Usage.InputTokens + resp.Usage.CacheReadInputTokens + resp.Usage.CacheCreationInputTokens
+	usage := TokenUsage{
+		PromptTokens:       int(promptTokens),
+		CompletionTokens:   int(resp.Usage.OutputTokens),
+		TotalTokens:        int(promptTokens + resp.Usage.OutputTokens),
+		CachedPromptTokens: int(resp.Usage.CacheReadInputTokens),
+		CacheWriteTokens:   int(resp.Usage.CacheCreationInputTokens),
+	}
+
+	// A refusal may come back without any content
+	refused := resp.StopReason == anthropic.StopReasonRefusal
+	if len(resp.Content) == 0 && !refused {
+		return nil, withPartialUsage(fmt.Errorf("no response content returned from Anthropic"), usage)
 	}
 
 	// Extract text content and thinking content
@@ -600,8 +738,8 @@ func (c *anthropicClient) Generate(ctx context.Context, model Model, prompt stri
 		}
 	}
 
-	if text == "" {
-		return nil, fmt.Errorf("no text content found in Anthropic response")
+	if text == "" && !refused {
+		return nil, withPartialUsage(fmt.Errorf("no text content found in Anthropic response"), usage)
 	}
 
 	// Build response
@@ -609,15 +747,15 @@ func (c *anthropicClient) Generate(ctx context.Context, model Model, prompt stri
 		Text:         text,
 		Model:        string(resp.Model),
 		FinishReason: string(resp.StopReason),
-		Usage: TokenUsage{
-			PromptTokens:     int(resp.Usage.InputTokens),
-			CompletionTokens: int(resp.Usage.OutputTokens),
-			TotalTokens:      int(resp.Usage.InputTokens + resp.Usage.OutputTokens),
-		},
-		Metadata: map[string]string{
-			"provider": "anthropic",
-			"model":    string(resp.Model),
-		},
+		StopSequence: resp.StopSequence,
+		Usage:        usage,
+	}
+	if minimal {
+		return result, nil
+	}
+	result.Metadata = map[string]string{
+		"provider": "anthropic",
+		"model":    string(resp.Model),
 	}
 
 	// Add thinking content to metadata if present
@@ -625,15 +763,171 @@ func (c *anthropicClient) Generate(ctx context.Context, model Model, prompt stri
 		result.Metadata["thinking"] = thinkingText
 	}
 
+	return result, nil
+}
+
+// SubmitBatch starts a Message Batches job for the requests
+func (c *anthropicClient) SubmitBatch(ctx context.Context, requests []BatchRequest) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(c.timeout, ProviderAnthropic, ""))
+	defer cancel()
+
+	batchRequests := make([]anthropic.MessageBatchNewParamsRequest, 0, len(requests))
+	prefills := make(map[string]string)
+	for _, req := range requests {
+		if req.Model.Provider() != ProviderAnthropic {
+			return "", fmt.Errorf("model %s is not an Anthropic model", req.Model.ModelName())
+		}
+
+		// Batch params mirror the Messages API params, so convert through JSON
+		params, _, err := c.buildParams(req.Model, req.Model.SystemPrompt(), req.Prompt)
+		if err != nil {
+			return "", err
+		}
+		data, err := json.Marshal(params)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode batch request %s: %w", req.CustomID, err)
+		}
+		var batchParams anthropic.MessageBatchNewParamsRequestParams
+		if err := json.Unmarshal(data, &batchParams); err != nil {
+			return "", fmt.Errorf("failed to encode batch request %s: %w", req.CustomID, err)
+		}
+
+		batchRequests = append(batchRequests, anthropic.MessageBatchNewParamsRequest{
+			CustomID: req.CustomID,
+			Params:   batchParams,
+		})
+		if prefill := anthropicPrefill(req.Model); prefill != "" {
+			prefills[req.CustomID] = prefill
+		}
+	}
+
 	c.logger.Debug().
-		Str("model", string(resp.Model)).
-		Int64("input_tokens", resp.Usage.InputTokens).
-		Int64("output_tokens", resp.Usage.OutputTokens).
-		Int64("total_tokens", resp.Usage.InputTokens+resp.Usage.OutputTokens).
-		Bool("has_thinking", thinkingText != "").
-		Msg("Anthropic generation completed")
+		Int("requests", len(requests)).
+		Msg("Submitting Anthropic batch")
 
-	return result, nil
+	var batch *anthropic.MessageBatch
+	err := c.rateLimiter.Execute(ctx, "", func() error {
+		var reqErr error
+		batch, reqErr = c.client.Messages.Batches.New(ctx, anthropic.MessageBatchNewParams{
+			Requests: batchRequests,
+		})
+		return reqErr
+	})
+	if err != nil {
+		c.logger.Error().
+			Err(err).
+			Int("requests", len(requests)).
+			Msg("Anthropic batch submission failed")
+		return "", fmt.Errorf("anthropic batch submission failed: %w", err)
+	}
+
+	if len(prefills) > 0 {
+		c.batchPrefillsMu.Lock()
+		if c.batchPrefills == nil {
+			c.batchPrefills = make(map[string]map[string]string)
+		}
+		c.batchPrefills[batch.ID] = prefills
+		c.batchPrefillsMu.Unlock()
+	}
+
+	c.logger.Debug().
+		Str("batch_id", batch.ID).
+		Msg("Anthropic batch submitted")
+
+	return batch.ID, nil
+}
+
+// GetBatchStatus returns the status of a Message Batches job
+func (c *anthropicClient) GetBatchStatus(ctx context.Context, batchID string) (*BatchStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(c.timeout, ProviderAnthropic, ""))
+	defer cancel()
+
+	var batch *anthropic.MessageBatch
+	err := c.rateLimiter.Execute(ctx, "", func() error {
+		var reqErr error
+		batch, reqErr = c.client.Messages.Batches.Get(ctx, batchID)
+		return reqErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Anthropic batch %s: %w", batchID, err)
+	}
+
+	counts := batch.RequestCounts
+	state := BatchInProgress
+	if batch.ProcessingStatus == anthropic.MessageBatchProcessingStatusEnded {
+		state = BatchCompleted
+	}
+
+	return &BatchStatus{
+		ID:             batch.ID,
+		Provider:       ProviderAnthropic,
+		State:          state,
+		ProviderStatus: string(batch.ProcessingStatus),
+		Total:          int(counts.Processing + counts.Succeeded + counts.Errored + counts.Canceled + counts.Expired),
+		Completed:      int(counts.Succeeded),
+		Failed:         int(counts.Errored + counts.Canceled + counts.Expired),
+	}, nil
+}
+
+// GetBatchResults streams and converts the results of a Message Batches job.
+// Assistant prefills are restored for batches submitted through this client.
+func (c *anthropicClient) GetBatchResults(ctx context.Context, batchID string) ([]BatchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(c.timeout, ProviderAnthropic, ""))
+	defer cancel()
+
+	c.batchPrefillsMu.Lock()
+	prefills := c.batchPrefills[batchID]
+	c.batchPrefillsMu.Unlock()
+
+	var results []BatchResult
+	err := c.rateLimiter.Execute(ctx, "", func() error {
+		stream := c.client.Messages.Batches.ResultsStreaming(ctx, batchID)
+		defer stream.Close()
+
+		results = nil
+		for stream.Next() {
+			item := stream.Current()
+			result := BatchResult{CustomID: item.CustomID}
+
+			switch item.Result.Type {
+			case "succeeded":
+				response, err := buildAnthropicResponse(&item.Result.Message, false)
+				if err != nil {
+					result.Error = err.Error()
+					break
+				}
+				if !response.Refused() {
+					response.Text = prefills[item.CustomID] + response.Text
+				}
+				result.Response = response
+			case "errored":
+				result.Error = item.Result.Error.Error.Message
+			default:
+				result.Error = item.Result.Type
+			}
+			results = append(results, result)
+		}
+		return stream.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Anthropic batch results %s: %w", batchID, err)
+	}
+
+	return results, nil
+}
+
+// rateLimitStats returns the rate limit counters of the Anthropic client
+func (c *anthropicClient) rateLimitStats() RateLimitStats { return c.rateLimiter.Stats() }
+
+// Ping checks connectivity and credentials by listing the available models
+func (c *anthropicClient) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if _, err := c.client.Models.List(ctx, anthropic.ModelListParams{Limit: anthropic.Int(1)}); err != nil {
+		return fmt.Errorf("anthropic ping failed: %w", err)
+	}
+	return nil
 }
 
 // Health checks the health of the Anthropic client
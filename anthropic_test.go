@@ -0,0 +1,127 @@
+package lingo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/anthropics/anthropic-sdk-go/option"
+)
+
+func TestAnthropicTemperature(t *testing.T) {
+	zero, low := 0.0, 0.3
+	tests := []struct {
+		name        string
+		model       Model
+		defaultTemp *float64
+		want        float64
+		sent        bool
+	}{
+		{"default", NewClaudeSonnet45(), nil, 1, true},
+		{"zero value", &ClaudeSonnet45{}, nil, 0, false},
+		{"explicit zero", NewClaudeSonnet45().WithTemperature(0), nil, 0, true},
+		{"non-zero", NewClaudeSonnet45().WithTemperature(0.7), nil, 0.7, true},
+		{"config default", NewClaudeSonnet45(), &low, 0.3, true},
+		{"config default of zero", NewClaudeSonnet45(), &zero, 0, true},
+		{"explicit over config default", NewClaudeSonnet45().WithTemperature(0.7), &zero, 0.7, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &anthropicClient{defaultTemperature: tt.defaultTemp}
+			params, _, err := c.buildParams(tt.model, "", "hi")
+			if err != nil {
+				t.Fatalf("buildParams: %v", err)
+			}
+			if params.Temperature.Valid() != tt.sent || params.Temperature.Value != tt.want {
+				t.Errorf("Temperature = %v, want %v (sent %t)", params.Temperature, tt.want, tt.sent)
+			}
+		})
+	}
+}
+
+func TestAnthropicDryRunIncludesExtraBody(t *testing.T) {
+	g, err := New([]ProviderConfig{&AnthropicConfig{APIKey: "test"}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer g.Close()
+
+	tests := []struct {
+		name  string
+		opts  []GenerateOption
+		field string
+		want  string
+	}{
+		{"added", []GenerateOption{WithExtraBody(map[string]any{"service_tier": "auto"})}, "service_tier", `"auto"`},
+		{"set field kept", []GenerateOption{WithExtraBody(map[string]any{"max_tokens": 1})}, "max_tokens", "100"},
+		{"set field overridden", []GenerateOption{WithExtraBody(map[string]any{"max_tokens": 1}), WithExtraBodyOverrides()}, "max_tokens", "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]GenerateOption{WithDryRun(true)}, tt.opts...)
+			resp, err := g.Generate(context.Background(), NewClaudeSonnet45().WithMaxTokens(100), "Hi", opts...)
+			if err != nil {
+				t.Fatalf("Generate: %v", err)
+			}
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal([]byte(resp.Metadata["dry_run_request"]), &fields); err != nil {
+				t.Fatalf("dry run body: %v", err)
+			}
+			if got := string(fields[tt.field]); got != tt.want {
+				t.Errorf("%s = %s, want %s", tt.field, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnthropicBatchResultsRestorePrefill(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/messages/batches":
+			fmt.Fprint(w, `{"id":"batch_1","type":"message_batch","processing_status":"in_progress"}`)
+		case r.URL.Path == "/v1/messages/batches/batch_1/results":
+			for _, id := range []string{"prefilled", "plain"} {
+				fmt.Fprintf(w, `{"custom_id":%q,"result":{"type":"succeeded","message":{"id":"msg","type":"message","role":"assistant","model":"claude-sonnet-4-5","content":[{"type":"text","text":"\"a\": 1}"}],"stop_reason":"end_turn","usage":{"input_tokens":1,"output_tokens":1}}}}`+"\n", id)
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := &anthropicClient{
+		client:      anthropic.NewClient(option.WithAPIKey("test"), option.WithBaseURL(srv.URL)),
+		logger:      &NopLogger{},
+		rateLimiter: newRateLimiter(ProviderAnthropic, nil, &NopLogger{}),
+	}
+	batchID, err := c.SubmitBatch(context.Background(), []BatchRequest{
+		{CustomID: "prefilled", Model: NewClaudeSonnet45().WithAssistantPrefill("{"), Prompt: "Hi"},
+		{CustomID: "plain", Model: NewClaudeSonnet45(), Prompt: "Hi"},
+	})
+	if err != nil {
+		t.Fatalf("SubmitBatch: %v", err)
+	}
+
+	results, err := c.GetBatchResults(context.Background(), batchID)
+	if err != nil {
+		t.Fatalf("GetBatchResults: %v", err)
+	}
+	want := map[string]string{"prefilled": `{"a": 1}`, "plain": `"a": 1}`}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for _, r := range results {
+		if r.Response == nil {
+			t.Fatalf("result %s failed: %s", r.CustomID, r.Error)
+		}
+		if r.Response.Text != want[r.CustomID] {
+			t.Errorf("result %s text = %q, want %q", r.CustomID, r.Response.Text, want[r.CustomID])
+		}
+	}
+}
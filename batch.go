@@ -0,0 +1,174 @@
+package lingo
+
+import (
+	"context"
+	"fmt"
+)
+
+// ============================================================================
+// BATCH TYPES
+// ============================================================================
+
+// BatchRequest is a single request within an asynchronous batch job
+type BatchRequest struct {
+	// CustomID identifies the request in the results; must be unique within a batch
+	CustomID string
+	// Model is the model to use, carrying its own generation options
+	Model Model
+	// Prompt is the user prompt
+	Prompt string
+}
+
+// BatchState is the normalized state of a batch job
+type BatchState string
+
+const (
+	// BatchInProgress means the batch is queued, validating or processing
+	BatchInProgress BatchState = "in_progress"
+	// BatchCompleted means processing has ended and results are available
+	BatchCompleted BatchState = "completed"
+	// BatchFailed means the batch could not be processed
+	BatchFailed BatchState = "failed"
+	// BatchCancelled means the batch was cancelled
+	BatchCancelled BatchState = "cancelled"
+	// BatchExpired means the batch did not finish within the completion window
+	BatchExpired BatchState = "expired"
+)
+
+// BatchStatus describes the progress of a batch job
+type BatchStatus struct {
+	// ID is the provider's batch identifier
+	ID string
+	// Provider is the provider running the batch
+	Provider ProviderType
+	// State is the normalized batch state
+	State BatchState
+	// ProviderStatus is the provider's own status string
+	ProviderStatus string
+	// Total is the number of requests in the batch
+	Total int
+	// Completed is the number of requests that succeeded
+	Completed int
+	// Failed is the number of requests that errored, expired or were cancelled
+	Failed int
+}
+
+// BatchResult is the outcome of a single request within a batch
+type BatchResult struct {
+	// CustomID matches the BatchRequest.CustomID
+	CustomID string
+	// Response is the generated response, nil if the request failed
+	Response *GenerationResponse
+	// Error describes why the request failed, empty on success
+	Error string
+}
+
+// BatchProvider is implemented by providers that support asynchronous batch jobs
+type BatchProvider interface {
+	SubmitBatch(ctx context.Context, requests []BatchRequest) (string, error)
+	GetBatchStatus(ctx context.Context, batchID string) (*BatchStatus, error)
+	GetBatchResults(ctx context.Context, batchID string) ([]BatchResult, error)
+}
+
+// BatchGateway defines the interface for asynchronous batch operations.
+// Batch jobs are billed at a discount but may take up to 24 hours to complete.
+type BatchGateway interface {
+	// SubmitBatch submits requests as a batch job and returns the batch ID.
+	// All requests must use models from the same provider.
+	SubmitBatch(ctx context.Context, requests []BatchRequest) (string, error)
+
+	// GetBatchStatus returns the status of a batch job
+	GetBatchStatus(ctx context.Context, provider ProviderType, batchID string) (*BatchStatus, error)
+
+	// GetBatchResults returns the results of a completed batch job
+	GetBatchResults(ctx context.Context, provider ProviderType, batchID string) ([]BatchResult, error)
+}
+
+// ============================================================================
+// GATEWAY BATCH METHODS
+// ============================================================================
+
+// SubmitBatch submits requests as an asynchronous batch job and returns the batch ID
+func (g *LLMGateway) SubmitBatch(ctx context.Context, requests []BatchRequest) (string, error) {
+	if len(requests) == 0 {
+		return "", fmt.Errorf("batch must contain at least one request")
+	}
+
+	seen := make(map[string]bool, len(requests))
+	for i, req := range requests {
+		if req.CustomID == "" {
+			return "", fmt.Errorf("batch request %d is missing a custom ID", i)
+		}
+		if req.Model == nil {
+			return "", fmt.Errorf("batch request %s is missing a model", req.CustomID)
+		}
+		if req.Model.Provider() != requests[0].Model.Provider() {
+			return "", fmt.Errorf("batch requests must all use the same provider: got %s and %s", requests[0].Model.Provider(), req.Model.Provider())
+		}
+		if seen[req.CustomID] {
+			return "", fmt.Errorf("duplicate batch custom ID: %s", req.CustomID)
+		}
+		seen[req.CustomID] = true
+	}
+
+	provider := requests[0].Model.Provider()
+	batcher, err := g.batchProvider(provider)
+	if err != nil {
+		return "", err
+	}
+
+	batchID, err := batcher.SubmitBatch(ctx, requests)
+	if err != nil {
+		return "", newProviderError(provider, "", err)
+	}
+	return batchID, nil
+}
+
+// GetBatchStatus returns the status of a batch job
+func (g *LLMGateway) GetBatchStatus(ctx context.Context, provider ProviderType, batchID string) (*BatchStatus, error) {
+	batcher, err := g.batchProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := batcher.GetBatchStatus(ctx, batchID)
+	if err != nil {
+		return nil, newProviderError(provider, "", err)
+	}
+	return status, nil
+}
+
+// GetBatchResults returns the results of a completed batch job
+func (g *LLMGateway) GetBatchResults(ctx context.Context, provider ProviderType, batchID string) ([]BatchResult, error) {
+	batcher, err := g.batchProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := batcher.GetBatchResults(ctx, batchID)
+	if err != nil {
+		return nil, newProviderError(provider, "", err)
+	}
+
+	for _, r := range results {
+		if r.Response != nil {
+			r.Response.Provider = provider
+		}
+	}
+	return results, nil
+}
+
+// batchProvider looks up a registered provider that supports batch jobs
+func (g *LLMGateway) batchProvider(provider ProviderType) (BatchProvider, error) {
+	client, err := g.lookupProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	batcher, ok := client.(BatchProvider)
+	if !ok {
+		return nil, fmt.Errorf("provider %s does not support batch jobs", provider)
+	}
+
+	return batcher, nil
+}
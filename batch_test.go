@@ -0,0 +1,40 @@
+package lingo
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSubmitBatchValidatesRequests(t *testing.T) {
+	g, err := New([]ProviderConfig{&OpenAIConfig{APIKey: "test"}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer g.Close()
+
+	tests := []struct {
+		name     string
+		requests []BatchRequest
+		want     string
+	}{
+		{"missing model", []BatchRequest{{CustomID: "a", Prompt: "Hi"}}, "missing a model"},
+		{"missing model after the first", []BatchRequest{
+			{CustomID: "a", Model: NewGPT4o(), Prompt: "Hi"},
+			{CustomID: "b", Prompt: "Hi"},
+		}, "missing a model"},
+		{"missing custom ID", []BatchRequest{{Model: NewGPT4o(), Prompt: "Hi"}}, "missing a custom ID"},
+		{"duplicate custom ID", []BatchRequest{
+			{CustomID: "a", Model: NewGPT4o(), Prompt: "Hi"},
+			{CustomID: "a", Model: NewGPT4o(), Prompt: "Hi"},
+		}, "duplicate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := g.SubmitBatch(context.Background(), tt.requests)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("SubmitBatch error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
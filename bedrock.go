@@ -1,9 +1,13 @@
+//go:build !lingo_no_bedrock
+
 package lingo
 
 import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -20,6 +24,32 @@ func init() {
 		}
 		return newBedrockClient(bedrockCfg, logger)
 	})
+
+	builtinModels = append(builtinModels,
+		func() Model { return NewBedrockClaude35Sonnet() },
+		func() Model { return NewBedrockClaude35Haiku() },
+		func() Model { return NewBedrockClaude3Sonnet() },
+		func() Model { return NewBedrockClaude3Haiku() },
+		func() Model { return NewBedrockClaude3Opus() },
+		func() Model { return NewBedrockTitanTextExpress() },
+		func() Model { return NewBedrockTitanTextLite() },
+		func() Model { return NewBedrockTitanTextPremier() },
+		func() Model { return NewBedrockLlama31Instruct8B() },
+		func() Model { return NewBedrockLlama31Instruct70B() },
+		func() Model { return NewBedrockLlama31Instruct405B() },
+		func() Model { return NewBedrockLlama32Instruct1B() },
+		func() Model { return NewBedrockLlama32Instruct3B() },
+		func() Model { return NewBedrockMistral7B() },
+		func() Model { return NewBedrockMixtral8x7B() },
+		func() Model { return NewBedrockMistralLarge() },
+	)
+
+	promptFormatters = append(promptFormatters, func(model Model, system, prompt string) (string, bool) {
+		if m, ok := model.(bedrockTemplateModel); ok && m.template() != nil {
+			return m.template().Format(system, prompt), true
+		}
+		return "", false
+	})
 }
 
 // ============================================================================
@@ -28,7 +58,7 @@ func init() {
 
 // BedrockConfig contains configuration for the AWS Bedrock provider
 type BedrockConfig struct {
-	// Region is the AWS region (required, e.g., "us-east-1")
+	// Region is the AWS region (required unless AWSConfig sets one, e.g., "us-east-1")
 	Region string
 	// Profile is the AWS profile name from ~/.aws/credentials or ~/.aws/config (optional)
 	Profile string
@@ -38,10 +68,19 @@ type BedrockConfig struct {
 	SecretAccessKey string
 	// SessionToken is the AWS session token for temporary credentials (optional)
 	SessionToken string
-	// Timeout is the request timeout (default: 60s)
+	// Timeout is the request timeout (default: the model's recommended timeout,
+	// reported by Describe)
 	Timeout time.Duration
 	// RateLimiter is the optional rate limit configuration
 	RateLimiter *RateLimitConfig
+	// ContentType is the request body MIME type (default: "application/json")
+	ContentType string
+	// Accept is the desired response MIME type (default: "application/json")
+	Accept string
+	// AWSConfig is an existing AWS config to build the client from (optional).
+	// When set, Profile and the static credentials are ignored, and Region,
+	// if set, overrides the config's region.
+	AWSConfig *aws.Config
 }
 
 // Implement ProviderConfig interface
@@ -61,7 +100,20 @@ type bedrockClaudeOptions struct {
 	topP             float64
 	topK             int
 	systemPrompt     string
-	anthropicVersion string
+	anthropicVersion string // Sent as anthropic_version (default: "bedrock-2023-05-31")
+	modelFamily      string // Optional: overrides family detection from the model ID
+
+	temperatureSet bool // Distinguishes an explicit temperature of 0 from unset
+}
+
+func (o *bedrockClaudeOptions) family() string  { return o.modelFamily }
+func (o *bedrockClaudeOptions) version() string { return o.anthropicVersion }
+func (o *bedrockClaudeOptions) sampling() bedrockSampling {
+	return bedrockSampling{o.maxTokens, o.temperature, o.temperatureSet, o.topP, o.topK}
+}
+func (o *bedrockClaudeOptions) setTemperature(t float64) { o.temperature = t; o.temperatureSet = true }
+func (o *bedrockClaudeOptions) explicitTemperature() (float64, bool) {
+	return o.temperature, o.temperatureSet
 }
 
 // bedrockTitanOptions contains options for Amazon Titan models on Bedrock
@@ -70,23 +122,79 @@ type bedrockTitanOptions struct {
 	temperature  float64
 	topP         float64
 	systemPrompt string
+	modelFamily  string // Optional: overrides family detection from the model ID
+
+	temperatureSet bool // Distinguishes an explicit temperature of 0 from unset
+}
+
+func (o *bedrockTitanOptions) family() string { return o.modelFamily }
+func (o *bedrockTitanOptions) sampling() bedrockSampling {
+	return bedrockSampling{maxTokens: o.maxTokens, temperature: o.temperature, temperatureSet: o.temperatureSet, topP: o.topP}
+}
+func (o *bedrockTitanOptions) setTemperature(t float64) { o.temperature = t; o.temperatureSet = true }
+func (o *bedrockTitanOptions) explicitTemperature() (float64, bool) {
+	return o.temperature, o.temperatureSet
 }
 
 // bedrockLlamaOptions contains options for Llama models on Bedrock
 type bedrockLlamaOptions struct {
-	maxTokens    int
-	temperature  float64
-	topP         float64
-	systemPrompt string
+	maxTokens      int
+	temperature    float64
+	topP           float64
+	systemPrompt   string
+	modelFamily    string         // Optional: overrides family detection from the model ID
+	promptTemplate PromptTemplate // Optional: overrides the family's prompt template
+
+	temperatureSet bool // Distinguishes an explicit temperature of 0 from unset
+}
+
+func (o *bedrockLlamaOptions) family() string           { return o.modelFamily }
+func (o *bedrockLlamaOptions) template() PromptTemplate { return o.promptTemplate }
+func (o *bedrockLlamaOptions) sampling() bedrockSampling {
+	return bedrockSampling{maxTokens: o.maxTokens, temperature: o.temperature, temperatureSet: o.temperatureSet, topP: o.topP}
+}
+func (o *bedrockLlamaOptions) setTemperature(t float64) { o.temperature = t; o.temperatureSet = true }
+func (o *bedrockLlamaOptions) explicitTemperature() (float64, bool) {
+	return o.temperature, o.temperatureSet
 }
 
 // bedrockMistralOptions contains options for Mistral models on Bedrock
 type bedrockMistralOptions struct {
-	maxTokens    int
-	temperature  float64
-	topP         float64
-	topK         int
-	systemPrompt string
+	maxTokens      int
+	temperature    float64
+	topP           float64
+	topK           int
+	systemPrompt   string
+	modelFamily    string         // Optional: overrides family detection from the model ID
+	promptTemplate PromptTemplate // Optional: overrides the family's prompt template
+
+	temperatureSet bool // Distinguishes an explicit temperature of 0 from unset
+}
+
+func (o *bedrockMistralOptions) family() string           { return o.modelFamily }
+func (o *bedrockMistralOptions) template() PromptTemplate { return o.promptTemplate }
+func (o *bedrockMistralOptions) sampling() bedrockSampling {
+	return bedrockSampling{o.maxTokens, o.temperature, o.temperatureSet, o.topP, o.topK}
+}
+func (o *bedrockMistralOptions) setTemperature(t float64) { o.temperature = t; o.temperatureSet = true }
+func (o *bedrockMistralOptions) explicitTemperature() (float64, bool) {
+	return o.temperature, o.temperatureSet
+}
+
+// bedrockSampling holds the sampling options common to every model family.
+// Families that don't accept an option ignore it.
+type bedrockSampling struct {
+	maxTokens      int
+	temperature    float64
+	temperatureSet bool
+	topP           float64
+	topK           int
+}
+
+// temperatureFor returns the temperature to send, or false to leave the
+// request's default. An explicit temperature of 0 is sent.
+func (s bedrockSampling) temperatureFor() (float64, bool) {
+	return s.temperature, s.temperatureSet || s.temperature > 0
 }
 
 // ============================================================================
@@ -107,7 +215,7 @@ func (m *BedrockClaude35Sonnet) WithMaxTokens(n int) *BedrockClaude35Sonnet {
 	return m
 }
 func (m *BedrockClaude35Sonnet) WithTemperature(t float64) *BedrockClaude35Sonnet {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *BedrockClaude35Sonnet) WithTopP(p float64) *BedrockClaude35Sonnet { m.topP = p; return m }
@@ -116,6 +224,14 @@ func (m *BedrockClaude35Sonnet) WithSystemPrompt(s string) *BedrockClaude35Sonne
 	m.systemPrompt = s
 	return m
 }
+func (m *BedrockClaude35Sonnet) WithModelFamily(f string) *BedrockClaude35Sonnet {
+	m.modelFamily = f
+	return m
+}
+func (m *BedrockClaude35Sonnet) WithAnthropicVersion(v string) *BedrockClaude35Sonnet {
+	m.anthropicVersion = v
+	return m
+}
 
 // NewBedrockClaude35Sonnet creates a new Claude 3.5 Sonnet model for Bedrock
 func NewBedrockClaude35Sonnet() *BedrockClaude35Sonnet {
@@ -135,7 +251,7 @@ func (m *BedrockClaude35Haiku) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *BedrockClaude35Haiku) WithMaxTokens(n int) *BedrockClaude35Haiku { m.maxTokens = n; return m }
 func (m *BedrockClaude35Haiku) WithTemperature(t float64) *BedrockClaude35Haiku {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *BedrockClaude35Haiku) WithTopP(p float64) *BedrockClaude35Haiku { m.topP = p; return m }
@@ -144,6 +260,14 @@ func (m *BedrockClaude35Haiku) WithSystemPrompt(s string) *BedrockClaude35Haiku
 	m.systemPrompt = s
 	return m
 }
+func (m *BedrockClaude35Haiku) WithModelFamily(f string) *BedrockClaude35Haiku {
+	m.modelFamily = f
+	return m
+}
+func (m *BedrockClaude35Haiku) WithAnthropicVersion(v string) *BedrockClaude35Haiku {
+	m.anthropicVersion = v
+	return m
+}
 
 // NewBedrockClaude35Haiku creates a new Claude 3.5 Haiku model for Bedrock
 func NewBedrockClaude35Haiku() *BedrockClaude35Haiku {
@@ -163,7 +287,7 @@ func (m *BedrockClaude3Sonnet) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *BedrockClaude3Sonnet) WithMaxTokens(n int) *BedrockClaude3Sonnet { m.maxTokens = n; return m }
 func (m *BedrockClaude3Sonnet) WithTemperature(t float64) *BedrockClaude3Sonnet {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *BedrockClaude3Sonnet) WithTopP(p float64) *BedrockClaude3Sonnet { m.topP = p; return m }
@@ -172,6 +296,14 @@ func (m *BedrockClaude3Sonnet) WithSystemPrompt(s string) *BedrockClaude3Sonnet
 	m.systemPrompt = s
 	return m
 }
+func (m *BedrockClaude3Sonnet) WithModelFamily(f string) *BedrockClaude3Sonnet {
+	m.modelFamily = f
+	return m
+}
+func (m *BedrockClaude3Sonnet) WithAnthropicVersion(v string) *BedrockClaude3Sonnet {
+	m.anthropicVersion = v
+	return m
+}
 
 // NewBedrockClaude3Sonnet creates a new Claude 3 Sonnet model for Bedrock
 func NewBedrockClaude3Sonnet() *BedrockClaude3Sonnet {
@@ -191,7 +323,7 @@ func (m *BedrockClaude3Haiku) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *BedrockClaude3Haiku) WithMaxTokens(n int) *BedrockClaude3Haiku { m.maxTokens = n; return m }
 func (m *BedrockClaude3Haiku) WithTemperature(t float64) *BedrockClaude3Haiku {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *BedrockClaude3Haiku) WithTopP(p float64) *BedrockClaude3Haiku { m.topP = p; return m }
@@ -200,6 +332,14 @@ func (m *BedrockClaude3Haiku) WithSystemPrompt(s string) *BedrockClaude3Haiku {
 	m.systemPrompt = s
 	return m
 }
+func (m *BedrockClaude3Haiku) WithModelFamily(f string) *BedrockClaude3Haiku {
+	m.modelFamily = f
+	return m
+}
+func (m *BedrockClaude3Haiku) WithAnthropicVersion(v string) *BedrockClaude3Haiku {
+	m.anthropicVersion = v
+	return m
+}
 
 // NewBedrockClaude3Haiku creates a new Claude 3 Haiku model for Bedrock
 func NewBedrockClaude3Haiku() *BedrockClaude3Haiku {
@@ -219,7 +359,7 @@ func (m *BedrockClaude3Opus) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *BedrockClaude3Opus) WithMaxTokens(n int) *BedrockClaude3Opus { m.maxTokens = n; return m }
 func (m *BedrockClaude3Opus) WithTemperature(t float64) *BedrockClaude3Opus {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *BedrockClaude3Opus) WithTopP(p float64) *BedrockClaude3Opus { m.topP = p; return m }
@@ -228,6 +368,14 @@ func (m *BedrockClaude3Opus) WithSystemPrompt(s string) *BedrockClaude3Opus {
 	m.systemPrompt = s
 	return m
 }
+func (m *BedrockClaude3Opus) WithModelFamily(f string) *BedrockClaude3Opus {
+	m.modelFamily = f
+	return m
+}
+func (m *BedrockClaude3Opus) WithAnthropicVersion(v string) *BedrockClaude3Opus {
+	m.anthropicVersion = v
+	return m
+}
 
 // NewBedrockClaude3Opus creates a new Claude 3 Opus model for Bedrock
 func NewBedrockClaude3Opus() *BedrockClaude3Opus {
@@ -254,7 +402,7 @@ func (m *BedrockTitanTextExpress) WithMaxTokens(n int) *BedrockTitanTextExpress
 	return m
 }
 func (m *BedrockTitanTextExpress) WithTemperature(t float64) *BedrockTitanTextExpress {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *BedrockTitanTextExpress) WithTopP(p float64) *BedrockTitanTextExpress { m.topP = p; return m }
@@ -262,6 +410,10 @@ func (m *BedrockTitanTextExpress) WithSystemPrompt(s string) *BedrockTitanTextEx
 	m.systemPrompt = s
 	return m
 }
+func (m *BedrockTitanTextExpress) WithModelFamily(f string) *BedrockTitanTextExpress {
+	m.modelFamily = f
+	return m
+}
 
 // NewBedrockTitanTextExpress creates a new Titan Text Express model for Bedrock
 func NewBedrockTitanTextExpress() *BedrockTitanTextExpress {
@@ -277,7 +429,7 @@ func (m *BedrockTitanTextLite) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *BedrockTitanTextLite) WithMaxTokens(n int) *BedrockTitanTextLite { m.maxTokens = n; return m }
 func (m *BedrockTitanTextLite) WithTemperature(t float64) *BedrockTitanTextLite {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *BedrockTitanTextLite) WithTopP(p float64) *BedrockTitanTextLite { m.topP = p; return m }
@@ -285,6 +437,10 @@ func (m *BedrockTitanTextLite) WithSystemPrompt(s string) *BedrockTitanTextLite
 	m.systemPrompt = s
 	return m
 }
+func (m *BedrockTitanTextLite) WithModelFamily(f string) *BedrockTitanTextLite {
+	m.modelFamily = f
+	return m
+}
 
 // NewBedrockTitanTextLite creates a new Titan Text Lite model for Bedrock
 func NewBedrockTitanTextLite() *BedrockTitanTextLite {
@@ -303,7 +459,7 @@ func (m *BedrockTitanTextPremier) WithMaxTokens(n int) *BedrockTitanTextPremier
 	return m
 }
 func (m *BedrockTitanTextPremier) WithTemperature(t float64) *BedrockTitanTextPremier {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *BedrockTitanTextPremier) WithTopP(p float64) *BedrockTitanTextPremier { m.topP = p; return m }
@@ -311,6 +467,10 @@ func (m *BedrockTitanTextPremier) WithSystemPrompt(s string) *BedrockTitanTextPr
 	m.systemPrompt = s
 	return m
 }
+func (m *BedrockTitanTextPremier) WithModelFamily(f string) *BedrockTitanTextPremier {
+	m.modelFamily = f
+	return m
+}
 
 // NewBedrockTitanTextPremier creates a new Titan Text Premier model for Bedrock
 func NewBedrockTitanTextPremier() *BedrockTitanTextPremier {
@@ -333,7 +493,7 @@ func (m *BedrockLlama31Instruct8B) WithMaxTokens(n int) *BedrockLlama31Instruct8
 	return m
 }
 func (m *BedrockLlama31Instruct8B) WithTemperature(t float64) *BedrockLlama31Instruct8B {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *BedrockLlama31Instruct8B) WithTopP(p float64) *BedrockLlama31Instruct8B {
@@ -344,6 +504,14 @@ func (m *BedrockLlama31Instruct8B) WithSystemPrompt(s string) *BedrockLlama31Ins
 	m.systemPrompt = s
 	return m
 }
+func (m *BedrockLlama31Instruct8B) WithModelFamily(f string) *BedrockLlama31Instruct8B {
+	m.modelFamily = f
+	return m
+}
+func (m *BedrockLlama31Instruct8B) WithPromptTemplate(tmpl PromptTemplate) *BedrockLlama31Instruct8B {
+	m.promptTemplate = tmpl
+	return m
+}
 
 // NewBedrockLlama31Instruct8B creates a new Llama 3.1 8B Instruct model for Bedrock
 func NewBedrockLlama31Instruct8B() *BedrockLlama31Instruct8B {
@@ -362,7 +530,7 @@ func (m *BedrockLlama31Instruct70B) WithMaxTokens(n int) *BedrockLlama31Instruct
 	return m
 }
 func (m *BedrockLlama31Instruct70B) WithTemperature(t float64) *BedrockLlama31Instruct70B {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *BedrockLlama31Instruct70B) WithTopP(p float64) *BedrockLlama31Instruct70B {
@@ -373,6 +541,14 @@ func (m *BedrockLlama31Instruct70B) WithSystemPrompt(s string) *BedrockLlama31In
 	m.systemPrompt = s
 	return m
 }
+func (m *BedrockLlama31Instruct70B) WithModelFamily(f string) *BedrockLlama31Instruct70B {
+	m.modelFamily = f
+	return m
+}
+func (m *BedrockLlama31Instruct70B) WithPromptTemplate(tmpl PromptTemplate) *BedrockLlama31Instruct70B {
+	m.promptTemplate = tmpl
+	return m
+}
 
 // NewBedrockLlama31Instruct70B creates a new Llama 3.1 70B Instruct model for Bedrock
 func NewBedrockLlama31Instruct70B() *BedrockLlama31Instruct70B {
@@ -391,7 +567,7 @@ func (m *BedrockLlama31Instruct405B) WithMaxTokens(n int) *BedrockLlama31Instruc
 	return m
 }
 func (m *BedrockLlama31Instruct405B) WithTemperature(t float64) *BedrockLlama31Instruct405B {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *BedrockLlama31Instruct405B) WithTopP(p float64) *BedrockLlama31Instruct405B {
@@ -402,6 +578,14 @@ func (m *BedrockLlama31Instruct405B) WithSystemPrompt(s string) *BedrockLlama31I
 	m.systemPrompt = s
 	return m
 }
+func (m *BedrockLlama31Instruct405B) WithModelFamily(f string) *BedrockLlama31Instruct405B {
+	m.modelFamily = f
+	return m
+}
+func (m *BedrockLlama31Instruct405B) WithPromptTemplate(tmpl PromptTemplate) *BedrockLlama31Instruct405B {
+	m.promptTemplate = tmpl
+	return m
+}
 
 // NewBedrockLlama31Instruct405B creates a new Llama 3.1 405B Instruct model for Bedrock
 func NewBedrockLlama31Instruct405B() *BedrockLlama31Instruct405B {
@@ -420,7 +604,7 @@ func (m *BedrockLlama32Instruct1B) WithMaxTokens(n int) *BedrockLlama32Instruct1
 	return m
 }
 func (m *BedrockLlama32Instruct1B) WithTemperature(t float64) *BedrockLlama32Instruct1B {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *BedrockLlama32Instruct1B) WithTopP(p float64) *BedrockLlama32Instruct1B {
@@ -431,6 +615,14 @@ func (m *BedrockLlama32Instruct1B) WithSystemPrompt(s string) *BedrockLlama32Ins
 	m.systemPrompt = s
 	return m
 }
+func (m *BedrockLlama32Instruct1B) WithModelFamily(f string) *BedrockLlama32Instruct1B {
+	m.modelFamily = f
+	return m
+}
+func (m *BedrockLlama32Instruct1B) WithPromptTemplate(tmpl PromptTemplate) *BedrockLlama32Instruct1B {
+	m.promptTemplate = tmpl
+	return m
+}
 
 // NewBedrockLlama32Instruct1B creates a new Llama 3.2 1B Instruct model for Bedrock
 func NewBedrockLlama32Instruct1B() *BedrockLlama32Instruct1B {
@@ -449,7 +641,7 @@ func (m *BedrockLlama32Instruct3B) WithMaxTokens(n int) *BedrockLlama32Instruct3
 	return m
 }
 func (m *BedrockLlama32Instruct3B) WithTemperature(t float64) *BedrockLlama32Instruct3B {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *BedrockLlama32Instruct3B) WithTopP(p float64) *BedrockLlama32Instruct3B {
@@ -460,6 +652,14 @@ func (m *BedrockLlama32Instruct3B) WithSystemPrompt(s string) *BedrockLlama32Ins
 	m.systemPrompt = s
 	return m
 }
+func (m *BedrockLlama32Instruct3B) WithModelFamily(f string) *BedrockLlama32Instruct3B {
+	m.modelFamily = f
+	return m
+}
+func (m *BedrockLlama32Instruct3B) WithPromptTemplate(tmpl PromptTemplate) *BedrockLlama32Instruct3B {
+	m.promptTemplate = tmpl
+	return m
+}
 
 // NewBedrockLlama32Instruct3B creates a new Llama 3.2 3B Instruct model for Bedrock
 func NewBedrockLlama32Instruct3B() *BedrockLlama32Instruct3B {
@@ -477,11 +677,19 @@ func (m *BedrockMistral7B) ModelName() string      { return "mistral.mistral-7b-
 func (m *BedrockMistral7B) Provider() ProviderType { return ProviderBedrock }
 func (m *BedrockMistral7B) SystemPrompt() string   { return m.systemPrompt }
 
-func (m *BedrockMistral7B) WithMaxTokens(n int) *BedrockMistral7B       { m.maxTokens = n; return m }
-func (m *BedrockMistral7B) WithTemperature(t float64) *BedrockMistral7B { m.temperature = t; return m }
+func (m *BedrockMistral7B) WithMaxTokens(n int) *BedrockMistral7B { m.maxTokens = n; return m }
+func (m *BedrockMistral7B) WithTemperature(t float64) *BedrockMistral7B {
+	m.setTemperature(t)
+	return m
+}
 func (m *BedrockMistral7B) WithTopP(p float64) *BedrockMistral7B        { m.topP = p; return m }
 func (m *BedrockMistral7B) WithTopK(k int) *BedrockMistral7B            { m.topK = k; return m }
 func (m *BedrockMistral7B) WithSystemPrompt(s string) *BedrockMistral7B { m.systemPrompt = s; return m }
+func (m *BedrockMistral7B) WithModelFamily(f string) *BedrockMistral7B  { m.modelFamily = f; return m }
+func (m *BedrockMistral7B) WithPromptTemplate(tmpl PromptTemplate) *BedrockMistral7B {
+	m.promptTemplate = tmpl
+	return m
+}
 
 // NewBedrockMistral7B creates a new Mistral 7B Instruct model for Bedrock
 func NewBedrockMistral7B() *BedrockMistral7B {
@@ -497,7 +705,7 @@ func (m *BedrockMixtral8x7B) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *BedrockMixtral8x7B) WithMaxTokens(n int) *BedrockMixtral8x7B { m.maxTokens = n; return m }
 func (m *BedrockMixtral8x7B) WithTemperature(t float64) *BedrockMixtral8x7B {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *BedrockMixtral8x7B) WithTopP(p float64) *BedrockMixtral8x7B { m.topP = p; return m }
@@ -506,6 +714,14 @@ func (m *BedrockMixtral8x7B) WithSystemPrompt(s string) *BedrockMixtral8x7B {
 	m.systemPrompt = s
 	return m
 }
+func (m *BedrockMixtral8x7B) WithModelFamily(f string) *BedrockMixtral8x7B {
+	m.modelFamily = f
+	return m
+}
+func (m *BedrockMixtral8x7B) WithPromptTemplate(tmpl PromptTemplate) *BedrockMixtral8x7B {
+	m.promptTemplate = tmpl
+	return m
+}
 
 // NewBedrockMixtral8x7B creates a new Mixtral 8x7B Instruct model for Bedrock
 func NewBedrockMixtral8x7B() *BedrockMixtral8x7B {
@@ -521,7 +737,7 @@ func (m *BedrockMistralLarge) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *BedrockMistralLarge) WithMaxTokens(n int) *BedrockMistralLarge { m.maxTokens = n; return m }
 func (m *BedrockMistralLarge) WithTemperature(t float64) *BedrockMistralLarge {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *BedrockMistralLarge) WithTopP(p float64) *BedrockMistralLarge { m.topP = p; return m }
@@ -530,6 +746,14 @@ func (m *BedrockMistralLarge) WithSystemPrompt(s string) *BedrockMistralLarge {
 	m.systemPrompt = s
 	return m
 }
+func (m *BedrockMistralLarge) WithModelFamily(f string) *BedrockMistralLarge {
+	m.modelFamily = f
+	return m
+}
+func (m *BedrockMistralLarge) WithPromptTemplate(tmpl PromptTemplate) *BedrockMistralLarge {
+	m.promptTemplate = tmpl
+	return m
+}
 
 // NewBedrockMistralLarge creates a new Mistral Large model for Bedrock
 func NewBedrockMistralLarge() *BedrockMistralLarge {
@@ -543,28 +767,42 @@ func NewBedrockMistralLarge() *BedrockMistralLarge {
 // BedrockModel represents a generic Bedrock model
 // Use this for any model available in your Bedrock environment
 type BedrockModel struct {
-	modelID      string
-	maxTokens    int
-	temperature  float64
-	topP         float64
-	topK         int
-	systemPrompt string
-	modelFamily  string // "claude", "titan", "llama", "mistral"
+	modelID        string
+	maxTokens      int
+	temperature    float64
+	topP           float64
+	topK           int
+	systemPrompt   string
+	modelFamily    string         // "claude", "titan", "llama", "mistral"
+	promptTemplate PromptTemplate // Optional: overrides the family's prompt template
+	temperatureSet bool           // Distinguishes an explicit temperature of 0 from unset
+}
+
+func (m *BedrockModel) ModelName() string                    { return m.modelID }
+func (m *BedrockModel) Provider() ProviderType               { return ProviderBedrock }
+func (m *BedrockModel) SystemPrompt() string                 { return m.systemPrompt }
+func (m *BedrockModel) family() string                       { return m.modelFamily }
+func (m *BedrockModel) template() PromptTemplate             { return m.promptTemplate }
+func (m *BedrockModel) setTemperature(t float64)             { m.temperature = t; m.temperatureSet = true }
+func (m *BedrockModel) explicitTemperature() (float64, bool) { return m.temperature, m.temperatureSet }
+func (m *BedrockModel) sampling() bedrockSampling {
+	return bedrockSampling{m.maxTokens, m.temperature, m.temperatureSet, m.topP, m.topK}
 }
 
-func (m *BedrockModel) ModelName() string      { return m.modelID }
-func (m *BedrockModel) Provider() ProviderType { return ProviderBedrock }
-func (m *BedrockModel) SystemPrompt() string   { return m.systemPrompt }
-
 func (m *BedrockModel) WithMaxTokens(n int) *BedrockModel       { m.maxTokens = n; return m }
-func (m *BedrockModel) WithTemperature(t float64) *BedrockModel { m.temperature = t; return m }
+func (m *BedrockModel) WithTemperature(t float64) *BedrockModel { m.setTemperature(t); return m }
 func (m *BedrockModel) WithTopP(p float64) *BedrockModel        { m.topP = p; return m }
 func (m *BedrockModel) WithTopK(k int) *BedrockModel            { m.topK = k; return m }
 func (m *BedrockModel) WithSystemPrompt(s string) *BedrockModel { m.systemPrompt = s; return m }
 func (m *BedrockModel) WithModelFamily(f string) *BedrockModel  { m.modelFamily = f; return m }
+func (m *BedrockModel) WithPromptTemplate(tmpl PromptTemplate) *BedrockModel {
+	m.promptTemplate = tmpl
+	return m
+}
 
 // NewBedrockModel creates a new generic Bedrock model with the specified model ID
-// modelFamily should be one of: "claude", "titan", "llama", "mistral"
+// modelFamily should be one of: "claude", "titan", "llama", "mistral", or empty
+// to detect the family from the model ID
 func NewBedrockModel(modelID, modelFamily string) *BedrockModel {
 	return &BedrockModel{
 		modelID:     modelID,
@@ -574,6 +812,109 @@ func NewBedrockModel(modelID, modelFamily string) *BedrockModel {
 	}
 }
 
+// ============================================================================
+// PROMPT TEMPLATES
+// ============================================================================
+
+// PromptTemplate formats a system prompt and user prompt into the single prompt
+// string sent to text-completion models on Bedrock (Llama and Mistral)
+type PromptTemplate interface {
+	Format(systemPrompt, prompt string) string
+}
+
+// PromptTemplateFunc adapts an ordinary function to a PromptTemplate
+type PromptTemplateFunc func(systemPrompt, prompt string) string
+
+// Format calls f(systemPrompt, prompt)
+func (f PromptTemplateFunc) Format(systemPrompt, prompt string) string {
+	return f(systemPrompt, prompt)
+}
+
+// Llama2PromptTemplate is the Llama 2 chat format: <s>[INST] <<SYS>>...<</SYS>> ... [/INST]
+var Llama2PromptTemplate PromptTemplate = PromptTemplateFunc(func(systemPrompt, prompt string) string {
+	if systemPrompt != "" {
+		return fmt.Sprintf("<s>[INST] <<SYS>>\n%s\n<</SYS>>\n\n%s [/INST]", systemPrompt, prompt)
+	}
+	return fmt.Sprintf("<s>[INST] %s [/INST]", prompt)
+})
+
+// Llama3PromptTemplate is the header-based Llama 3 chat format, used by Llama
+// 3.x models: <|begin_of_text|><|start_header_id|>system<|end_header_id|>...
+var Llama3PromptTemplate PromptTemplate = PromptTemplateFunc(func(systemPrompt, prompt string) string {
+	var b strings.Builder
+	b.WriteString("<|begin_of_text|>")
+	if systemPrompt != "" {
+		b.WriteString("<|start_header_id|>system<|end_header_id|>\n\n")
+		b.WriteString(systemPrompt)
+		b.WriteString("<|eot_id|>")
+	}
+	b.WriteString("<|start_header_id|>user<|end_header_id|>\n\n")
+	b.WriteString(prompt)
+	b.WriteString("<|eot_id|><|start_header_id|>assistant<|end_header_id|>\n\n")
+	return b.String()
+})
+
+// MistralPromptTemplate is the Mistral instruct format. Mistral has no system
+// role, so the system prompt leads the instruction.
+var MistralPromptTemplate PromptTemplate = PromptTemplateFunc(func(systemPrompt, prompt string) string {
+	if systemPrompt != "" {
+		return fmt.Sprintf("<s>[INST] %s\n\n%s [/INST]", systemPrompt, prompt)
+	}
+	return fmt.Sprintf("<s>[INST] %s [/INST]", prompt)
+})
+
+// promptTemplates maps Bedrock model families to their prompt templates
+var (
+	promptTemplates = map[string]PromptTemplate{
+		"llama":   Llama2PromptTemplate,
+		"llama3":  Llama3PromptTemplate,
+		"mistral": MistralPromptTemplate,
+	}
+	promptTemplatesMu sync.RWMutex
+)
+
+// RegisterPromptTemplate sets the prompt template used for a Bedrock model
+// family ("llama", "llama3" or "mistral"), replacing the built-in one. "llama3"
+// covers Llama 3.x model IDs and "llama" the remaining Llama models. A model's
+// own WithPromptTemplate still takes precedence.
+func RegisterPromptTemplate(family string, tmpl PromptTemplate) {
+	promptTemplatesMu.Lock()
+	defer promptTemplatesMu.Unlock()
+	promptTemplates[family] = tmpl
+}
+
+// bedrockTemplateKey returns the template registry key for a model. Llama 3
+// models use a different chat format from Llama 2 and have their own entry.
+func bedrockTemplateKey(modelID, family string) string {
+	if family == "llama" && strings.Contains(modelID, "llama3") {
+		return "llama3"
+	}
+	return family
+}
+
+// bedrockTemplateModel is an interface for models that carry a prompt template override
+type bedrockTemplateModel interface {
+	template() PromptTemplate
+}
+
+// bedrockPrompt formats the prompt for a text-completion model using the
+// model's own template if set, otherwise the one registered for its family
+func bedrockPrompt(model Model, family, system, prompt string) string {
+	var tmpl PromptTemplate
+	if tm, ok := model.(bedrockTemplateModel); ok {
+		tmpl = tm.template()
+	}
+	if tmpl == nil {
+		promptTemplatesMu.RLock()
+		tmpl = promptTemplates[bedrockTemplateKey(model.ModelName(), family)]
+		promptTemplatesMu.RUnlock()
+	}
+	if tmpl == nil {
+		return prompt
+	}
+	return tmpl.Format(system, prompt)
+}
+
 // ============================================================================
 // BEDROCK PROVIDER CLIENT
 // ============================================================================
@@ -581,15 +922,58 @@ func NewBedrockModel(modelID, modelFamily string) *BedrockModel {
 // bedrockClient implements the Provider interface for AWS Bedrock
 type bedrockClient struct {
 	client      *bedrockruntime.Client
-	timeout     time.Duration
+	timeout     time.Duration // Zero uses the model's recommended timeout
 	logger      Logger
 	rateLimiter *rateLimiter
+	contentType string
+	accept      string
 }
 
 // newBedrockClient creates a new Bedrock client
 func newBedrockClient(bedrockCfg *BedrockConfig, logger Logger) (*bedrockClient, error) {
+	awsCfg, err := loadBedrockAWSConfig(bedrockCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	client := bedrockruntime.NewFromConfig(awsCfg)
+
+	contentType := bedrockCfg.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	accept := bedrockCfg.Accept
+	if accept == "" {
+		accept = "application/json"
+	}
+
+	return &bedrockClient{
+		client:      client,
+		timeout:     bedrockCfg.Timeout,
+		logger:      logger,
+		rateLimiter: newRateLimiter(ProviderBedrock, bedrockCfg.RateLimiter, logger),
+		contentType: contentType,
+		accept:      accept,
+	}, nil
+}
+
+// loadBedrockAWSConfig returns the AWS config for the Bedrock client: a copy
+// of the provided AWSConfig if there is one, otherwise the default config
+// loaded with the configured region and credentials
+func loadBedrockAWSConfig(bedrockCfg *BedrockConfig) (aws.Config, error) {
+	if bedrockCfg.AWSConfig != nil {
+		awsCfg := bedrockCfg.AWSConfig.Copy()
+		if bedrockCfg.Region != "" {
+			awsCfg.Region = bedrockCfg.Region
+		}
+		if awsCfg.Region == "" {
+			return aws.Config{}, fmt.Errorf("AWS region is required for Bedrock")
+		}
+		return awsCfg, nil
+	}
+
 	if bedrockCfg.Region == "" {
-		return nil, fmt.Errorf("AWS region is required for Bedrock")
+		return aws.Config{}, fmt.Errorf("AWS region is required for Bedrock")
 	}
 
 	ctx := context.Background()
@@ -615,22 +999,9 @@ func newBedrockClient(bedrockCfg *BedrockConfig, logger Logger) (*bedrockClient,
 
 	awsCfg, err := config.LoadDefaultConfig(ctx, configOpts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config: %w", err)
-	}
-
-	client := bedrockruntime.NewFromConfig(awsCfg)
-
-	timeout := bedrockCfg.Timeout
-	if timeout == 0 {
-		timeout = defaultTimeout()
+		return aws.Config{}, fmt.Errorf("failed to load AWS config: %w", err)
 	}
-
-	return &bedrockClient{
-		client:      client,
-		timeout:     timeout,
-		logger:      logger,
-		rateLimiter: newRateLimiter(bedrockCfg.RateLimiter, logger),
-	}, nil
+	return awsCfg, nil
 }
 
 // Bedrock request/response types for different model families
@@ -641,9 +1012,10 @@ type bedrockClaudeRequest struct {
 	MaxTokens        int                    `json:"max_tokens"`
 	Messages         []bedrockClaudeMessage `json:"messages"`
 	System           string                 `json:"system,omitempty"`
-	Temperature      float64                `json:"temperature,omitempty"`
+	Temperature      *float64               `json:"temperature,omitempty"`
 	TopP             float64                `json:"top_p,omitempty"`
 	TopK             int                    `json:"top_k,omitempty"`
+	StopSequences    []string               `json:"stop_sequences,omitempty"`
 }
 
 type bedrockClaudeMessage struct {
@@ -652,9 +1024,10 @@ type bedrockClaudeMessage struct {
 }
 
 type bedrockClaudeResponse struct {
-	Content    []bedrockClaudeContent `json:"content"`
-	StopReason string                 `json:"stop_reason"`
-	Usage      bedrockClaudeUsage     `json:"usage"`
+	Content      []bedrockClaudeContent `json:"content"`
+	StopReason   string                 `json:"stop_reason"`
+	StopSequence string                 `json:"stop_sequence"`
+	Usage        bedrockClaudeUsage     `json:"usage"`
 }
 
 type bedrockClaudeContent struct {
@@ -663,8 +1036,10 @@ type bedrockClaudeContent struct {
 }
 
 type bedrockClaudeUsage struct {
-	InputTokens  int `json:"input_tokens"`
-	OutputTokens int `json:"output_tokens"`
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
 }
 
 // Titan format
@@ -708,7 +1083,7 @@ type bedrockLlamaResponse struct {
 type bedrockMistralRequest struct {
 	Prompt      string  `json:"prompt"`
 	MaxTokens   int     `json:"max_tokens"`
-	Temperature float64 `json:"temperature,omitempty"`
+	Temperature float64 `json:"temperature"`
 	TopP        float64 `json:"top_p,omitempty"`
 	TopK        int     `json:"top_k,omitempty"`
 }
@@ -722,6 +1097,31 @@ type bedrockMistralOutput struct {
 	StopReason string `json:"stop_reason"`
 }
 
+// bedrockSamplingModel is implemented by Bedrock models that carry sampling options
+type bedrockSamplingModel interface {
+	sampling() bedrockSampling
+}
+
+// bedrockVersionModel is implemented by Claude models on Bedrock that can
+// override the anthropic_version
+type bedrockVersionModel interface {
+	version() string
+}
+
+// bedrockFamilyModel is implemented by Bedrock models that accept an explicit family
+type bedrockFamilyModel interface {
+	family() string
+}
+
+// bedrockModelFamily returns the model's explicit family if one was set with
+// WithModelFamily, otherwise the family detected from the model ID
+func bedrockModelFamily(model Model) string {
+	if fm, ok := model.(bedrockFamilyModel); ok && fm.family() != "" {
+		return fm.family()
+	}
+	return getModelFamily(model.ModelName())
+}
+
 // getModelFamily determines the model family from the model ID
 func getModelFamily(modelID string) string {
 	switch {
@@ -739,27 +1139,25 @@ func getModelFamily(modelID string) string {
 }
 
 // Generate generates text using AWS Bedrock
-func (c *bedrockClient) Generate(ctx context.Context, model Model, prompt string) (*GenerationResponse, error) {
+func (c *bedrockClient) Generate(ctx context.Context, model Model, prompt string, genOpts ...GenerateOption) (*GenerationResponse, error) {
 	// Verify model is for Bedrock
 	if model.Provider() != ProviderBedrock {
 		return nil, fmt.Errorf("model %s is not a Bedrock model", model.ModelName())
 	}
 
+	reqOpts := newGenerateOptions(genOpts)
+	logger := reqOpts.logger(c.logger)
+
 	// Set timeout
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(c.timeout, ProviderBedrock, model.ModelName()))
 	defer cancel()
 
 	modelID := model.ModelName()
 
 	// Determine model family
-	var modelFamily string
-	if bm, ok := model.(*BedrockModel); ok {
-		modelFamily = bm.modelFamily
-	} else {
-		modelFamily = getModelFamily(modelID)
-	}
+	modelFamily := bedrockModelFamily(model)
 
-	c.logger.Debug().
+	logger.Debug().
 		Str("model", modelID).
 		Str("family", modelFamily).
 		Msg("Making Bedrock API request")
@@ -768,38 +1166,59 @@ func (c *bedrockClient) Generate(ctx context.Context, model Model, prompt string
 	var err error
 
 	// Build request based on model family
+	system := reqOpts.systemPromptFor(model)
 	switch modelFamily {
 	case "claude":
-		body, err = c.buildClaudeRequest(model, prompt)
+		body, err = c.buildClaudeRequest(model, system, prompt, reqOpts.stopSequences)
 	case "titan":
-		body, err = c.buildTitanRequest(model, prompt)
+		body, err = c.buildTitanRequest(model, system, prompt)
 	case "llama":
-		body, err = c.buildLlamaRequest(model, prompt)
+		body, err = c.buildLlamaRequest(model, system, prompt)
 	case "mistral":
-		body, err = c.buildMistralRequest(model, prompt)
+		body, err = c.buildMistralRequest(model, system, prompt)
 	default:
 		return nil, fmt.Errorf("unsupported model family: %s", modelFamily)
 	}
 	if err != nil {
 		return nil, err
 	}
+	if modelFamily != "claude" && len(reqOpts.stopSequences) > 0 {
+		warnStopSequencesIgnored(c.logger, model, "only Claude models on Bedrock accept stop sequences")
+	}
+	if body, err = c.applyDeterminism(body, model, modelFamily, reqOpts); err != nil {
+		return nil, err
+	}
+	if body, err = reqOpts.applyExtraBody(body); err != nil {
+		return nil, err
+	}
+	reqOpts.inspectRequest(ProviderBedrock, json.RawMessage(body))
+	if reqOpts.dryRun {
+		return dryRunResponse(model, json.RawMessage(body))
+	}
 
 	// Make request with rate limit handling
 	var output *bedrockruntime.InvokeModelOutput
-	err = c.rateLimiter.Execute(ctx, func() error {
+	var invokeOptions []func(*bedrockruntime.Options)
+	if reqOpts.noRetry {
+		invokeOptions = append(invokeOptions, func(o *bedrockruntime.Options) {
+			o.RetryMaxAttempts = 1
+		})
+	}
+	err = c.rateLimiter.ExecuteRequest(ctx, model.ModelName(), reqOpts, func() error {
 		var reqErr error
 		output, reqErr = c.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
 			ModelId:     aws.String(modelID),
 			Body:        body,
-			ContentType: aws.String("application/json"),
-		})
+			ContentType: aws.String(c.contentType),
+			Accept:      aws.String(c.accept),
+		}, invokeOptions...)
 		return reqErr
 	})
 	if err != nil {
-		c.logger.Error().
+		logger.Error().
 			Err(err).
 			Str("model", modelID).
-			Str("prompt_preview", truncateString(prompt, 100)).
+			Str("prompt_preview", reqOpts.promptPreview(prompt)).
 			Msg("Bedrock generation failed")
 		return nil, fmt.Errorf("bedrock generation failed: %w", err)
 	}
@@ -819,8 +1238,19 @@ func (c *bedrockClient) Generate(ctx context.Context, model Model, prompt string
 	if err != nil {
 		return nil, err
 	}
+	if !reqOpts.minimalResponse {
+		response.Metadata = map[string]string{
+			"provider": "bedrock",
+			"model":    modelID,
+			"family":   modelFamily,
+		}
+	}
+
+	if reqOpts.includeRaw {
+		response.RawJSON = output.Body
+	}
 
-	c.logger.Debug().
+	logger.Debug().
 		Str("model", modelID).
 		Int("prompt_tokens", response.Usage.PromptTokens).
 		Int("completion_tokens", response.Usage.CompletionTokens).
@@ -830,119 +1260,82 @@ func (c *bedrockClient) Generate(ctx context.Context, model Model, prompt string
 	return response, nil
 }
 
-func (c *bedrockClient) buildClaudeRequest(model Model, prompt string) ([]byte, error) {
+// applyDeterminism sets a temperature of 0 in the request body in
+// deterministic mode. None of the supported model families accept a seed.
+func (c *bedrockClient) applyDeterminism(body []byte, model Model, family string, reqOpts *generateOptions) ([]byte, error) {
+	if _, ok := reqOpts.samplingSeed(); ok {
+		warnNotDeterministic(c.logger, model, "Bedrock models don't accept a seed")
+	}
+	if !reqOpts.deterministic {
+		return body, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, fmt.Errorf("failed to apply deterministic mode: %w", err)
+	}
+	if family == "titan" {
+		// Titan nests its sampling settings
+		var config map[string]json.RawMessage
+		if err := json.Unmarshal(fields["textGenerationConfig"], &config); err != nil {
+			return nil, fmt.Errorf("failed to apply deterministic mode: %w", err)
+		}
+		config["temperature"] = json.RawMessage("0")
+		encoded, err := json.Marshal(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply deterministic mode: %w", err)
+		}
+		fields["textGenerationConfig"] = encoded
+	} else {
+		fields["temperature"] = json.RawMessage("0")
+	}
+	return json.Marshal(fields)
+}
+
+func (c *bedrockClient) buildClaudeRequest(model Model, system, prompt string, stop []string) ([]byte, error) {
 	req := bedrockClaudeRequest{
 		AnthropicVersion: "bedrock-2023-05-31",
 		MaxTokens:        4096,
 		Messages: []bedrockClaudeMessage{
 			{Role: "user", Content: prompt},
 		},
+		StopSequences: stop,
 	}
 
 	// Apply model-specific options
-	switch m := model.(type) {
-	case *BedrockClaude35Sonnet:
-		if m.maxTokens > 0 {
-			req.MaxTokens = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = m.topP
-		}
-		if m.topK > 0 {
-			req.TopK = m.topK
-		}
-		if m.systemPrompt != "" {
-			req.System = m.systemPrompt
-		}
-	case *BedrockClaude35Haiku:
-		if m.maxTokens > 0 {
-			req.MaxTokens = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = m.topP
-		}
-		if m.topK > 0 {
-			req.TopK = m.topK
-		}
-		if m.systemPrompt != "" {
-			req.System = m.systemPrompt
-		}
-	case *BedrockClaude3Sonnet:
-		if m.maxTokens > 0 {
-			req.MaxTokens = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = m.topP
-		}
-		if m.topK > 0 {
-			req.TopK = m.topK
-		}
-		if m.systemPrompt != "" {
-			req.System = m.systemPrompt
-		}
-	case *BedrockClaude3Haiku:
-		if m.maxTokens > 0 {
-			req.MaxTokens = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = m.topP
-		}
-		if m.topK > 0 {
-			req.TopK = m.topK
-		}
-		if m.systemPrompt != "" {
-			req.System = m.systemPrompt
-		}
-	case *BedrockClaude3Opus:
-		if m.maxTokens > 0 {
-			req.MaxTokens = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = m.topP
-		}
-		if m.topK > 0 {
-			req.TopK = m.topK
+	if m, ok := model.(bedrockSamplingModel); ok {
+		o := m.sampling()
+		if o.maxTokens > 0 {
+			req.MaxTokens = o.maxTokens
 		}
-		if m.systemPrompt != "" {
-			req.System = m.systemPrompt
+		if t, ok := o.temperatureFor(); ok {
+			req.Temperature = &t
 		}
-	case *BedrockModel:
-		if m.maxTokens > 0 {
-			req.MaxTokens = m.maxTokens
+		if o.topP > 0 {
+			req.TopP = o.topP
 		}
-		if m.temperature > 0 {
-			req.Temperature = m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = m.topP
-		}
-		if m.topK > 0 {
-			req.TopK = m.topK
-		}
-		if m.systemPrompt != "" {
-			req.System = m.systemPrompt
+		if o.topK > 0 {
+			req.TopK = o.topK
 		}
 	}
+	if system != "" {
+		req.System = system
+	}
+	if m, ok := model.(bedrockVersionModel); ok && m.version() != "" {
+		req.AnthropicVersion = m.version()
+	}
 
+	var temperature float64
+	if req.Temperature != nil {
+		temperature = *req.Temperature
+	}
+	if err := validateSampling(ProviderBedrock, temperature, 1, req.TopP); err != nil {
+		return nil, err
+	}
 	return json.Marshal(req)
 }
 
-func (c *bedrockClient) buildTitanRequest(model Model, prompt string) ([]byte, error) {
+func (c *bedrockClient) buildTitanRequest(model Model, system, prompt string) ([]byte, error) {
 	req := bedrockTitanRequest{
 		InputText: prompt,
 		TextGenerationConfig: bedrockTitanConfig{
@@ -953,212 +1346,86 @@ func (c *bedrockClient) buildTitanRequest(model Model, prompt string) ([]byte, e
 	}
 
 	// Prepend system prompt if set
-	if model.SystemPrompt() != "" {
-		req.InputText = model.SystemPrompt() + "\n\n" + prompt
+	if system != "" {
+		req.InputText = system + "\n\n" + prompt
 	}
 
 	// Apply model-specific options
-	switch m := model.(type) {
-	case *BedrockTitanTextExpress:
-		if m.maxTokens > 0 {
-			req.TextGenerationConfig.MaxTokenCount = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.TextGenerationConfig.Temperature = m.temperature
+	if m, ok := model.(bedrockSamplingModel); ok {
+		o := m.sampling()
+		if o.maxTokens > 0 {
+			req.TextGenerationConfig.MaxTokenCount = o.maxTokens
 		}
-		if m.topP > 0 {
-			req.TextGenerationConfig.TopP = m.topP
+		if t, ok := o.temperatureFor(); ok {
+			req.TextGenerationConfig.Temperature = t
 		}
-	case *BedrockTitanTextLite:
-		if m.maxTokens > 0 {
-			req.TextGenerationConfig.MaxTokenCount = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.TextGenerationConfig.Temperature = m.temperature
-		}
-		if m.topP > 0 {
-			req.TextGenerationConfig.TopP = m.topP
-		}
-	case *BedrockTitanTextPremier:
-		if m.maxTokens > 0 {
-			req.TextGenerationConfig.MaxTokenCount = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.TextGenerationConfig.Temperature = m.temperature
-		}
-		if m.topP > 0 {
-			req.TextGenerationConfig.TopP = m.topP
-		}
-	case *BedrockModel:
-		if m.maxTokens > 0 {
-			req.TextGenerationConfig.MaxTokenCount = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.TextGenerationConfig.Temperature = m.temperature
-		}
-		if m.topP > 0 {
-			req.TextGenerationConfig.TopP = m.topP
+		if o.topP > 0 {
+			req.TextGenerationConfig.TopP = o.topP
 		}
 	}
 
+	if err := validateSampling(ProviderBedrock, req.TextGenerationConfig.Temperature, 1, req.TextGenerationConfig.TopP); err != nil {
+		return nil, err
+	}
 	return json.Marshal(req)
 }
 
-func (c *bedrockClient) buildLlamaRequest(model Model, prompt string) ([]byte, error) {
-	// Build Llama prompt format
-	var fullPrompt string
-	if model.SystemPrompt() != "" {
-		fullPrompt = fmt.Sprintf("<s>[INST] <<SYS>>\n%s\n<</SYS>>\n\n%s [/INST]", model.SystemPrompt(), prompt)
-	} else {
-		fullPrompt = fmt.Sprintf("<s>[INST] %s [/INST]", prompt)
-	}
-
+func (c *bedrockClient) buildLlamaRequest(model Model, system, prompt string) ([]byte, error) {
 	req := bedrockLlamaRequest{
-		Prompt:      fullPrompt,
+		Prompt:      bedrockPrompt(model, "llama", system, prompt),
 		MaxGenLen:   2048,
 		Temperature: 0.6,
 		TopP:        0.9,
 	}
 
 	// Apply model-specific options
-	switch m := model.(type) {
-	case *BedrockLlama31Instruct8B:
-		if m.maxTokens > 0 {
-			req.MaxGenLen = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = m.topP
-		}
-	case *BedrockLlama31Instruct70B:
-		if m.maxTokens > 0 {
-			req.MaxGenLen = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = m.topP
-		}
-	case *BedrockLlama31Instruct405B:
-		if m.maxTokens > 0 {
-			req.MaxGenLen = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = m.topP
-		}
-	case *BedrockLlama32Instruct1B:
-		if m.maxTokens > 0 {
-			req.MaxGenLen = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = m.topP
-		}
-	case *BedrockLlama32Instruct3B:
-		if m.maxTokens > 0 {
-			req.MaxGenLen = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = m.temperature
+	if m, ok := model.(bedrockSamplingModel); ok {
+		o := m.sampling()
+		if o.maxTokens > 0 {
+			req.MaxGenLen = o.maxTokens
 		}
-		if m.topP > 0 {
-			req.TopP = m.topP
+		if t, ok := o.temperatureFor(); ok {
+			req.Temperature = t
 		}
-	case *BedrockModel:
-		if m.maxTokens > 0 {
-			req.MaxGenLen = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = m.topP
+		if o.topP > 0 {
+			req.TopP = o.topP
 		}
 	}
 
+	if err := validateSampling(ProviderBedrock, req.Temperature, 1, req.TopP); err != nil {
+		return nil, err
+	}
 	return json.Marshal(req)
 }
 
-func (c *bedrockClient) buildMistralRequest(model Model, prompt string) ([]byte, error) {
-	// Build Mistral prompt format
-	var fullPrompt string
-	if model.SystemPrompt() != "" {
-		fullPrompt = fmt.Sprintf("<s>[INST] %s\n\n%s [/INST]", model.SystemPrompt(), prompt)
-	} else {
-		fullPrompt = fmt.Sprintf("<s>[INST] %s [/INST]", prompt)
-	}
-
+func (c *bedrockClient) buildMistralRequest(model Model, system, prompt string) ([]byte, error) {
 	req := bedrockMistralRequest{
-		Prompt:      fullPrompt,
+		Prompt:      bedrockPrompt(model, "mistral", system, prompt),
 		MaxTokens:   4096,
 		Temperature: 0.7,
 		TopP:        0.9,
 	}
 
 	// Apply model-specific options
-	switch m := model.(type) {
-	case *BedrockMistral7B:
-		if m.maxTokens > 0 {
-			req.MaxTokens = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = m.topP
-		}
-		if m.topK > 0 {
-			req.TopK = m.topK
-		}
-	case *BedrockMixtral8x7B:
-		if m.maxTokens > 0 {
-			req.MaxTokens = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = m.topP
-		}
-		if m.topK > 0 {
-			req.TopK = m.topK
-		}
-	case *BedrockMistralLarge:
-		if m.maxTokens > 0 {
-			req.MaxTokens = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = m.topP
+	if m, ok := model.(bedrockSamplingModel); ok {
+		o := m.sampling()
+		if o.maxTokens > 0 {
+			req.MaxTokens = o.maxTokens
 		}
-		if m.topK > 0 {
-			req.TopK = m.topK
+		if t, ok := o.temperatureFor(); ok {
+			req.Temperature = t
 		}
-	case *BedrockModel:
-		if m.maxTokens > 0 {
-			req.MaxTokens = m.maxTokens
+		if o.topP > 0 {
+			req.TopP = o.topP
 		}
-		if m.temperature > 0 {
-			req.Temperature = m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = m.topP
-		}
-		if m.topK > 0 {
-			req.TopK = m.topK
+		if o.topK > 0 {
+			req.TopK = o.topK
 		}
 	}
 
+	if err := validateSampling(ProviderBedrock, req.Temperature, 1, req.TopP); err != nil {
+		return nil, err
+	}
 	return json.Marshal(req)
 }
 
@@ -1168,7 +1435,8 @@ func (c *bedrockClient) parseClaudeResponse(body []byte, modelID string) (*Gener
 		return nil, fmt.Errorf("failed to parse Claude response: %w", err)
 	}
 
-	if len(resp.Content) == 0 {
+	// A refusal may come back without any content
+	if len(resp.Content) == 0 && resp.StopReason != "refusal" {
 		return nil, fmt.Errorf("no content in Claude response")
 	}
 
@@ -1179,19 +1447,19 @@ func (c *bedrockClient) parseClaudeResponse(body []byte, modelID string) (*Gener
 		}
 	}
 
+	// Cache reads and writes are reported separately from input tokens
+	promptTokens := resp.Usage.InputTokens + resp.Usage.CacheReadInputTokens + resp.Usage.CacheCreationInputTokens
 	return &GenerationResponse{
 		Text:         text,
 		Model:        modelID,
 		FinishReason: resp.StopReason,
+		StopSequence: resp.StopSequence,
 		Usage: TokenUsage{
-			PromptTokens:     resp.Usage.InputTokens,
-			CompletionTokens: resp.Usage.OutputTokens,
-			TotalTokens:      resp.Usage.InputTokens + resp.Usage.OutputTokens,
-		},
-		Metadata: map[string]string{
-			"provider": "bedrock",
-			"model":    modelID,
-			"family":   "claude",
+			PromptTokens:       promptTokens,
+			CompletionTokens:   resp.Usage.OutputTokens,
+			TotalTokens:        promptTokens + resp.Usage.OutputTokens,
+			CachedPromptTokens: resp.Usage.CacheReadInputTokens,
+			CacheWriteTokens:   resp.Usage.CacheCreationInputTokens,
 		},
 	}, nil
 }
@@ -1215,11 +1483,6 @@ func (c *bedrockClient) parseTitanResponse(body []byte, modelID string) (*Genera
 			CompletionTokens: result.TokenCount,
 			TotalTokens:      result.TokenCount,
 		},
-		Metadata: map[string]string{
-			"provider": "bedrock",
-			"model":    modelID,
-			"family":   "titan",
-		},
 	}, nil
 }
 
@@ -1238,11 +1501,6 @@ func (c *bedrockClient) parseLlamaResponse(body []byte, modelID string) (*Genera
 			CompletionTokens: resp.GenerationTokenCount,
 			TotalTokens:      resp.PromptTokenCount + resp.GenerationTokenCount,
 		},
-		Metadata: map[string]string{
-			"provider": "bedrock",
-			"model":    modelID,
-			"family":   "llama",
-		},
 	}, nil
 }
 
@@ -1262,14 +1520,12 @@ func (c *bedrockClient) parseMistralResponse(body []byte, modelID string) (*Gene
 		Model:        modelID,
 		FinishReason: output.StopReason,
 		Usage:        TokenUsage{}, // Mistral doesn't return token counts
-		Metadata: map[string]string{
-			"provider": "bedrock",
-			"model":    modelID,
-			"family":   "mistral",
-		},
 	}, nil
 }
 
+// rateLimitStats returns the rate limit counters of the Bedrock client
+func (c *bedrockClient) rateLimitStats() RateLimitStats { return c.rateLimiter.Stats() }
+
 // Health checks the health of the Bedrock client
 func (c *bedrockClient) Health(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
@@ -1293,7 +1549,8 @@ func (c *bedrockClient) Health(ctx context.Context) error {
 	_, err = c.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
 		ModelId:     aws.String("amazon.titan-text-lite-v1"),
 		Body:        body,
-		ContentType: aws.String("application/json"),
+		ContentType: aws.String(c.contentType),
+		Accept:      aws.String(c.accept),
 	})
 	if err != nil {
 		return fmt.Errorf("bedrock health check failed: %w", err)
@@ -0,0 +1,67 @@
+//go:build !lingo_no_bedrock
+
+package lingo
+
+import "testing"
+
+func TestBedrockLlamaPrompt(t *testing.T) {
+	const (
+		llama3NoSystem = "<|begin_of_text|><|start_header_id|>user<|end_header_id|>\n\nHi<|eot_id|>" +
+			"<|start_header_id|>assistant<|end_header_id|>\n\n"
+		llama3System = "<|begin_of_text|><|start_header_id|>system<|end_header_id|>\n\nBe brief<|eot_id|>" +
+			"<|start_header_id|>user<|end_header_id|>\n\nHi<|eot_id|>" +
+			"<|start_header_id|>assistant<|end_header_id|>\n\n"
+	)
+
+	tests := []struct {
+		name   string
+		model  Model
+		system string
+		want   string
+	}{
+		{"llama 2", NewBedrockModel("meta.llama2-13b-chat-v1", "llama"), "", "<s>[INST] Hi [/INST]"},
+		{"llama 2 with system", NewBedrockModel("meta.llama2-70b-chat-v1", "llama"), "Be brief", "<s>[INST] <<SYS>>\nBe brief\n<</SYS>>\n\nHi [/INST]"},
+		{"llama 3.1", NewBedrockLlama31Instruct8B(), "", llama3NoSystem},
+		{"llama 3.1 with system", NewBedrockLlama31Instruct70B(), "Be brief", llama3System},
+		{"llama 3.2", NewBedrockLlama32Instruct1B(), "", llama3NoSystem},
+		{"llama 3.2 with system", NewBedrockLlama32Instruct3B(), "Be brief", llama3System},
+		{"custom llama 3 ID", NewBedrockModel("meta.llama3-3-70b-instruct-v1:0", "llama"), "Be brief", llama3System},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bedrockPrompt(tt.model, "llama", tt.system, "Hi"); got != tt.want {
+				t.Errorf("bedrockPrompt(%s) =\n%q\nwant\n%q", tt.model.ModelName(), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBedrockTemplateKey(t *testing.T) {
+	tests := []struct {
+		modelID, family, want string
+	}{
+		{"meta.llama2-13b-chat-v1", "llama", "llama"},
+		{"meta.llama3-1-8b-instruct-v1:0", "llama", "llama3"},
+		{"meta.llama3-2-1b-instruct-v1:0", "llama", "llama3"},
+		{"mistral.mistral-7b-instruct-v0:2", "mistral", "mistral"},
+	}
+	for _, tt := range tests {
+		if got := bedrockTemplateKey(tt.modelID, tt.family); got != tt.want {
+			t.Errorf("bedrockTemplateKey(%q, %q) = %q, want %q", tt.modelID, tt.family, got, tt.want)
+		}
+	}
+}
+
+func TestBedrockTemplateFingerprint(t *testing.T) {
+	upper := PromptTemplateFunc(func(system, prompt string) string { return "[" + prompt + "]" })
+	lower := PromptTemplateFunc(func(system, prompt string) string { return "<" + prompt + ">" })
+
+	a := RequestFingerprint(NewBedrockModel("meta.custom", "llama").WithPromptTemplate(upper), "Hi")
+	b := RequestFingerprint(NewBedrockModel("meta.custom", "llama").WithPromptTemplate(lower), "Hi")
+	if a == b {
+		t.Errorf("models with different template functions share a fingerprint")
+	}
+	if again := RequestFingerprint(NewBedrockModel("meta.custom", "llama").WithPromptTemplate(upper), "Hi"); again != a {
+		t.Errorf("the same template gave a different fingerprint")
+	}
+}
@@ -0,0 +1,136 @@
+package lingo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+)
+
+// ============================================================================
+// BEST OF N
+// ============================================================================
+
+// ScoreFunc rates a generated candidate; higher scores are better
+type ScoreFunc func(text string) float64
+
+// GenerateBestOf generates n candidates for the prompt with separate,
+// concurrent requests and returns the one the score function rates highest
+// (the earliest on a tie). Candidates that fail are skipped; the returned error
+// joins every failure only if all n fail.
+//
+// The returned response's Usage covers all candidates, and its Metadata lists
+// every candidate under "best_of_candidates" and their scores under
+// "best_of_scores" (both JSON arrays, in the same order), along with the
+// winner's position in them under "best_of_index". A NaN score ranks below
+// every other score, and scores that aren't finite are listed as null. In
+// deterministic mode every candidate is the same, so n should be 1.
+func (g *LLMGateway) GenerateBestOf(ctx context.Context, model Model, prompt string, n int, score ScoreFunc, opts ...GenerateOption) (*GenerationResponse, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("%w: best-of count must be at least 1, got %d", ErrOptionOutOfRange, n)
+	}
+	if score == nil {
+		return nil, fmt.Errorf("a score function is required for best-of generation")
+	}
+
+	// Identical candidates would otherwise collapse into one singleflight call
+	candidateOpts := append(append([]GenerateOption(nil), opts...), withUnshared())
+
+	resps := make([]*GenerationResponse, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resps[i], errs[i] = g.Generate(ctx, model, prompt, candidateOpts...)
+		}(i)
+	}
+	wg.Wait()
+
+	var answered []*GenerationResponse
+	var usage TokenUsage
+	var candidates []string
+	var scores []float64
+	for i, resp := range resps {
+		if errs[i] != nil {
+			g.logger.Debug().
+				Err(errs[i]).
+				Str("provider", string(model.Provider())).
+				Str("model", model.ModelName()).
+				Int("candidate", i).
+				Msg("Best-of candidate failed")
+			continue
+		}
+		usage.PromptTokens += resp.Usage.PromptTokens
+		usage.CompletionTokens += resp.Usage.CompletionTokens
+		usage.TotalTokens += resp.Usage.TotalTokens
+		usage.CachedPromptTokens += resp.Usage.CachedPromptTokens
+		usage.CacheWriteTokens += resp.Usage.CacheWriteTokens
+		usage.ReasoningTokens += resp.Usage.ReasoningTokens
+
+		answered = append(answered, resp)
+		candidates = append(candidates, resp.Text)
+		s := score(resp.Text)
+		if math.IsNaN(s) {
+			s = math.Inf(-1)
+		}
+		scores = append(scores, s)
+	}
+	if len(answered) == 0 {
+		return nil, fmt.Errorf("all %d best-of candidates failed: %w", n, errors.Join(errs...))
+	}
+
+	candidatesJSON, err := json.Marshal(candidates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode best-of candidates: %w", err)
+	}
+	scoresJSON, err := json.Marshal(finiteScores(scores))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode best-of scores: %w", err)
+	}
+
+	index := bestIndex(scores)
+	best := answered[index]
+	best.Usage = usage
+	if best.Metadata == nil {
+		best.Metadata = make(map[string]string)
+	}
+	best.Metadata["best_of_candidates"] = string(candidatesJSON)
+	best.Metadata["best_of_scores"] = string(scoresJSON)
+	best.Metadata["best_of_index"] = strconv.Itoa(index)
+	return best, nil
+}
+
+// withUnshared keeps a request out of the gateway's singleflight group, so
+// identical concurrent requests each make their own provider call
+func withUnshared() GenerateOption {
+	return func(o *generateOptions) {
+		o.unshared = true
+	}
+}
+
+// bestIndex returns the position of the highest score, the earliest on a tie
+func bestIndex(scores []float64) int {
+	best := 0
+	for i, s := range scores {
+		if s > scores[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+// finiteScores replaces infinite scores with nil, which JSON can encode
+func finiteScores(scores []float64) []*float64 {
+	finite := make([]*float64, len(scores))
+	for i := range scores {
+		if !math.IsInf(scores[i], 0) {
+			finite[i] = &scores[i]
+		}
+	}
+	return finite
+}
@@ -0,0 +1,42 @@
+package lingo
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestGenerateBestOfNonFiniteScores(t *testing.T) {
+	tests := []struct {
+		name       string
+		scores     []float64
+		wantIndex  string
+		wantScores string
+	}{
+		{"NaN ranks last", []float64{math.NaN(), 1, 0.5}, "1", "[null,1,0.5]"},
+		{"positive infinity wins", []float64{2, math.Inf(1), math.NaN()}, "1", "[2,null,null]"},
+		{"all NaN", []float64{math.NaN(), math.NaN()}, "0", "[null,null]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newChatTestGateway(t)
+			calls := 0
+			score := func(string) float64 {
+				s := tt.scores[calls]
+				calls++
+				return s
+			}
+
+			resp, err := g.GenerateBestOf(context.Background(), NewLlama31(), "hi", len(tt.scores), score)
+			if err != nil {
+				t.Fatalf("GenerateBestOf: %v", err)
+			}
+			if got := resp.Metadata["best_of_index"]; got != tt.wantIndex {
+				t.Errorf("best_of_index = %q, want %q", got, tt.wantIndex)
+			}
+			if got := resp.Metadata["best_of_scores"]; got != tt.wantScores {
+				t.Errorf("best_of_scores = %q, want %q", got, tt.wantScores)
+			}
+		})
+	}
+}
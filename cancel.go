@@ -0,0 +1,71 @@
+package lingo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrProviderCanceled is the cause of requests canceled by CancelProvider
+var ErrProviderCanceled = errors.New("provider requests canceled")
+
+// inflightRequest is a request the gateway is currently sending to a provider
+type inflightRequest struct {
+	cancel context.CancelCauseFunc
+}
+
+// CancelProvider cancels every in-flight request to the given provider, such
+// as when it has been detected as unhealthy and waiting out timeouts would only
+// delay failing over. The canceled requests return an error wrapping
+// ErrProviderCanceled, classified as KindServer so GenerateWithFallback moves
+// on to the next model. Requests started afterwards are not affected.
+func (g *LLMGateway) CancelProvider(provider ProviderType) {
+	g.inflightMu.Lock()
+	requests := g.inflight[provider]
+	delete(g.inflight, provider)
+	g.inflightMu.Unlock()
+
+	for req := range requests {
+		req.cancel(ErrProviderCanceled)
+	}
+
+	if len(requests) > 0 {
+		g.logger.Info().
+			Str("provider", string(provider)).
+			Int("requests", len(requests)).
+			Msg("Canceled in-flight requests")
+	}
+}
+
+// trackRequest derives a context for a request to the given provider that
+// CancelProvider can cancel. The returned function must be called once the
+// request completes; it wraps the request's error with ErrProviderCanceled if
+// the request was canceled by CancelProvider.
+func (g *LLMGateway) trackRequest(ctx context.Context, provider ProviderType) (context.Context, func(error) error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	req := &inflightRequest{cancel: cancel}
+
+	g.inflightMu.Lock()
+	if g.inflight == nil {
+		g.inflight = make(map[ProviderType]map[*inflightRequest]struct{})
+	}
+	if g.inflight[provider] == nil {
+		g.inflight[provider] = make(map[*inflightRequest]struct{})
+	}
+	g.inflight[provider][req] = struct{}{}
+	g.inflightMu.Unlock()
+
+	return ctx, func(err error) error {
+		g.inflightMu.Lock()
+		delete(g.inflight[provider], req)
+		g.inflightMu.Unlock()
+
+		canceled := errors.Is(context.Cause(ctx), ErrProviderCanceled)
+		cancel(nil)
+
+		if err != nil && canceled && !errors.Is(err, ErrProviderCanceled) {
+			return fmt.Errorf("%w: %w", ErrProviderCanceled, err)
+		}
+		return err
+	}
+}
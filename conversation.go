@@ -0,0 +1,108 @@
+package lingo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ============================================================================
+// CONVERSATIONS
+// ============================================================================
+
+// Conversation keeps the history of a multi-turn chat with one model, so each
+// Send carries the earlier turns without the caller managing a []ChatMessage.
+// It is safe for concurrent use; concurrent Sends are applied one at a time.
+type Conversation struct {
+	mu            sync.Mutex
+	model         Model
+	messages      []ChatMessage
+	maxMessages   int
+	autoTrim      bool
+	reserveOutput int
+}
+
+// NewConversation starts an empty conversation with the given model. The
+// system prompt comes from the model.
+func NewConversation(model Model) *Conversation {
+	return &Conversation{model: model}
+}
+
+// WithMaxMessages keeps only the most recent n messages of history, dropping
+// the oldest user and assistant turns in pairs. An odd n is rounded up so
+// whole exchanges are kept. Zero, the default, keeps the whole conversation.
+func (c *Conversation) WithMaxMessages(n int) *Conversation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxMessages = n
+	c.trim()
+	return c
+}
+
+// WithAutoTrim trims the history with TrimMessages before each Send so it
+// fits the model's context window with reserveOutput tokens left for the
+// reply. Trimmed turns are dropped from the history.
+func (c *Conversation) WithAutoTrim(reserveOutput int) *Conversation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.autoTrim = true
+	c.reserveOutput = reserveOutput
+	return c
+}
+
+// Send adds prompt as the next user turn, generates the reply with
+// GenerateChat and appends it to the history. The history is left unchanged
+// if generation fails.
+func (c *Conversation) Send(ctx context.Context, g *LLMGateway, prompt string, opts ...GenerateOption) (*GenerationResponse, error) {
+	if prompt == "" {
+		return nil, fmt.Errorf("conversation prompt is empty")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	turn := ChatMessage{Role: RoleUser, Content: prompt}
+	messages := append(c.messages[:len(c.messages):len(c.messages)], turn)
+	if c.autoTrim {
+		messages, _ = TrimMessages(c.model, messages, c.reserveOutput, opts...)
+	}
+	resp, err := g.GenerateChat(ctx, c.model, messages, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.messages = append(messages, resp.ChatMessage())
+	c.trim()
+	return resp, nil
+}
+
+// Messages returns a copy of the conversation history
+func (c *Conversation) Messages() []ChatMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]ChatMessage(nil), c.messages...)
+}
+
+// Reset clears the conversation history
+func (c *Conversation) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages = nil
+}
+
+// trim drops the oldest turns beyond maxMessages. Turns are dropped in pairs
+// so the history still starts with a user turn.
+func (c *Conversation) trim() {
+	limit := c.maxMessages
+	if limit%2 == 1 {
+		limit++
+	}
+	if limit <= 0 || len(c.messages) <= limit {
+		return
+	}
+	drop := len(c.messages) - limit
+	if drop%2 == 1 {
+		drop++
+	}
+	c.messages = append([]ChatMessage(nil), c.messages[drop:]...)
+}
@@ -0,0 +1,144 @@
+package lingo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// chatCountProvider answers every chat request with the number of messages it
+// received, or with an error when the last message is "fail"
+type chatCountProvider struct{}
+
+func (p chatCountProvider) Generate(ctx context.Context, model Model, prompt string, opts ...GenerateOption) (*GenerationResponse, error) {
+	return p.GenerateChat(ctx, model, []ChatMessage{{Role: RoleUser, Content: prompt}}, opts...)
+}
+
+func (chatCountProvider) GenerateChat(_ context.Context, model Model, messages []ChatMessage, _ ...GenerateOption) (*GenerationResponse, error) {
+	if messages[len(messages)-1].Content == "fail" {
+		return nil, errors.New("boom")
+	}
+	return &GenerationResponse{Text: fmt.Sprintf("seen %d", len(messages)), Model: model.ModelName()}, nil
+}
+
+func (chatCountProvider) Health(context.Context) error { return nil }
+func (chatCountProvider) Close() error                 { return nil }
+
+// newChatTestGateway returns a gateway whose Ollama provider is a
+// chatCountProvider
+func newChatTestGateway(t *testing.T) *LLMGateway {
+	t.Helper()
+	g, err := New([]ProviderConfig{&OllamaConfig{}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	g.providers[ProviderOllama] = chatCountProvider{}
+	return g
+}
+
+func TestConversationSend(t *testing.T) {
+	g := newChatTestGateway(t)
+	defer g.Close()
+	ctx := context.Background()
+
+	chat := NewConversation(NewOllamaModel("llama3"))
+	if _, err := chat.Send(ctx, g, "Hi"); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	resp, err := chat.Send(ctx, g, "Again")
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if resp.Text != "seen 3" {
+		t.Errorf("reply = %q, want the earlier turns to be sent", resp.Text)
+	}
+
+	want := []ChatMessage{
+		{Role: RoleUser, Content: "Hi"},
+		{Role: RoleAssistant, Content: "seen 1"},
+		{Role: RoleUser, Content: "Again"},
+		{Role: RoleAssistant, Content: "seen 3"},
+	}
+	if got := chat.Messages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Messages() = %v, want %v", got, want)
+	}
+
+	if _, err := chat.Send(ctx, g, "fail"); err == nil {
+		t.Fatal("Send succeeded, want an error")
+	}
+	if got := chat.Messages(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Messages() after a failed Send = %v, want %v", got, want)
+	}
+
+	chat.Reset()
+	if got := chat.Messages(); len(got) != 0 {
+		t.Errorf("Messages() after Reset = %v, want empty", got)
+	}
+}
+
+func TestConversationMaxMessages(t *testing.T) {
+	history := func(n int) []ChatMessage {
+		var messages []ChatMessage
+		for i := 0; i < n; i++ {
+			role := RoleUser
+			if i%2 == 1 {
+				role = RoleAssistant
+			}
+			messages = append(messages, ChatMessage{Role: role, Content: fmt.Sprint(i)})
+		}
+		return messages
+	}
+
+	tests := []struct {
+		max, have, wantLen int
+	}{
+		{0, 6, 6},
+		{4, 6, 4},
+		{4, 4, 4},
+		{3, 6, 4}, // rounded up to whole exchanges
+		{1, 6, 2},
+		{1, 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d of %d", tt.max, tt.have), func(t *testing.T) {
+			chat := NewConversation(NewOllamaModel("llama3"))
+			chat.messages = history(tt.have)
+			chat.WithMaxMessages(tt.max)
+
+			got := chat.Messages()
+			if len(got) != tt.wantLen {
+				t.Fatalf("kept %d messages, want %d", len(got), tt.wantLen)
+			}
+			if got[0].Role != RoleUser {
+				t.Errorf("history starts with %s, want user", got[0].Role)
+			}
+			if want := history(tt.have)[tt.have-tt.wantLen:]; !reflect.DeepEqual(got, want) {
+				t.Errorf("kept %v, want the newest %v", got, want)
+			}
+		})
+	}
+}
+
+func TestConversationAutoTrim(t *testing.T) {
+	g := newChatTestGateway(t)
+	defer g.Close()
+	ctx := context.Background()
+
+	// Each 40-character turn is about 14 tokens, so only the last exchange and
+	// the new prompt fit
+	model := NewOllamaModel("llama3").WithNumCtx(60)
+	chat := NewConversation(model).WithAutoTrim(10)
+	for _, prompt := range []string{"a", "b", "c"} {
+		if _, err := chat.Send(ctx, g, strings.Repeat(prompt, 40)); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+
+	got := chat.Messages()
+	if len(got) != 4 || got[0].Content != strings.Repeat("b", 40) {
+		t.Errorf("Messages() = %v, want the last two exchanges", got)
+	}
+}
@@ -0,0 +1,132 @@
+package lingo
+
+// ============================================================================
+// DETERMINISTIC MODE
+// ============================================================================
+
+// DeterministicSeed is the sampling seed used in deterministic mode when the
+// request doesn't set one with WithSeed
+const DeterministicSeed int64 = 42
+
+// WithDeterministic makes every request through the gateway as reproducible
+// as its provider allows, for evals and benchmarks. Requests are sent with a
+// temperature of 0, overriding the model's temperature, and with a fixed
+// seed (DeterministicSeed, or the request's WithSeed) on providers that accept
+// one: OpenAI Chat Completions, Gemini and Ollama. A warning is logged for
+// requests a provider can't make deterministic, such as reasoning models that
+// don't accept a temperature or providers without a seed parameter.
+func WithDeterministic() Option {
+	return func(g *LLMGateway) {
+		g.deterministic = true
+	}
+}
+
+// WithSeed sets the sampling seed for this request on providers that accept
+// one (OpenAI Chat Completions, Gemini and Ollama). Other providers ignore it
+// and log a warning. A seed makes repeated requests mostly, but not always,
+// return the same output.
+func WithSeed(seed int64) GenerateOption {
+	return func(o *generateOptions) {
+		o.seed = seed
+		o.seedSet = true
+	}
+}
+
+// withDeterministic carries the gateway's deterministic mode to the provider
+func withDeterministic(deterministic bool) GenerateOption {
+	return func(o *generateOptions) {
+		o.deterministic = deterministic
+	}
+}
+
+// samplingSeed returns the seed to send with the request, if any
+func (o *generateOptions) samplingSeed() (int64, bool) {
+	if o.seedSet {
+		return o.seed, true
+	}
+	if o.deterministic {
+		return DeterministicSeed, true
+	}
+	return 0, false
+}
+
+// warnNotDeterministic logs that a request can't be made reproducible
+func warnNotDeterministic(logger Logger, model Model, reason string) {
+	logger.Warn().
+		Str("provider", string(model.Provider())).
+		Str("model", model.ModelName()).
+		Str("reason", reason).
+		Msg("Request may not be reproducible")
+}
+
+// ============================================================================
+// CACHE POLICY
+// ============================================================================
+
+// WithForceCache marks this request as cacheable even when it isn't
+// reproducible, for callers that want a repeated answer regardless
+func WithForceCache() GenerateOption {
+	return func(o *generateOptions) {
+		o.forceCache = true
+	}
+}
+
+// Cacheable reports whether a response to the request may be stored in a
+// response cache keyed by RequestFingerprint. Only reproducible requests are
+// cacheable: those sent with a temperature of 0 (deterministic mode, or a
+// model's WithTemperature(0)) or a fixed seed (WithSeed, or an Ollama model's
+// WithSeed). Seeds count only on providers that send them, and deterministic
+// mode only on models that accept a temperature of 0. Caching any other
+// request would serve one sampled answer as if it were the only one.
+// WithForceCache overrides the policy.
+func (g *LLMGateway) Cacheable(model Model, opts ...GenerateOption) bool {
+	reqOpts := newGenerateOptions(g.requestOptions(opts))
+	if reqOpts.forceCache {
+		return true
+	}
+	if reqOpts.seedSet && g.honorsSeed(model) {
+		return true
+	}
+	if reqOpts.deterministic && acceptsZeroTemperature(model) {
+		return true
+	}
+	if m, ok := model.(seededModel); ok {
+		if _, seeded := m.modelSeed(); seeded {
+			return true
+		}
+	}
+	if m, ok := model.(explicitTemperatureModel); ok {
+		if t, set := m.explicitTemperature(); set && t == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// honorsSeed reports whether the model's provider sends a request's seed.
+// Anthropic, Bedrock, Perplexity and OpenAI's Responses API ignore it.
+func (g *LLMGateway) honorsSeed(model Model) bool {
+	switch model.Provider() {
+	case ProviderGoogle, ProviderOllama:
+		return true
+	case ProviderOpenAI:
+		g.mu.RLock()
+		client, ok := g.providers[ProviderOpenAI].(*openAIClient)
+		g.mu.RUnlock()
+		return !ok || !client.useResponsesAPI
+	}
+	return false
+}
+
+// acceptsZeroTemperature reports whether deterministic mode can send the model
+// a temperature of 0. OpenAI reasoning models don't accept a temperature, and
+// Anthropic extended thinking requires the default one.
+func acceptsZeroTemperature(model Model) bool {
+	if _, ok := model.(openAIReasoningModel); ok {
+		return false
+	}
+	if m, ok := model.(anthropicBudgetModel); ok && m.budget() > 0 {
+		return false
+	}
+	return true
+}
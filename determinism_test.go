@@ -0,0 +1,57 @@
+package lingo
+
+import "testing"
+
+func TestCacheable(t *testing.T) {
+	newGateway := func(opts ...Option) *LLMGateway {
+		g, err := New([]ProviderConfig{&OllamaConfig{}}, opts...)
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		t.Cleanup(func() { g.Close() })
+		return g
+	}
+	plain := newGateway()
+	deterministic := newGateway(WithDeterministic())
+
+	responses, err := New([]ProviderConfig{&OpenAIConfig{APIKey: "test", UseResponsesAPI: true}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { responses.Close() })
+
+	tests := []struct {
+		name  string
+		g     *LLMGateway
+		model Model
+		opts  []GenerateOption
+		want  bool
+	}{
+		{"implicit default temperature", plain, NewGPT4o(), nil, false},
+		{"implicit Ollama temperature", plain, NewLlama31(), nil, false},
+		{"explicit non-zero temperature", plain, NewGPT4o().WithTemperature(0.7), nil, false},
+		{"explicit zero on OpenAI", plain, NewGPT4o().WithTemperature(0), nil, true},
+		{"explicit zero on Anthropic", plain, NewClaudeSonnet45().WithTemperature(0), nil, true},
+		{"explicit zero on Ollama", plain, NewLlama31().WithTemperature(0), nil, true},
+		{"explicit zero on Perplexity", plain, NewSonar().WithTemperature(0), nil, true},
+		{"request seed", plain, NewGPT4o(), []GenerateOption{WithSeed(7)}, true},
+		{"Ollama model seed", plain, NewLlama31().WithSeed(7), nil, true},
+		{"request seed on Gemini", plain, NewGemini25Flash(), []GenerateOption{WithSeed(7)}, true},
+		{"request seed on Anthropic", plain, NewClaudeSonnet45(), []GenerateOption{WithSeed(7)}, false},
+		{"request seed on Bedrock", plain, NewBedrockClaude35Sonnet(), []GenerateOption{WithSeed(7)}, false},
+		{"request seed on Perplexity", plain, NewSonar(), []GenerateOption{WithSeed(7)}, false},
+		{"request seed on the Responses API", responses, NewGPT4o(), []GenerateOption{WithSeed(7)}, false},
+		{"deterministic gateway", deterministic, NewGPT4o(), nil, true},
+		{"deterministic on Anthropic", deterministic, NewClaudeSonnet45(), nil, true},
+		{"deterministic with extended thinking", deterministic, NewClaudeSonnet45().WithThinkingBudget(2048), nil, false},
+		{"deterministic on a reasoning model", deterministic, NewO1(), nil, false},
+		{"forced", plain, NewGPT4o(), []GenerateOption{WithForceCache()}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.g.Cacheable(tt.model, tt.opts...); got != tt.want {
+				t.Errorf("Cacheable() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package lingo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/gerdou/lingo/internal/perplexity"
+	"github.com/openai/openai-go"
+)
+
+// ============================================================================
+// PROVIDER ERRORS
+// ============================================================================
+
+// ErrorKind classifies why a provider request failed
+type ErrorKind string
+
+const (
+	KindUnknown        ErrorKind = "unknown"
+	KindAuth           ErrorKind = "auth"            // Invalid or missing credentials, or no permission
+	KindInvalidRequest ErrorKind = "invalid_request" // The request was rejected as malformed or unsupported
+	KindNotFound       ErrorKind = "not_found"       // The model or endpoint doesn't exist
+	KindRateLimit      ErrorKind = "rate_limit"      // Rate limited or out of quota
+	KindTimeout        ErrorKind = "timeout"         // The request timed out
+	KindServer         ErrorKind = "server"          // The provider failed or is overloaded
+)
+
+// Retryable reports whether an error of this kind is transient and may
+// succeed if the request is repeated
+func (k ErrorKind) Retryable() bool {
+	switch k {
+	case KindRateLimit, KindTimeout, KindServer:
+		return true
+	}
+	return false
+}
+
+// ProviderError is returned by the gateway when a provider request fails.
+// It wraps the underlying error, so errors.Is and errors.As still reach the
+// SDK's own error types, and its message is the underlying error's message.
+type ProviderError struct {
+	Provider   ProviderType
+	Model      string // Empty for batch operations
+	Kind       ErrorKind
+	StatusCode int           // HTTP status code, when the provider returned one
+	RetryAfter time.Duration // Wait the provider asked for before retrying, for rate limit errors that report one
+	Usage      *TokenUsage   // Tokens the provider reported for the failed request, if any
+	Err        error
+}
+
+// Error implements the error interface
+func (e *ProviderError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// newProviderError wraps err in a ProviderError for the given provider and
+// model, which is empty for requests not tied to one (such as batch status).
+// Nil errors and errors that already are a ProviderError are returned
+// unchanged.
+func newProviderError(provider ProviderType, model string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var pe *ProviderError
+	if errors.As(err, &pe) {
+		return err
+	}
+
+	status := errorStatusCode(err)
+	pe = &ProviderError{
+		Provider:   provider,
+		Model:      model,
+		Kind:       classifyError(err, status),
+		StatusCode: status,
+		Err:        err,
+	}
+	if pe.Kind == KindRateLimit {
+		pe.RetryAfter = errorRetryAfter(err)
+	}
+	var usageErr *partialUsageError
+	if errors.As(err, &usageErr) {
+		usage := usageErr.usage
+		pe.Usage = &usage
+	}
+	return pe
+}
+
+// partialUsageError carries the token usage a provider reported for a request
+// that still failed, such as a response without any usable output. The
+// gateway copies the usage onto the ProviderError.
+type partialUsageError struct {
+	err   error
+	usage TokenUsage
+}
+
+func (e *partialUsageError) Error() string { return e.err.Error() }
+func (e *partialUsageError) Unwrap() error { return e.err }
+
+// withPartialUsage attaches the reported usage to err, unless no tokens were
+// reported
+func withPartialUsage(err error, usage TokenUsage) error {
+	if usage == (TokenUsage{}) {
+		return err
+	}
+	return &partialUsageError{err: err, usage: usage}
+}
+
+// errorStatusCoders extract HTTP status codes from the errors of SDKs that are
+// only linked in with their provider, registered by the provider's init()
+var errorStatusCoders []func(err error) int
+
+// errorStatusCode extracts the HTTP status code from a provider SDK error,
+// or returns 0 if there is none
+func errorStatusCode(err error) int {
+	var openaiErr *openai.Error
+	if errors.As(err, &openaiErr) {
+		return openaiErr.StatusCode
+	}
+	var anthropicErr *anthropic.Error
+	if errors.As(err, &anthropicErr) {
+		return anthropicErr.StatusCode
+	}
+	for _, statusCode := range errorStatusCoders {
+		if status := statusCode(err); status != 0 {
+			return status
+		}
+	}
+	var perplexityErr *perplexity.APIError
+	if errors.As(err, &perplexityErr) {
+		return perplexityErr.StatusCode
+	}
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode
+	}
+	// AWS SDK response errors
+	var awsErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &awsErr) {
+		return awsErr.HTTPStatusCode()
+	}
+	return 0
+}
+
+// errorRetryAfter returns the wait a rate limited provider asked for, read
+// from the Retry-After headers of SDK errors or else from the error message.
+// It returns 0 if the provider didn't say.
+func errorRetryAfter(err error) time.Duration {
+	var header http.Header
+	var openaiErr *openai.Error
+	var anthropicErr *anthropic.Error
+	switch {
+	case errors.As(err, &openaiErr) && openaiErr.Response != nil:
+		header = openaiErr.Response.Header
+	case errors.As(err, &anthropicErr) && anthropicErr.Response != nil:
+		header = anthropicErr.Response.Header
+	}
+
+	if ms, parseErr := strconv.ParseFloat(header.Get("Retry-After-Ms"), 64); parseErr == nil && ms > 0 {
+		return time.Duration(ms * float64(time.Millisecond))
+	}
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, parseErr := strconv.ParseFloat(value, 64); parseErr == nil && seconds > 0 {
+			return time.Duration(seconds * float64(time.Second))
+		}
+		if at, parseErr := http.ParseTime(value); parseErr == nil {
+			if wait := time.Until(at); wait > 0 {
+				return wait
+			}
+		}
+	}
+	return extractRetryAfter(err)
+}
+
+// classifyError determines the kind of a provider error from its HTTP status
+// code, falling back to inspecting the error itself
+func classifyError(err error, status int) ErrorKind {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return KindAuth
+	case status == http.StatusNotFound:
+		return KindNotFound
+	case status == http.StatusRequestTimeout:
+		return KindTimeout
+	case status == http.StatusTooManyRequests:
+		return KindRateLimit
+	case status >= 500:
+		return KindServer
+	case status >= 400:
+		return KindInvalidRequest
+	}
+
+	if errors.Is(err, ErrOptionOutOfRange) {
+		return KindInvalidRequest
+	}
+	if errors.Is(err, ErrProviderCanceled) {
+		return KindServer
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return KindTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return KindTimeout
+	}
+	if isRateLimitError(err) {
+		return KindRateLimit
+	}
+	if strings.Contains(strings.ToLower(err.Error()), "timeout") {
+		return KindTimeout
+	}
+	return KindUnknown
+}
+
+// ============================================================================
+// OPTION VALIDATION
+// ============================================================================
+
+// ErrOptionOutOfRange is returned when a model option is outside the range the
+// provider accepts, such as an OpenAI temperature of 1.5 reused on Claude
+var ErrOptionOutOfRange = errors.New("option out of range")
+
+// validateSampling checks a temperature and top-p against the provider's
+// accepted ranges. Temperatures run from 0 to maxTemperature and top-p from 0
+// to 1; zero means the option wasn't set.
+func validateSampling(provider ProviderType, temperature, maxTemperature, topP float64) error {
+	if temperature < 0 || temperature > maxTemperature {
+		return fmt.Errorf("%w: temperature %g is not accepted by %s (range 0 to %g)", ErrOptionOutOfRange, temperature, provider, maxTemperature)
+	}
+	if topP < 0 || topP > 1 {
+		return fmt.Errorf("%w: top_p %g is not accepted by %s (range 0 to 1)", ErrOptionOutOfRange, topP, provider)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package lingo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ============================================================================
+// FALLBACK
+// ============================================================================
+
+// GenerateWithFallback tries each model in order and returns the first
+// successful response. Responses from any model but the first are marked with
+// SourceFallback and the index of the model that answered. If every model
+// fails, the returned error joins all of their errors.
+//
+// With WithFallbackOnContentFilter, responses blocked by a safety filter also
+// move on to the next model. With WithFallbackOnRetryableOnly, only rate limit,
+// timeout and server errors move on; any other error ends the chain immediately.
+func (g *LLMGateway) GenerateWithFallback(ctx context.Context, models []Model, prompt string, opts ...GenerateOption) (*GenerationResponse, error) {
+	if len(models) == 0 {
+		return nil, fmt.Errorf("at least one model is required for fallback")
+	}
+
+	reqOpts := newGenerateOptions(opts)
+
+	var errs []error
+	var filtered *GenerationResponse
+	for i, model := range models {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		resp, err := g.Generate(ctx, model, prompt, opts...)
+		if err != nil {
+			g.logger.Debug().
+				Err(err).
+				Str("provider", string(model.Provider())).
+				Str("model", model.ModelName()).
+				Int("fallback_index", i).
+				Msg("Model failed, trying next fallback")
+			errs = append(errs, fmt.Errorf("%s/%s: %w", model.Provider(), model.ModelName(), err))
+			if reqOpts.fallbackRetryableOnly && !isRetryableError(err) {
+				return nil, fmt.Errorf("fallback aborted: %w", errors.Join(errs...))
+			}
+			continue
+		}
+
+		if i > 0 {
+			resp.Source = SourceFallback
+			resp.FallbackIndex = i
+		}
+
+		if reqOpts.fallbackOnContentFilter && resp.FinishKind() == FinishContentFilter {
+			g.logger.Debug().
+				Str("provider", string(model.Provider())).
+				Str("model", model.ModelName()).
+				Str("finish_reason", resp.FinishReason).
+				Int("fallback_index", i).
+				Msg("Response blocked by content filter, trying next fallback")
+			filtered = resp
+			continue
+		}
+		return resp, nil
+	}
+
+	if filtered != nil {
+		return filtered, nil
+	}
+	return nil, fmt.Errorf("all fallback models failed: %w", errors.Join(errs...))
+}
+
+// isRetryableError reports whether err is a provider error of a retryable
+// kind, one that another model may not run into. Errors that aren't a
+// ProviderError can't be classified and aren't retryable.
+func isRetryableError(err error) bool {
+	var pe *ProviderError
+	if !errors.As(err, &pe) {
+		return false
+	}
+	return pe.Kind.Retryable()
+}
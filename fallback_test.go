@@ -0,0 +1,81 @@
+package lingo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"rate limit", &ProviderError{Kind: KindRateLimit}, true},
+		{"timeout", &ProviderError{Kind: KindTimeout}, true},
+		{"server", &ProviderError{Kind: KindServer}, true},
+		{"wrapped server", fmt.Errorf("generate: %w", &ProviderError{Kind: KindServer}), true},
+		{"auth", &ProviderError{Kind: KindAuth}, false},
+		{"invalid request", &ProviderError{Kind: KindInvalidRequest}, false},
+		{"not found", &ProviderError{Kind: KindNotFound}, false},
+		{"unknown", &ProviderError{Kind: KindUnknown}, false},
+		{"not a provider error", errors.New("boom"), false},
+		{"canceled", context.Canceled, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryableError(tt.err); got != tt.want {
+				t.Errorf("isRetryableError(%v) = %t, want %t", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFallbackOnRetryableOnly(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var body struct {
+			Model string `json:"model"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Model == "garbled" {
+			fmt.Fprint(w, `not json`)
+			return
+		}
+		fmt.Fprint(w, `{"model":"llama3","message":{"role":"assistant","content":"Hello"},"done":true}`)
+	}))
+	defer srv.Close()
+
+	g, err := New([]ProviderConfig{&OllamaConfig{BaseURL: srv.URL}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer g.Close()
+	models := []Model{NewOllamaModel("garbled"), NewOllamaModel("llama3")}
+
+	// An unclassified error ends the chain
+	_, err = g.GenerateWithFallback(context.Background(), models, "Say hello", WithFallbackOnRetryableOnly())
+	var pe *ProviderError
+	if !errors.As(err, &pe) || pe.Kind != KindUnknown {
+		t.Fatalf("GenerateWithFallback error = %v, want an unknown provider error", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("provider received %d requests, want 1", n)
+	}
+
+	// Without the option it falls back
+	resp, err := g.GenerateWithFallback(context.Background(), models, "Say hello")
+	if err != nil {
+		t.Fatalf("GenerateWithFallback without the option: %v", err)
+	}
+	if resp.FallbackIndex != 1 {
+		t.Errorf("FallbackIndex = %d, want 1", resp.FallbackIndex)
+	}
+}
@@ -0,0 +1,109 @@
+package lingo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ============================================================================
+// REQUEST FINGERPRINTS
+// ============================================================================
+
+// RequestFingerprint returns a stable hash identifying a request: the
+// provider, model name, system prompt, prompt, every model option and the
+// generate options that affect the result. Two requests with the same
+// fingerprint produce the same provider call, so it can be used as a cache key.
+// It doesn't see gateway-wide settings such as WithDeterministic; use
+// LLMGateway.RequestFingerprint for requests sent through a gateway.
+func RequestFingerprint(model Model, prompt string, opts ...GenerateOption) string {
+	return requestKey(model, prompt, opts...)
+}
+
+// RequestFingerprint returns the fingerprint of a request sent through the
+// gateway, including the gateway-wide settings that change the provider call
+func (g *LLMGateway) RequestFingerprint(model Model, prompt string, opts ...GenerateOption) string {
+	return requestKey(model, prompt, g.requestOptions(opts)...)
+}
+
+// promptFormatters return the prompt a model's own template produces, so
+// models whose templates are functions, which can't be compared, still get
+// distinct fingerprints. Providers with prompt templates register one in init.
+var promptFormatters []func(model Model, system, prompt string) (string, bool)
+
+// requestKey builds the canonical request description and hashes it
+func requestKey(model Model, prompt string, opts ...GenerateOption) string {
+	reqOpts := newGenerateOptions(opts)
+	system := reqOpts.systemPromptFor(model)
+
+	fields := []string{
+		"provider=" + string(model.Provider()),
+		"model=" + model.ModelName(),
+		"system=" + system,
+		"prompt=" + prompt,
+		fmt.Sprintf("opt.include_raw=%t", reqOpts.includeRaw),
+		fmt.Sprintf("opt.dry_run=%t", reqOpts.dryRun),
+		fmt.Sprintf("opt.minimal_response=%t", reqOpts.minimalResponse),
+		fmt.Sprintf("opt.max_input_chars=%d", reqOpts.maxInputChars),
+		"opt.input_truncation=" + string(reqOpts.inputTruncation),
+		"opt.stop_pattern=" + reqOpts.stopPattern,
+		"opt.stop_sequences=" + strings.Join(reqOpts.stopSequences, "\x00"),
+		fmt.Sprintf("opt.seed=%d,%t", reqOpts.seed, reqOpts.seedSet),
+		fmt.Sprintf("opt.deterministic=%t", reqOpts.deterministic),
+		fmt.Sprintf("opt.extra_body_overrides=%t", reqOpts.extraBodyOverrides),
+	}
+	fields = appendModelFields(fields, "opt.extra_body.", reflect.ValueOf(reqOpts.extraBody))
+	fields = appendModelFields(fields, "model.", reflect.ValueOf(model))
+	for _, format := range promptFormatters {
+		if formatted, ok := format(model, system, prompt); ok {
+			fields = append(fields, "formatted_prompt="+formatted)
+		}
+	}
+	sort.Strings(fields)
+
+	h := sha256.New()
+	for _, f := range fields {
+		// Length-prefix each field so values can't run into each other
+		fmt.Fprintf(h, "%d:%s;", len(f), f)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// appendModelFields flattens the option fields of a model into "path=value"
+// strings. Unexported fields are read through reflection; functions and
+// channels are skipped since they can't be compared.
+func appendModelFields(fields []string, path string, v reflect.Value) []string {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return append(fields, path+"=<nil>")
+		}
+		return appendModelFields(fields, path, v.Elem())
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			fields = appendModelFields(fields, path+t.Field(i).Name+".", v.Field(i))
+		}
+		return fields
+	case reflect.Map:
+		keys := v.MapKeys()
+		entries := make([]string, 0, len(keys))
+		for _, k := range keys {
+			entries = append(entries, fmt.Sprintf("%v:%v", k, v.MapIndex(k)))
+		}
+		sort.Strings(entries)
+		return append(fields, path+"={"+strings.Join(entries, ",")+"}")
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			fields = appendModelFields(fields, fmt.Sprintf("%s%d.", path, i), v.Index(i))
+		}
+		return fields
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer, reflect.Invalid:
+		return fields
+	default:
+		return append(fields, fmt.Sprintf("%s=%v", path, v))
+	}
+}
@@ -0,0 +1,85 @@
+package lingo
+
+import "testing"
+
+func TestRequestFingerprintStable(t *testing.T) {
+	key := func() string {
+		return RequestFingerprint(NewGPT4o().WithTemperature(0.2).WithLogitBias(map[int]int{1: 2, 3: 4}), "Hello",
+			WithSeed(7), WithStopSequences("END"), WithExtraBody(map[string]any{"a": 1, "b": "x"}))
+	}
+	first := key()
+	for i := 0; i < 20; i++ {
+		if got := key(); got != first {
+			t.Fatalf("fingerprint changed between calls: %s != %s", got, first)
+		}
+	}
+}
+
+func TestRequestFingerprintOrderIndependent(t *testing.T) {
+	model := NewGPT4o()
+	a := RequestFingerprint(model, "Hello", WithSeed(7), WithStopSequences("END"), WithMaxInputChars(100))
+	b := RequestFingerprint(model, "Hello", WithMaxInputChars(100), WithStopSequences("END"), WithSeed(7))
+	if a != b {
+		t.Errorf("option order changed the fingerprint")
+	}
+
+	// Map iteration order is random, so build the maps in different orders
+	first := map[int]int{}
+	second := map[int]int{}
+	for _, k := range []int{1, 2, 3, 4} {
+		first[k] = k
+	}
+	for _, k := range []int{4, 3, 2, 1} {
+		second[k] = k
+	}
+	if RequestFingerprint(NewGPT4o().WithLogitBias(first), "Hello") != RequestFingerprint(NewGPT4o().WithLogitBias(second), "Hello") {
+		t.Errorf("logit bias key order changed the fingerprint")
+	}
+}
+
+func TestRequestFingerprintDistinguishes(t *testing.T) {
+	base := RequestFingerprint(NewGPT4o(), "Hello")
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{"prompt", RequestFingerprint(NewGPT4o(), "Hello!")},
+		{"model", RequestFingerprint(NewGPT4oMini(), "Hello")},
+		{"model option", RequestFingerprint(NewGPT4o().WithTemperature(0), "Hello")},
+		{"system prompt", RequestFingerprint(NewGPT4o().WithSystemPrompt("Be brief"), "Hello")},
+		{"request system prompt", RequestFingerprint(NewGPT4o(), "Hello", WithSystemPromptOpt("Be brief"))},
+		{"logit bias", RequestFingerprint(NewGPT4o().WithLogitBias(map[int]int{1: 2}), "Hello")},
+		{"input limit", RequestFingerprint(NewGPT4o(), "Hello", WithMaxInputChars(100))},
+		{"stop sequences", RequestFingerprint(NewGPT4o(), "Hello", WithStopSequences("END"))},
+		{"dry run", RequestFingerprint(NewGPT4o(), "Hello", WithDryRun(true))},
+		{"seed", RequestFingerprint(NewGPT4o(), "Hello", WithSeed(1))},
+		{"extra body", RequestFingerprint(NewGPT4o(), "Hello", WithExtraBody(map[string]any{"a": 1}))},
+		{"deterministic", RequestFingerprint(NewGPT4o(), "Hello", withDeterministic(true))},
+	}
+	for _, tt := range tests {
+		if tt.key == base {
+			t.Errorf("%s doesn't change the fingerprint", tt.name)
+		}
+	}
+}
+
+func TestGatewayRequestFingerprint(t *testing.T) {
+	plain, err := New([]ProviderConfig{&OllamaConfig{}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer plain.Close()
+	deterministic, err := New([]ProviderConfig{&OllamaConfig{}}, WithDeterministic())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer deterministic.Close()
+
+	model := NewLlama31()
+	if plain.RequestFingerprint(model, "Hello") != RequestFingerprint(model, "Hello") {
+		t.Errorf("a plain gateway changed the fingerprint")
+	}
+	if deterministic.RequestFingerprint(model, "Hello") == plain.RequestFingerprint(model, "Hello") {
+		t.Errorf("deterministic mode doesn't change the fingerprint")
+	}
+}
@@ -2,11 +2,19 @@ package lingo
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/rs/zerolog"
+	"golang.org/x/sync/singleflight"
 )
 
 // ProviderFactory creates a new provider instance from a provider config
@@ -25,13 +33,75 @@ func RegisterProvider(providerType ProviderType, factory ProviderFactory) {
 	providerFactories[providerType] = factory
 }
 
+// RegisteredFactories returns the provider types that have a registered
+// factory, sorted by name. A provider missing here wasn't compiled into the
+// binary, for example because its package isn't imported or a build tag
+// excluded it.
+func RegisteredFactories() []ProviderType {
+	providerFactoriesMu.RLock()
+	defer providerFactoriesMu.RUnlock()
+
+	types := make([]ProviderType, 0, len(providerFactories))
+	for providerType := range providerFactories {
+		types = append(types, providerType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// errUnknownProviderType builds an ErrUnknownProviderType error listing the
+// providers that can be configured
+func errUnknownProviderType(providerType ProviderType) error {
+	available := RegisteredFactories()
+	names := make([]string, len(available))
+	for i, p := range available {
+		names[i] = string(p)
+	}
+	return fmt.Errorf("%w: %s (available: %s); the provider may not be compiled in, "+
+		"check that the package registering it is imported and not excluded by build tags",
+		ErrUnknownProviderType, providerType, strings.Join(names, ", "))
+}
+
 // LLMGateway implements the Gateway interface and manages multiple LLM providers
 type LLMGateway struct {
 	providers map[ProviderType]Provider
 	mu        sync.RWMutex
 	logger    Logger
+
+	singleflight bool
+	flight       singleflight.Group
+
+	router *ModelRouter
+
+	defaultModel Model
+
+	promptRedactor      func(string) string
+	promptPreviewLength int
+
+	requestInspector func(provider ProviderType, body []byte)
+
+	deterministic bool
+
+	closed bool // Guarded by mu
+
+	inflight   map[ProviderType]map[*inflightRequest]struct{}
+	inflightMu sync.Mutex
 }
 
+// ErrNoDefaultModel is returned by GenerateDefault when no default model was configured
+var ErrNoDefaultModel = errors.New("no default model configured; use WithDefaultModel")
+
+// ErrGatewayClosed is returned for requests made after Close
+var ErrGatewayClosed = errors.New("gateway is closed")
+
+// ErrUnknownProviderType is returned by New for a config whose provider has no
+// registered factory
+var ErrUnknownProviderType = errors.New("unknown provider type")
+
+// ErrProviderNotRegistered is returned when a request targets a provider that
+// wasn't configured on the gateway
+var ErrProviderNotRegistered = errors.New("provider not registered")
+
 // Option is a functional option for configuring the gateway
 type Option func(*LLMGateway)
 
@@ -49,6 +119,63 @@ func WithZerolog(logger zerolog.Logger) Option {
 	}
 }
 
+// WithSlog sets a log/slog logger for the gateway
+func WithSlog(logger *slog.Logger) Option {
+	return func(g *LLMGateway) {
+		g.logger = NewSlogAdapter(logger)
+	}
+}
+
+// WithSingleflight enables deduplication of identical concurrent requests.
+// Calls with the same model, model options, prompt and generate options that
+// overlap in time share a single provider call. A caller whose context is
+// done stops waiting without canceling the call for the others.
+func WithSingleflight(enabled bool) Option {
+	return func(g *LLMGateway) {
+		g.singleflight = enabled
+	}
+}
+
+// WithModelRouter sets the router used by GenerateRouted
+func WithModelRouter(router *ModelRouter) Option {
+	return func(g *LLMGateway) {
+		g.router = router
+	}
+}
+
+// WithDefaultModel sets the model used by GenerateDefault
+func WithDefaultModel(model Model) Option {
+	return func(g *LLMGateway) {
+		g.defaultModel = model
+	}
+}
+
+// WithPromptRedactor sets a function applied to prompts before they are logged,
+// e.g. to mask emails or account numbers
+func WithPromptRedactor(redactor func(string) string) Option {
+	return func(g *LLMGateway) {
+		g.promptRedactor = redactor
+	}
+}
+
+// WithPromptPreviewLength sets how many characters of a prompt are logged on
+// failures (default: 100). A negative value disables prompt previews.
+func WithPromptPreviewLength(n int) Option {
+	return func(g *LLMGateway) {
+		g.promptPreviewLength = n
+	}
+}
+
+// WithRequestInspector sets a debug hook called with the JSON request body just
+// before each Generate request is sent. Bedrock, Ollama and Perplexity pass
+// the exact body; for SDK-based providers it's the JSON encoding of the
+// request params. The prompt is included unredacted.
+func WithRequestInspector(inspector func(provider ProviderType, body []byte)) Option {
+	return func(g *LLMGateway) {
+		g.requestInspector = inspector
+	}
+}
+
 // New creates a new LLM gateway with the provided provider configurations.
 // Each ProviderConfig in the slice will be used to initialize its corresponding provider.
 // Returns an error if any provider fails to initialize.
@@ -56,6 +183,7 @@ func New(configs []ProviderConfig, opts ...Option) (*LLMGateway, error) {
 	g := &LLMGateway{
 		providers: make(map[ProviderType]Provider),
 		logger:    &NopLogger{},
+		router:    NewModelRouter(),
 	}
 
 	// Apply options first so logger is available during registration
@@ -75,7 +203,7 @@ func New(configs []ProviderConfig, opts ...Option) (*LLMGateway, error) {
 		factory, exists := providerFactories[providerType]
 		providerFactoriesMu.RUnlock()
 		if !exists {
-			return nil, fmt.Errorf("unknown provider type: %s", providerType)
+			return nil, errUnknownProviderType(providerType)
 		}
 
 		client, err := factory(config, g.logger)
@@ -83,6 +211,10 @@ func New(configs []ProviderConfig, opts ...Option) (*LLMGateway, error) {
 			return nil, fmt.Errorf("failed to initialize %s: %w", providerType, err)
 		}
 
+		if c, ok := client.(promptLoggingProvider); ok {
+			c.setPromptLogging(g.promptRedactor, g.promptPreviewLength)
+		}
+
 		g.providers[providerType] = client
 		g.logger.Info().Str("provider", string(providerType)).Msg("Provider registered")
 	}
@@ -96,27 +228,489 @@ func New(configs []ProviderConfig, opts ...Option) (*LLMGateway, error) {
 
 // Generate generates text using the specified model.
 // The model carries its own generation options and knows which provider to use.
-func (g *LLMGateway) Generate(ctx context.Context, model Model, prompt string) (*GenerationResponse, error) {
+// Per-request behavior can be adjusted with GenerateOptions.
+func (g *LLMGateway) Generate(ctx context.Context, model Model, prompt string, opts ...GenerateOption) (*GenerationResponse, error) {
 	provider := model.Provider()
 
-	g.mu.RLock()
-	client, exists := g.providers[provider]
-	g.mu.RUnlock()
+	client, err := g.lookupProvider(provider)
+	if err != nil {
+		return nil, err
+	}
 
-	if !exists {
-		return nil, fmt.Errorf("provider %s is not registered", provider)
+	opts = g.requestOptions(opts)
+	reqOpts := newGenerateOptions(opts)
+
+	prompt, err = g.fitInput(model, prompt, reqOpts)
+	if err != nil {
+		return nil, err
+	}
+	stop, err := reqOpts.stopRegexp()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := reqOpts.renderSystemPrompt(); err != nil {
+		return nil, err
+	}
+
+	ctx, done := g.trackRequest(ctx, provider)
+	var resp *GenerationResponse
+	// Dry runs never share a call, so they can't receive a real response
+	if g.singleflight && !reqOpts.dryRun && !reqOpts.unshared {
+		resp, err = g.generateShared(ctx, client, model, prompt, opts)
+	} else {
+		start := time.Now()
+		resp, err = client.Generate(ctx, model, prompt, opts...)
+		if err == nil {
+			g.finishResponse(resp, model, start, reqOpts.logger(g.logger))
+		}
+	}
+	if err = done(err); err != nil {
+		return nil, newProviderError(provider, model.ModelName(), err)
+	}
+
+	trimAtStop(resp, stop)
+	return resp, nil
+}
+
+// GenerateDefault generates text using the gateway's default model.
+// Returns ErrNoDefaultModel if none was configured with WithDefaultModel.
+func (g *LLMGateway) GenerateDefault(ctx context.Context, prompt string, opts ...GenerateOption) (*GenerationResponse, error) {
+	if g.defaultModel == nil {
+		return nil, ErrNoDefaultModel
+	}
+	return g.Generate(ctx, g.defaultModel, prompt, opts...)
+}
+
+// GenerateStream generates text using the specified model, calling handler
+// with each chunk as it arrives. The returned response holds the full text and
+// usage once the stream completes. Returns an error if the model's provider
+// doesn't support streaming.
+func (g *LLMGateway) GenerateStream(ctx context.Context, model Model, prompt string, handler StreamHandler, opts ...GenerateOption) (*GenerationResponse, error) {
+	provider := model.Provider()
+
+	client, err := g.lookupProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	streamer, ok := client.(StreamingProvider)
+	if !ok {
+		return nil, fmt.Errorf("provider %s does not support streaming", provider)
+	}
+
+	opts = g.requestOptions(opts)
+	reqOpts := newGenerateOptions(opts)
+
+	prompt, err = g.fitInput(model, prompt, reqOpts)
+	if err != nil {
+		return nil, err
+	}
+	stop, err := reqOpts.stopRegexp()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := reqOpts.renderSystemPrompt(); err != nil {
+		return nil, err
+	}
+	if stop != nil {
+		handler = stopStream(handler, stop)
+	}
+
+	ctx, done := g.trackRequest(ctx, provider)
+	start := time.Now()
+	resp, err := streamer.GenerateStream(ctx, model, prompt, handler, opts...)
+	if err = done(err); err != nil {
+		return nil, newProviderError(provider, model.ModelName(), err)
+	}
+
+	g.finishResponse(resp, model, start, reqOpts.logger(g.logger))
+	trimAtStop(resp, stop)
+	return resp, nil
+}
+
+// GenerateChat generates the next assistant turn of a conversation. Messages
+// alternate between user and assistant turns and end with a user turn; the
+// system prompt comes from the model or WithSystemPromptOpt. Returns an error
+// if the model's provider doesn't support multi-turn conversations.
+func (g *LLMGateway) GenerateChat(ctx context.Context, model Model, messages []ChatMessage, opts ...GenerateOption) (*GenerationResponse, error) {
+	provider := model.Provider()
+
+	client, err := g.lookupProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	chatter, ok := client.(ChatProvider)
+	if !ok {
+		return nil, fmt.Errorf("provider %s does not support multi-turn chat", provider)
+	}
+	if err := validateChat(messages); err != nil {
+		return nil, err
+	}
+
+	opts = g.requestOptions(opts)
+	reqOpts := newGenerateOptions(opts)
+
+	messages, err = g.fitChat(model, messages, reqOpts)
+	if err != nil {
+		return nil, err
+	}
+	stop, err := reqOpts.stopRegexp()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := reqOpts.renderSystemPrompt(); err != nil {
+		return nil, err
+	}
+
+	ctx, done := g.trackRequest(ctx, provider)
+	start := time.Now()
+	resp, err := chatter.GenerateChat(ctx, model, messages, opts...)
+	if err = done(err); err != nil {
+		return nil, newProviderError(provider, model.ModelName(), err)
+	}
+
+	g.finishResponse(resp, model, start, reqOpts.logger(g.logger))
+	trimAtStop(resp, stop)
+	return resp, nil
+}
+
+// GenerateChatStream generates the next assistant turn of a conversation like
+// GenerateChat, calling handler with each chunk as it arrives. The returned
+// response holds the full reply; append resp.ChatMessage() to the messages to
+// continue the conversation. Returns an error if the model's provider can't
+// stream multi-turn conversations.
+func (g *LLMGateway) GenerateChatStream(ctx context.Context, model Model, messages []ChatMessage, handler StreamHandler, opts ...GenerateOption) (*GenerationResponse, error) {
+	provider := model.Provider()
+
+	client, err := g.lookupProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	streamer, ok := client.(ChatStreamingProvider)
+	if !ok {
+		return nil, fmt.Errorf("provider %s does not support streaming multi-turn chat", provider)
+	}
+	if err := validateChat(messages); err != nil {
+		return nil, err
+	}
+
+	opts = g.requestOptions(opts)
+	reqOpts := newGenerateOptions(opts)
+
+	messages, err = g.fitChat(model, messages, reqOpts)
+	if err != nil {
+		return nil, err
+	}
+	stop, err := reqOpts.stopRegexp()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := reqOpts.renderSystemPrompt(); err != nil {
+		return nil, err
+	}
+	if stop != nil {
+		handler = stopStream(handler, stop)
+	}
+
+	ctx, done := g.trackRequest(ctx, provider)
+	start := time.Now()
+	resp, err := streamer.GenerateChatStream(ctx, model, messages, handler, opts...)
+	if err = done(err); err != nil {
+		return nil, newProviderError(provider, model.ModelName(), err)
+	}
+
+	g.finishResponse(resp, model, start, reqOpts.logger(g.logger))
+	trimAtStop(resp, stop)
+	return resp, nil
+}
+
+// validateChat checks that a conversation alternates between user and
+// assistant turns and ends with a user turn
+func validateChat(messages []ChatMessage) error {
+	if len(messages) == 0 {
+		return fmt.Errorf("chat requires at least one message")
+	}
+	for i, m := range messages {
+		want := RoleUser
+		if (len(messages)-1-i)%2 == 1 {
+			want = RoleAssistant
+		}
+		if m.Role != want {
+			return fmt.Errorf("chat message %d has role %q, expected %q: turns must alternate and end with a user turn", i, m.Role, want)
+		}
+	}
+	return nil
+}
+
+// Transcribe converts audio to text using the specified transcription model.
+// Returns an error if the model's provider doesn't support transcription.
+func (g *LLMGateway) Transcribe(ctx context.Context, model TranscriptionModel, audio []byte) (string, error) {
+	provider := model.Provider()
+
+	client, err := g.lookupProvider(provider)
+	if err != nil {
+		return "", err
+	}
+
+	transcriber, ok := client.(Transcriber)
+	if !ok {
+		return "", fmt.Errorf("provider %s does not support transcription", provider)
+	}
+
+	ctx, done := g.trackRequest(ctx, provider)
+	text, err := transcriber.Transcribe(ctx, model, audio)
+	if err = done(err); err != nil {
+		return "", newProviderError(provider, model.ModelName(), err)
+	}
+	return text, nil
+}
+
+// GenerateImage generates images using the specified image model.
+// Returns an error if the model's provider doesn't support image generation.
+func (g *LLMGateway) GenerateImage(ctx context.Context, model ImageModel, prompt string) ([]ImageResult, error) {
+	provider := model.Provider()
+
+	client, err := g.lookupProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	generator, ok := client.(ImageGenerator)
+	if !ok {
+		return nil, fmt.Errorf("provider %s does not support image generation", provider)
 	}
 
-	resp, err := client.Generate(ctx, model, prompt)
+	ctx, done := g.trackRequest(ctx, provider)
+	images, err := generator.GenerateImage(ctx, model, prompt)
+	if err = done(err); err != nil {
+		return nil, newProviderError(provider, model.ModelName(), err)
+	}
+	return images, nil
+}
+
+// GenerateInto generates text using the specified model and decodes the
+// response as JSON into v. Pair it with a model configured for structured
+// output (e.g. an Ollama model with WithFormat) for reliable results.
+func (g *LLMGateway) GenerateInto(ctx context.Context, model Model, prompt string, v any, opts ...GenerateOption) (*GenerationResponse, error) {
+	resp, err := g.Generate(ctx, model, prompt, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	// Set provider in response
-	resp.Provider = provider
+	if err := json.Unmarshal([]byte(extractJSON(resp.Text)), v); err != nil {
+		return resp, fmt.Errorf("failed to decode %s response into %T: %w", model.ModelName(), v, err)
+	}
+
 	return resp, nil
 }
 
+// GenerateRouted generates text using a model picked by the gateway's
+// ModelRouter for the given hint. Only models whose provider is registered
+// are considered.
+func (g *LLMGateway) GenerateRouted(ctx context.Context, hint ModelHint, prompt string, opts ...GenerateOption) (*GenerationResponse, error) {
+	if g.router == nil {
+		return nil, fmt.Errorf("no model router configured")
+	}
+
+	model, err := g.router.Select(hint, g.IsRegistered)
+	if err != nil {
+		return nil, err
+	}
+
+	g.logger.Debug().
+		Str("hint", string(hint)).
+		Str("provider", string(model.Provider())).
+		Str("model", model.ModelName()).
+		Msg("Routed request to model")
+
+	return g.Generate(ctx, model, prompt, opts...)
+}
+
+// requestOptions prepends the gateway-level settings to the caller's options
+func (g *LLMGateway) requestOptions(opts []GenerateOption) []GenerateOption {
+	return append([]GenerateOption{
+		withPromptLogging(g.promptRedactor, g.promptPreviewLength),
+		withRequestInspector(g.requestInspector),
+		withDeterministic(g.deterministic),
+	}, opts...)
+}
+
+// finishResponse fills in the gateway-level fields of a provider response and
+// logs the request latency
+func (g *LLMGateway) finishResponse(resp *GenerationResponse, model Model, start time.Time, logger Logger) {
+	resp.Provider = model.Provider()
+	resp.Source = SourceProvider
+	resp.Latency = time.Since(start)
+
+	logger.Debug().
+		Str("provider", string(model.Provider())).
+		Str("model", model.ModelName()).
+		Dur("latency", resp.Latency).
+		Msg("Request completed")
+}
+
+// fitInput applies the request's input length guard, warning when the prompt
+// had to be truncated
+func (g *LLMGateway) fitInput(model Model, prompt string, reqOpts *generateOptions) (string, error) {
+	fitted, err := reqOpts.fitInput(prompt)
+	if err != nil {
+		return "", err
+	}
+
+	if len(fitted) != len(prompt) {
+		g.logger.Warn().
+			Str("provider", string(model.Provider())).
+			Str("model", model.ModelName()).
+			Int("original_chars", utf8.RuneCountInString(prompt)).
+			Int("truncated_chars", utf8.RuneCountInString(fitted)).
+			Msg("Prompt truncated to fit the input limit")
+	}
+	return fitted, nil
+}
+
+// fitChat applies the input length guard to the final user turn of a
+// conversation, returning a copy if the turn was truncated
+func (g *LLMGateway) fitChat(model Model, messages []ChatMessage, reqOpts *generateOptions) ([]ChatMessage, error) {
+	last := len(messages) - 1
+	fitted, err := g.fitInput(model, messages[last].Content, reqOpts)
+	if err != nil {
+		return nil, err
+	}
+	if len(fitted) == len(messages[last].Content) {
+		return messages, nil
+	}
+
+	messages = append([]ChatMessage(nil), messages...)
+	messages[last].Content = fitted
+	return messages, nil
+}
+
+// trimAtStop cuts the response text at the first match of stop, if any
+func trimAtStop(resp *GenerationResponse, stop *regexp.Regexp) {
+	if stop == nil {
+		return
+	}
+	loc := stop.FindStringIndex(resp.Text)
+	if loc == nil {
+		return
+	}
+
+	resp.Text = resp.Text[:loc[0]]
+	resp.FinishReason = "stop"
+	if resp.Metadata == nil {
+		resp.Metadata = make(map[string]string)
+	}
+	resp.Metadata["stop_regex_matched"] = "true"
+}
+
+// stopStream wraps handler so that chunks stop being emitted once the text
+// streamed so far matches stop. Only the text before the match is emitted.
+func stopStream(handler StreamHandler, stop *regexp.Regexp) StreamHandler {
+	var text strings.Builder
+	emitted := 0
+	stopped := false
+
+	return func(chunk StreamChunk) error {
+		if stopped {
+			return nil
+		}
+
+		text.WriteString(chunk.Text)
+		if loc := stop.FindStringIndex(text.String()); loc != nil {
+			stopped = true
+			if loc[0] <= emitted {
+				return nil
+			}
+			chunk.Text = text.String()[emitted:loc[0]]
+		}
+		emitted = text.Len()
+		return handler(chunk)
+	}
+}
+
+// generateShared runs Generate through the singleflight group so identical
+// concurrent requests share one provider call. Each caller gets its own copy
+// of the response. The shared call is detached from the callers' contexts, so
+// a caller that gives up returns its own context error without failing the
+// others; CancelProvider still cancels the shared call.
+func (g *LLMGateway) generateShared(ctx context.Context, client Provider, model Model, prompt string, opts []GenerateOption) (*GenerationResponse, error) {
+	key := requestKey(model, prompt, opts...)
+	logger := newGenerateOptions(opts).logger(g.logger)
+
+	results := g.flight.DoChan(key, func() (interface{}, error) {
+		sharedCtx, done := g.trackRequest(context.WithoutCancel(ctx), model.Provider())
+		start := time.Now()
+		resp, err := client.Generate(sharedCtx, model, prompt, opts...)
+		if err = done(err); err != nil {
+			return nil, err
+		}
+		g.finishResponse(resp, model, start, logger)
+		return resp, nil
+	})
+
+	joinedSharedCall()
+
+	var res singleflight.Result
+	select {
+	case res = <-results:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if res.Err != nil {
+		return nil, res.Err
+	}
+
+	if res.Shared {
+		logger.Debug().
+			Str("provider", string(model.Provider())).
+			Str("model", model.ModelName()).
+			Msg("Shared in-flight generation")
+	}
+
+	return cloneResponse(res.Val.(*GenerationResponse)), nil
+}
+
+// joinedSharedCall is called by each caller of generateShared once it has
+// started or joined the shared call. Tests replace it to order callers.
+var joinedSharedCall = func() {}
+
+// cloneResponse returns a copy of resp that shares no mutable state with it
+func cloneResponse(resp *GenerationResponse) *GenerationResponse {
+	clone := *resp
+	if resp.Metadata != nil {
+		clone.Metadata = make(map[string]string, len(resp.Metadata))
+		for k, v := range resp.Metadata {
+			clone.Metadata[k] = v
+		}
+	}
+	if resp.RawJSON != nil {
+		clone.RawJSON = append([]byte(nil), resp.RawJSON...)
+	}
+	if resp.Audio != nil {
+		clone.Audio = append([]byte(nil), resp.Audio...)
+	}
+	return &clone
+}
+
+// lookupProvider returns the registered client for a provider, or an error if
+// the provider isn't registered or the gateway has been closed
+func (g *LLMGateway) lookupProvider(provider ProviderType) (Provider, error) {
+	g.mu.RLock()
+	client, exists := g.providers[provider]
+	closed := g.closed
+	g.mu.RUnlock()
+
+	if closed {
+		return nil, ErrGatewayClosed
+	}
+	if !exists {
+		return nil, g.errNotRegistered(provider)
+	}
+	return client, nil
+}
+
 // IsRegistered checks if a provider is registered
 func (g *LLMGateway) IsRegistered(provider ProviderType) bool {
 	g.mu.RLock()
@@ -137,24 +731,135 @@ func (g *LLMGateway) ListRegisteredProviders() []ProviderType {
 	return providers
 }
 
+// errNotRegistered builds an ErrProviderNotRegistered error listing the
+// providers that are registered
+func (g *LLMGateway) errNotRegistered(provider ProviderType) error {
+	registered := g.ListRegisteredProviders()
+	names := make([]string, len(registered))
+	for i, p := range registered {
+		names[i] = string(p)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("%w: %s (registered: %s)", ErrProviderNotRegistered, provider, strings.Join(names, ", "))
+}
+
+// RateLimitStats returns rate limit counters for each registered provider
+func (g *LLMGateway) RateLimitStats() map[ProviderType]RateLimitStats {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	stats := make(map[ProviderType]RateLimitStats, len(g.providers))
+	for p, client := range g.providers {
+		if sp, ok := client.(rateLimitStatsProvider); ok {
+			stats[p] = sp.rateLimitStats()
+		}
+	}
+	return stats
+}
+
 // Health checks the health of a specific provider
 func (g *LLMGateway) Health(ctx context.Context, provider ProviderType) error {
+	client, err := g.lookupProvider(provider)
+	if err != nil {
+		return err
+	}
+
+	return client.Health(ctx)
+}
+
+// Ping checks that a provider is reachable and its credentials are accepted,
+// returning the round-trip latency. Unlike Health, providers implementing
+// Pinger are checked without a billable generation (OpenAI, Anthropic and
+// Google list their models); the others fall back to Health.
+func (g *LLMGateway) Ping(ctx context.Context, provider ProviderType) (time.Duration, error) {
+	client, err := g.lookupProvider(provider)
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	if pinger, ok := client.(Pinger); ok {
+		err = pinger.Ping(ctx)
+	} else {
+		err = client.Health(ctx)
+	}
+	return time.Since(start), err
+}
+
+// Warmup prepares every registered provider so the first real request isn't
+// slowed by cold connections or model loading. Providers implementing Warmer
+// receive the given models that belong to them (Ollama loads them into
+// memory), providers implementing Pinger are pinged without a billable
+// generation, and the others are sent a tiny Health request. Providers are
+// warmed up concurrently and all failures are returned together.
+func (g *LLMGateway) Warmup(ctx context.Context, models ...Model) error {
 	g.mu.RLock()
-	client, exists := g.providers[provider]
+	if g.closed {
+		g.mu.RUnlock()
+		return ErrGatewayClosed
+	}
+	providers := make(map[ProviderType]Provider, len(g.providers))
+	for p, client := range g.providers {
+		providers[p] = client
+	}
 	g.mu.RUnlock()
 
-	if !exists {
-		return fmt.Errorf("provider %s is not registered", provider)
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for provider, client := range providers {
+		var providerModels []Model
+		for _, m := range models {
+			if m.Provider() == provider {
+				providerModels = append(providerModels, m)
+			}
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			var err error
+			if warmer, ok := client.(Warmer); ok {
+				err = warmer.Warmup(ctx, providerModels)
+			} else if pinger, ok := client.(Pinger); ok {
+				err = pinger.Ping(ctx)
+			} else {
+				err = client.Health(ctx)
+			}
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", provider, err))
+				mu.Unlock()
+				return
+			}
+
+			g.logger.Info().
+				Str("provider", string(provider)).
+				Dur("duration", time.Since(start)).
+				Msg("Provider warmed up")
+		}()
 	}
+	wg.Wait()
 
-	return client.Health(ctx)
+	return errors.Join(errs...)
 }
 
-// Close closes all registered providers
+// Close closes all registered providers. Requests made afterwards fail with
+// ErrGatewayClosed. Calling Close more than once is safe; later calls do
+// nothing.
 func (g *LLMGateway) Close() error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	if g.closed {
+		return nil
+	}
+	g.closed = true
+
 	var errors []error
 	for name, provider := range g.providers {
 		if err := provider.Close(); err != nil {
@@ -169,15 +874,40 @@ func (g *LLMGateway) Close() error {
 	return nil
 }
 
-// truncateString truncates a string to the specified length
-func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
+// extractJSON strips surrounding whitespace, a leading <think> block (which
+// reasoning models such as Sonar Reasoning emit before structured output) and
+// markdown code fences that models commonly wrap JSON output in
+func extractJSON(text string) string {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "<think>") {
+		if end := strings.Index(text, "</think>"); end >= 0 {
+			text = strings.TrimSpace(text[end+len("</think>"):])
+		}
+	}
+	if !strings.HasPrefix(text, "```") {
+		return text
+	}
+
+	text = strings.TrimPrefix(text, "```")
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		text = text[i+1:]
 	}
-	return s[:maxLen] + "..."
+	text = strings.TrimSuffix(strings.TrimSpace(text), "```")
+	return strings.TrimSpace(text)
 }
 
-// defaultTimeout returns the default timeout for providers
-func defaultTimeout() time.Duration {
-	return 60 * time.Second
+// truncateString truncates a string to the specified number of characters,
+// cutting on rune boundaries so multi-byte characters are never split.
+// Invalid UTF-8 in the input is replaced so the result is always valid.
+func truncateString(s string, maxLen int) string {
+	s = strings.ToValidUTF8(s, string(utf8.RuneError))
+
+	count := 0
+	for i := range s {
+		if count == maxLen {
+			return s[:i] + "..."
+		}
+		count++
+	}
+	return s
 }
@@ -0,0 +1,140 @@
+package lingo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateString(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		maxLen int
+		want   string
+	}{
+		{"short ASCII", "hello", 10, "hello"},
+		{"exact ASCII", "hello", 5, "hello"},
+		{"long ASCII", "hello world", 5, "hello..."},
+		{"CJK at boundary", "你好世界", 2, "你好..."},
+		{"CJK exact", "你好世界", 4, "你好世界"},
+		{"emoji at boundary", "🙂🙃😀", 1, "🙂..."},
+		{"mixed", "a你🙂b", 3, "a你🙂..."},
+		{"zero length", "你好", 0, "..."},
+		{"invalid UTF-8", "ab\xffcd", 3, "ab�..."},
+		{"cut mid-character", "a你"[:2], 5, "a�"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateString(tt.s, tt.maxLen)
+			if got != tt.want {
+				t.Errorf("truncateString(%q, %d) = %q, want %q", tt.s, tt.maxLen, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("truncateString(%q, %d) = %q is not valid UTF-8", tt.s, tt.maxLen, got)
+			}
+		})
+	}
+}
+
+func TestSingleflightCallerCancel(t *testing.T) {
+	var requests atomic.Int32
+	received := make(chan struct{}, 1)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		received <- struct{}{}
+		<-release
+		fmt.Fprint(w, `{"model":"llama3","message":{"role":"assistant","content":"Hello"},"done":true}`)
+	}))
+	defer srv.Close()
+
+	joined := make(chan struct{}, 2)
+	joinedSharedCall = func() { joined <- struct{}{} }
+	defer func() { joinedSharedCall = func() {} }()
+
+	g, err := New([]ProviderConfig{&OllamaConfig{BaseURL: srv.URL}}, WithSingleflight(true))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer g.Close()
+	model := NewOllamaModel("llama3")
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := g.Generate(ctx1, model, "Say hello")
+		first <- err
+	}()
+	<-joined
+	<-received
+
+	type result struct {
+		resp *GenerationResponse
+		err  error
+	}
+	second := make(chan result, 1)
+	go func() {
+		resp, err := g.Generate(context.Background(), model, "Say hello")
+		second <- result{resp, err}
+	}()
+	<-joined // the provider is still blocked, so the second caller shares its call
+
+	cancel1()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("first caller error = %v, want context.Canceled", err)
+	}
+
+	close(release)
+	res := <-second
+	if res.err != nil {
+		t.Fatalf("second caller: %v", res.err)
+	}
+	if res.resp == nil || res.resp.Text != "Hello" {
+		t.Errorf("second caller response = %+v, want text %q", res.resp, "Hello")
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("provider received %d requests, want 1", n)
+	}
+}
+
+func TestGenerateChatInputGuard(t *testing.T) {
+	g, err := New([]ProviderConfig{&PerplexityConfig{APIKey: "test"}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer g.Close()
+
+	messages := []ChatMessage{
+		{Role: RoleUser, Content: "An early turn longer than the limit"},
+		{Role: RoleAssistant, Content: "OK"},
+		{Role: RoleUser, Content: "Summarize everything"},
+	}
+
+	// Rejected before anything is sent
+	_, err = g.GenerateChat(context.Background(), NewSonar(), messages, WithMaxInputChars(9))
+	if !errors.Is(err, ErrInputTooLong) {
+		t.Errorf("GenerateChat error = %v, want ErrInputTooLong", err)
+	}
+
+	fitted, err := g.fitChat(NewSonar(), messages, newGenerateOptions([]GenerateOption{
+		WithMaxInputChars(9), WithInputTruncation(TruncateTail),
+	}))
+	if err != nil {
+		t.Fatalf("fitChat: %v", err)
+	}
+	if fitted[2].Content != "Summarize" {
+		t.Errorf("final turn = %q, want %q", fitted[2].Content, "Summarize")
+	}
+	if fitted[0].Content != messages[0].Content {
+		t.Errorf("earlier turn was truncated: %q", fitted[0].Content)
+	}
+	if messages[2].Content != "Summarize everything" {
+		t.Errorf("caller's messages were modified: %q", messages[2].Content)
+	}
+}
@@ -1,8 +1,14 @@
+//go:build !lingo_no_google
+
 package lingo
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"google.golang.org/genai"
@@ -16,6 +22,34 @@ func init() {
 		}
 		return newGoogleClient(cfg, logger)
 	})
+
+	errorStatusCoders = append(errorStatusCoders, func(err error) int {
+		var googleErr genai.APIError
+		if errors.As(err, &googleErr) {
+			return googleErr.Code
+		}
+		return 0
+	})
+
+	builtinModels = append(builtinModels,
+		func() Model { return NewGemini25Pro() },
+		func() Model { return NewGemini25Flash() },
+		func() Model { return NewGemini20Flash() },
+		func() Model { return NewGemini20FlashLite() },
+		func() Model { return NewGemini15Pro() },
+		func() Model { return NewGemini15Flash() },
+		func() Model { return NewGemini15Flash8b() },
+		func() Model { return NewGemini20FlashExp() },
+		func() Model { return NewGemini20FlashThinking() },
+		func() Model { return NewGemini20ProExp() },
+		func() Model { return NewGemini3Pro() },
+		func() Model { return NewGemini3Flash() },
+		func() Model { return NewGemini3Ultra() },
+	)
+
+	addDefaultRoute(HintCheap, RouteCandidate{NewModel: func() Model { return NewGemini20FlashLite() }, Weight: 3})
+	addDefaultRoute(HintFast, RouteCandidate{NewModel: func() Model { return NewGemini25Flash() }, Weight: 3})
+	addDefaultRoute(HintSmart, RouteCandidate{NewModel: func() Model { return NewGemini25Pro() }, Weight: 2})
 }
 
 // ============================================================================
@@ -26,7 +60,8 @@ func init() {
 type GoogleConfig struct {
 	// APIKey is the Google AI API key (required)
 	APIKey string
-	// Timeout is the request timeout (default: 60s)
+	// Timeout is the request timeout (default: the model's recommended timeout,
+	// reported by Describe)
 	Timeout time.Duration
 	// RateLimiter is the optional rate limit configuration
 	RateLimiter *RateLimitConfig
@@ -44,12 +79,25 @@ func (c *GoogleConfig) rateLimitConfig() *RateLimitConfig { return c.RateLimiter
 
 // googleOptions contains options for Google Gemini models
 type googleOptions struct {
-	modelVersion string // Optional: override model name with specific version (e.g., "latest", "preview")
-	maxTokens    int
-	temperature  float64
-	topP         float64
-	topK         int
-	systemPrompt string
+	modelVersion  string // Optional: override model name with specific version (e.g., "latest", "preview")
+	maxTokens     int
+	temperature   float64
+	topP          float64
+	topK          int
+	systemPrompt  string
+	codeExecution bool // Enables the built-in tool that lets the model write and run Python
+
+	temperatureSet bool // Distinguishes an explicit temperature of 0 from unset
+}
+
+func (o *googleOptions) generationOptions() *googleOptions    { return o }
+func (o *googleOptions) setTemperature(t float64)             { o.temperature = t; o.temperatureSet = true }
+func (o *googleOptions) explicitTemperature() (float64, bool) { return o.temperature, o.temperatureSet }
+
+// googleImageOptions contains options for Imagen models
+type googleImageOptions struct {
+	numberOfImages int
+	aspectRatio    string // e.g. "1:1", "16:9"
 }
 
 // ============================================================================
@@ -71,14 +119,18 @@ func (m *Gemini25Pro) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Gemini25Pro) WithVersion(v string) *Gemini25Pro      { m.modelVersion = v; return m }
 func (m *Gemini25Pro) WithMaxTokens(n int) *Gemini25Pro       { m.maxTokens = n; return m }
-func (m *Gemini25Pro) WithTemperature(t float64) *Gemini25Pro { m.temperature = t; return m }
+func (m *Gemini25Pro) WithTemperature(t float64) *Gemini25Pro { m.setTemperature(t); return m }
 func (m *Gemini25Pro) WithTopP(p float64) *Gemini25Pro        { m.topP = p; return m }
 func (m *Gemini25Pro) WithTopK(k int) *Gemini25Pro            { m.topK = k; return m }
 func (m *Gemini25Pro) WithSystemPrompt(s string) *Gemini25Pro { m.systemPrompt = s; return m }
+func (m *Gemini25Pro) WithCodeExecution(enabled bool) *Gemini25Pro {
+	m.codeExecution = enabled
+	return m
+}
 
 // NewGemini25Pro creates a new Gemini 2.5 Pro model with default options
 func NewGemini25Pro() *Gemini25Pro {
-	return &Gemini25Pro{googleOptions{maxTokens: 8192, temperature: 1.0}}
+	return &Gemini25Pro{googleOptions{maxTokens: defaultMaxTokens("gemini-2.5-pro"), temperature: 1.0}}
 }
 
 // Gemini25Flash represents the Gemini 2.5 Flash model
@@ -96,14 +148,18 @@ func (m *Gemini25Flash) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Gemini25Flash) WithVersion(v string) *Gemini25Flash      { m.modelVersion = v; return m }
 func (m *Gemini25Flash) WithMaxTokens(n int) *Gemini25Flash       { m.maxTokens = n; return m }
-func (m *Gemini25Flash) WithTemperature(t float64) *Gemini25Flash { m.temperature = t; return m }
+func (m *Gemini25Flash) WithTemperature(t float64) *Gemini25Flash { m.setTemperature(t); return m }
 func (m *Gemini25Flash) WithTopP(p float64) *Gemini25Flash        { m.topP = p; return m }
 func (m *Gemini25Flash) WithTopK(k int) *Gemini25Flash            { m.topK = k; return m }
 func (m *Gemini25Flash) WithSystemPrompt(s string) *Gemini25Flash { m.systemPrompt = s; return m }
+func (m *Gemini25Flash) WithCodeExecution(enabled bool) *Gemini25Flash {
+	m.codeExecution = enabled
+	return m
+}
 
 // NewGemini25Flash creates a new Gemini 2.5 Flash model with default options
 func NewGemini25Flash() *Gemini25Flash {
-	return &Gemini25Flash{googleOptions{maxTokens: 8192, temperature: 1.0}}
+	return &Gemini25Flash{googleOptions{maxTokens: defaultMaxTokens("gemini-2.5-flash"), temperature: 1.0}}
 }
 
 // Gemini20Flash represents the Gemini 2.0 Flash model
@@ -114,14 +170,18 @@ func (m *Gemini20Flash) Provider() ProviderType { return ProviderGoogle }
 func (m *Gemini20Flash) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Gemini20Flash) WithMaxTokens(n int) *Gemini20Flash       { m.maxTokens = n; return m }
-func (m *Gemini20Flash) WithTemperature(t float64) *Gemini20Flash { m.temperature = t; return m }
+func (m *Gemini20Flash) WithTemperature(t float64) *Gemini20Flash { m.setTemperature(t); return m }
 func (m *Gemini20Flash) WithTopP(p float64) *Gemini20Flash        { m.topP = p; return m }
 func (m *Gemini20Flash) WithTopK(k int) *Gemini20Flash            { m.topK = k; return m }
 func (m *Gemini20Flash) WithSystemPrompt(s string) *Gemini20Flash { m.systemPrompt = s; return m }
+func (m *Gemini20Flash) WithCodeExecution(enabled bool) *Gemini20Flash {
+	m.codeExecution = enabled
+	return m
+}
 
 // NewGemini20Flash creates a new Gemini 2.0 Flash model with default options
 func NewGemini20Flash() *Gemini20Flash {
-	return &Gemini20Flash{googleOptions{maxTokens: 8192, temperature: 1.0}}
+	return &Gemini20Flash{googleOptions{maxTokens: defaultMaxTokens("gemini-2.0-flash"), temperature: 1.0}}
 }
 
 // Gemini20FlashLite represents the Gemini 2.0 Flash Lite model
@@ -133,7 +193,7 @@ func (m *Gemini20FlashLite) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Gemini20FlashLite) WithMaxTokens(n int) *Gemini20FlashLite { m.maxTokens = n; return m }
 func (m *Gemini20FlashLite) WithTemperature(t float64) *Gemini20FlashLite {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *Gemini20FlashLite) WithTopP(p float64) *Gemini20FlashLite { m.topP = p; return m }
@@ -142,10 +202,14 @@ func (m *Gemini20FlashLite) WithSystemPrompt(s string) *Gemini20FlashLite {
 	m.systemPrompt = s
 	return m
 }
+func (m *Gemini20FlashLite) WithCodeExecution(enabled bool) *Gemini20FlashLite {
+	m.codeExecution = enabled
+	return m
+}
 
 // NewGemini20FlashLite creates a new Gemini 2.0 Flash Lite model with default options
 func NewGemini20FlashLite() *Gemini20FlashLite {
-	return &Gemini20FlashLite{googleOptions{maxTokens: 4096, temperature: 1.0}}
+	return &Gemini20FlashLite{googleOptions{maxTokens: defaultMaxTokens("gemini-2.0-flash-lite"), temperature: 1.0}}
 }
 
 // Gemini15Pro represents the Gemini 1.5 Pro model
@@ -163,14 +227,18 @@ func (m *Gemini15Pro) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Gemini15Pro) WithVersion(v string) *Gemini15Pro      { m.modelVersion = v; return m }
 func (m *Gemini15Pro) WithMaxTokens(n int) *Gemini15Pro       { m.maxTokens = n; return m }
-func (m *Gemini15Pro) WithTemperature(t float64) *Gemini15Pro { m.temperature = t; return m }
+func (m *Gemini15Pro) WithTemperature(t float64) *Gemini15Pro { m.setTemperature(t); return m }
 func (m *Gemini15Pro) WithTopP(p float64) *Gemini15Pro        { m.topP = p; return m }
 func (m *Gemini15Pro) WithTopK(k int) *Gemini15Pro            { m.topK = k; return m }
 func (m *Gemini15Pro) WithSystemPrompt(s string) *Gemini15Pro { m.systemPrompt = s; return m }
+func (m *Gemini15Pro) WithCodeExecution(enabled bool) *Gemini15Pro {
+	m.codeExecution = enabled
+	return m
+}
 
 // NewGemini15Pro creates a new Gemini 1.5 Pro model with default options
 func NewGemini15Pro() *Gemini15Pro {
-	return &Gemini15Pro{googleOptions{maxTokens: 8192, temperature: 1.0}}
+	return &Gemini15Pro{googleOptions{maxTokens: defaultMaxTokens("gemini-1.5-pro"), temperature: 1.0}}
 }
 
 // Gemini15Flash represents the Gemini 1.5 Flash model
@@ -188,14 +256,18 @@ func (m *Gemini15Flash) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Gemini15Flash) WithVersion(v string) *Gemini15Flash      { m.modelVersion = v; return m }
 func (m *Gemini15Flash) WithMaxTokens(n int) *Gemini15Flash       { m.maxTokens = n; return m }
-func (m *Gemini15Flash) WithTemperature(t float64) *Gemini15Flash { m.temperature = t; return m }
+func (m *Gemini15Flash) WithTemperature(t float64) *Gemini15Flash { m.setTemperature(t); return m }
 func (m *Gemini15Flash) WithTopP(p float64) *Gemini15Flash        { m.topP = p; return m }
 func (m *Gemini15Flash) WithTopK(k int) *Gemini15Flash            { m.topK = k; return m }
 func (m *Gemini15Flash) WithSystemPrompt(s string) *Gemini15Flash { m.systemPrompt = s; return m }
+func (m *Gemini15Flash) WithCodeExecution(enabled bool) *Gemini15Flash {
+	m.codeExecution = enabled
+	return m
+}
 
 // NewGemini15Flash creates a new Gemini 1.5 Flash model with default options
 func NewGemini15Flash() *Gemini15Flash {
-	return &Gemini15Flash{googleOptions{maxTokens: 8192, temperature: 1.0}}
+	return &Gemini15Flash{googleOptions{maxTokens: defaultMaxTokens("gemini-1.5-flash"), temperature: 1.0}}
 }
 
 // Gemini15Flash8b represents the Gemini 1.5 Flash 8B model
@@ -206,14 +278,18 @@ func (m *Gemini15Flash8b) Provider() ProviderType { return ProviderGoogle }
 func (m *Gemini15Flash8b) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Gemini15Flash8b) WithMaxTokens(n int) *Gemini15Flash8b       { m.maxTokens = n; return m }
-func (m *Gemini15Flash8b) WithTemperature(t float64) *Gemini15Flash8b { m.temperature = t; return m }
+func (m *Gemini15Flash8b) WithTemperature(t float64) *Gemini15Flash8b { m.setTemperature(t); return m }
 func (m *Gemini15Flash8b) WithTopP(p float64) *Gemini15Flash8b        { m.topP = p; return m }
 func (m *Gemini15Flash8b) WithTopK(k int) *Gemini15Flash8b            { m.topK = k; return m }
 func (m *Gemini15Flash8b) WithSystemPrompt(s string) *Gemini15Flash8b { m.systemPrompt = s; return m }
+func (m *Gemini15Flash8b) WithCodeExecution(enabled bool) *Gemini15Flash8b {
+	m.codeExecution = enabled
+	return m
+}
 
 // NewGemini15Flash8b creates a new Gemini 1.5 Flash 8B model with default options
 func NewGemini15Flash8b() *Gemini15Flash8b {
-	return &Gemini15Flash8b{googleOptions{maxTokens: 8192, temperature: 1.0}}
+	return &Gemini15Flash8b{googleOptions{maxTokens: defaultMaxTokens("gemini-1.5-flash-8b"), temperature: 1.0}}
 }
 
 // Gemini20FlashExp represents the Gemini 2.0 Flash Experimental model
@@ -223,15 +299,22 @@ func (m *Gemini20FlashExp) ModelName() string      { return "gemini-2.0-flash-ex
 func (m *Gemini20FlashExp) Provider() ProviderType { return ProviderGoogle }
 func (m *Gemini20FlashExp) SystemPrompt() string   { return m.systemPrompt }
 
-func (m *Gemini20FlashExp) WithMaxTokens(n int) *Gemini20FlashExp       { m.maxTokens = n; return m }
-func (m *Gemini20FlashExp) WithTemperature(t float64) *Gemini20FlashExp { m.temperature = t; return m }
+func (m *Gemini20FlashExp) WithMaxTokens(n int) *Gemini20FlashExp { m.maxTokens = n; return m }
+func (m *Gemini20FlashExp) WithTemperature(t float64) *Gemini20FlashExp {
+	m.setTemperature(t)
+	return m
+}
 func (m *Gemini20FlashExp) WithTopP(p float64) *Gemini20FlashExp        { m.topP = p; return m }
 func (m *Gemini20FlashExp) WithTopK(k int) *Gemini20FlashExp            { m.topK = k; return m }
 func (m *Gemini20FlashExp) WithSystemPrompt(s string) *Gemini20FlashExp { m.systemPrompt = s; return m }
+func (m *Gemini20FlashExp) WithCodeExecution(enabled bool) *Gemini20FlashExp {
+	m.codeExecution = enabled
+	return m
+}
 
 // NewGemini20FlashExp creates a new Gemini 2.0 Flash Exp model with default options
 func NewGemini20FlashExp() *Gemini20FlashExp {
-	return &Gemini20FlashExp{googleOptions{maxTokens: 8192, temperature: 1.0}}
+	return &Gemini20FlashExp{googleOptions{maxTokens: defaultMaxTokens("gemini-2.0-flash-exp"), temperature: 1.0}}
 }
 
 // Gemini20FlashThinking represents the Gemini 2.0 Flash Thinking Experimental model
@@ -246,7 +329,7 @@ func (m *Gemini20FlashThinking) WithMaxTokens(n int) *Gemini20FlashThinking {
 	return m
 }
 func (m *Gemini20FlashThinking) WithTemperature(t float64) *Gemini20FlashThinking {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *Gemini20FlashThinking) WithTopP(p float64) *Gemini20FlashThinking { m.topP = p; return m }
@@ -255,10 +338,14 @@ func (m *Gemini20FlashThinking) WithSystemPrompt(s string) *Gemini20FlashThinkin
 	m.systemPrompt = s
 	return m
 }
+func (m *Gemini20FlashThinking) WithCodeExecution(enabled bool) *Gemini20FlashThinking {
+	m.codeExecution = enabled
+	return m
+}
 
 // NewGemini20FlashThinking creates a new Gemini 2.0 Flash Thinking model with default options
 func NewGemini20FlashThinking() *Gemini20FlashThinking {
-	return &Gemini20FlashThinking{googleOptions{maxTokens: 8192, temperature: 1.0}}
+	return &Gemini20FlashThinking{googleOptions{maxTokens: defaultMaxTokens("gemini-2.0-flash-thinking-exp"), temperature: 1.0}}
 }
 
 // Gemini20ProExp represents the Gemini 2.0 Pro Experimental model
@@ -269,14 +356,18 @@ func (m *Gemini20ProExp) Provider() ProviderType { return ProviderGoogle }
 func (m *Gemini20ProExp) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Gemini20ProExp) WithMaxTokens(n int) *Gemini20ProExp       { m.maxTokens = n; return m }
-func (m *Gemini20ProExp) WithTemperature(t float64) *Gemini20ProExp { m.temperature = t; return m }
+func (m *Gemini20ProExp) WithTemperature(t float64) *Gemini20ProExp { m.setTemperature(t); return m }
 func (m *Gemini20ProExp) WithTopP(p float64) *Gemini20ProExp        { m.topP = p; return m }
 func (m *Gemini20ProExp) WithTopK(k int) *Gemini20ProExp            { m.topK = k; return m }
 func (m *Gemini20ProExp) WithSystemPrompt(s string) *Gemini20ProExp { m.systemPrompt = s; return m }
+func (m *Gemini20ProExp) WithCodeExecution(enabled bool) *Gemini20ProExp {
+	m.codeExecution = enabled
+	return m
+}
 
 // NewGemini20ProExp creates a new Gemini 2.0 Pro Exp model with default options
 func NewGemini20ProExp() *Gemini20ProExp {
-	return &Gemini20ProExp{googleOptions{maxTokens: 8192, temperature: 1.0}}
+	return &Gemini20ProExp{googleOptions{maxTokens: defaultMaxTokens("gemini-2.0-pro-exp"), temperature: 1.0}}
 }
 
 // Gemini3Pro represents the Gemini 3 Pro model
@@ -292,16 +383,17 @@ func (m *Gemini3Pro) ModelName() string {
 func (m *Gemini3Pro) Provider() ProviderType { return ProviderGoogle }
 func (m *Gemini3Pro) SystemPrompt() string   { return m.systemPrompt }
 
-func (m *Gemini3Pro) WithVersion(v string) *Gemini3Pro      { m.modelVersion = v; return m }
-func (m *Gemini3Pro) WithMaxTokens(n int) *Gemini3Pro       { m.maxTokens = n; return m }
-func (m *Gemini3Pro) WithTemperature(t float64) *Gemini3Pro { m.temperature = t; return m }
-func (m *Gemini3Pro) WithTopP(p float64) *Gemini3Pro        { m.topP = p; return m }
-func (m *Gemini3Pro) WithTopK(k int) *Gemini3Pro            { m.topK = k; return m }
-func (m *Gemini3Pro) WithSystemPrompt(s string) *Gemini3Pro { m.systemPrompt = s; return m }
+func (m *Gemini3Pro) WithVersion(v string) *Gemini3Pro           { m.modelVersion = v; return m }
+func (m *Gemini3Pro) WithMaxTokens(n int) *Gemini3Pro            { m.maxTokens = n; return m }
+func (m *Gemini3Pro) WithTemperature(t float64) *Gemini3Pro      { m.setTemperature(t); return m }
+func (m *Gemini3Pro) WithTopP(p float64) *Gemini3Pro             { m.topP = p; return m }
+func (m *Gemini3Pro) WithTopK(k int) *Gemini3Pro                 { m.topK = k; return m }
+func (m *Gemini3Pro) WithSystemPrompt(s string) *Gemini3Pro      { m.systemPrompt = s; return m }
+func (m *Gemini3Pro) WithCodeExecution(enabled bool) *Gemini3Pro { m.codeExecution = enabled; return m }
 
 // NewGemini3Pro creates a new Gemini 3 Pro model with default options
 func NewGemini3Pro() *Gemini3Pro {
-	return &Gemini3Pro{googleOptions{maxTokens: 8192, temperature: 1.0}}
+	return &Gemini3Pro{googleOptions{maxTokens: defaultMaxTokens("gemini-3-pro"), temperature: 1.0}}
 }
 
 // Gemini3Flash represents the Gemini 3 Flash model
@@ -319,14 +411,18 @@ func (m *Gemini3Flash) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Gemini3Flash) WithVersion(v string) *Gemini3Flash      { m.modelVersion = v; return m }
 func (m *Gemini3Flash) WithMaxTokens(n int) *Gemini3Flash       { m.maxTokens = n; return m }
-func (m *Gemini3Flash) WithTemperature(t float64) *Gemini3Flash { m.temperature = t; return m }
+func (m *Gemini3Flash) WithTemperature(t float64) *Gemini3Flash { m.setTemperature(t); return m }
 func (m *Gemini3Flash) WithTopP(p float64) *Gemini3Flash        { m.topP = p; return m }
 func (m *Gemini3Flash) WithTopK(k int) *Gemini3Flash            { m.topK = k; return m }
 func (m *Gemini3Flash) WithSystemPrompt(s string) *Gemini3Flash { m.systemPrompt = s; return m }
+func (m *Gemini3Flash) WithCodeExecution(enabled bool) *Gemini3Flash {
+	m.codeExecution = enabled
+	return m
+}
 
 // NewGemini3Flash creates a new Gemini 3 Flash model with default options
 func NewGemini3Flash() *Gemini3Flash {
-	return &Gemini3Flash{googleOptions{maxTokens: 8192, temperature: 1.0}}
+	return &Gemini3Flash{googleOptions{maxTokens: defaultMaxTokens("gemini-3-flash"), temperature: 1.0}}
 }
 
 // Gemini3Ultra represents the Gemini 3 Ultra model
@@ -337,25 +433,71 @@ func (m *Gemini3Ultra) Provider() ProviderType { return ProviderGoogle }
 func (m *Gemini3Ultra) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Gemini3Ultra) WithMaxTokens(n int) *Gemini3Ultra       { m.maxTokens = n; return m }
-func (m *Gemini3Ultra) WithTemperature(t float64) *Gemini3Ultra { m.temperature = t; return m }
+func (m *Gemini3Ultra) WithTemperature(t float64) *Gemini3Ultra { m.setTemperature(t); return m }
 func (m *Gemini3Ultra) WithTopP(p float64) *Gemini3Ultra        { m.topP = p; return m }
 func (m *Gemini3Ultra) WithTopK(k int) *Gemini3Ultra            { m.topK = k; return m }
 func (m *Gemini3Ultra) WithSystemPrompt(s string) *Gemini3Ultra { m.systemPrompt = s; return m }
+func (m *Gemini3Ultra) WithCodeExecution(enabled bool) *Gemini3Ultra {
+	m.codeExecution = enabled
+	return m
+}
 
 // NewGemini3Ultra creates a new Gemini 3 Ultra model with default options
 func NewGemini3Ultra() *Gemini3Ultra {
-	return &Gemini3Ultra{googleOptions{maxTokens: 8192, temperature: 1.0}}
+	return &Gemini3Ultra{googleOptions{maxTokens: defaultMaxTokens("gemini-3-ultra"), temperature: 1.0}}
+}
+
+// ============================================================================
+// IMAGEN MODELS
+// ============================================================================
+
+// Imagen3 represents the Imagen 3 image generation model.
+// Imagen returns image data only, so results are always base64-encoded.
+type Imagen3 struct{ googleImageOptions }
+
+func (m *Imagen3) ModelName() string                 { return "imagen-3.0-generate-002" }
+func (m *Imagen3) Provider() ProviderType            { return ProviderGoogle }
+func (m *Imagen3) imageOptions() *googleImageOptions { return &m.googleImageOptions }
+
+func (m *Imagen3) WithNumberOfImages(n int) *Imagen3 { m.numberOfImages = n; return m }
+func (m *Imagen3) WithAspectRatio(r string) *Imagen3 { m.aspectRatio = r; return m }
+
+// NewImagen3 creates a new Imagen 3 model with default options
+func NewImagen3() *Imagen3 {
+	return &Imagen3{googleImageOptions{numberOfImages: 1}}
+}
+
+// Imagen4 represents the Imagen 4 image generation model.
+// Imagen returns image data only, so results are always base64-encoded.
+type Imagen4 struct{ googleImageOptions }
+
+func (m *Imagen4) ModelName() string                 { return "imagen-4.0-generate-001" }
+func (m *Imagen4) Provider() ProviderType            { return ProviderGoogle }
+func (m *Imagen4) imageOptions() *googleImageOptions { return &m.googleImageOptions }
+
+func (m *Imagen4) WithNumberOfImages(n int) *Imagen4 { m.numberOfImages = n; return m }
+func (m *Imagen4) WithAspectRatio(r string) *Imagen4 { m.aspectRatio = r; return m }
+
+// NewImagen4 creates a new Imagen 4 model with default options
+func NewImagen4() *Imagen4 {
+	return &Imagen4{googleImageOptions{numberOfImages: 1}}
 }
 
 // ============================================================================
 // GOOGLE PROVIDER CLIENT
 // ============================================================================
 
+// googleImageModel is an interface for Imagen models
+type googleImageModel interface {
+	ImageModel
+	imageOptions() *googleImageOptions
+}
+
 // googleClient implements the Provider interface for Google AI (Gemini)
 // Uses the new Google GenAI SDK (google.golang.org/genai)
 type googleClient struct {
 	client      *genai.Client
-	timeout     time.Duration
+	timeout     time.Duration // Zero uses the model's recommended timeout
 	logger      Logger
 	rateLimiter *rateLimiter
 }
@@ -375,62 +517,40 @@ func newGoogleClient(config *GoogleConfig, logger Logger) (*googleClient, error)
 		return nil, fmt.Errorf("failed to create Google AI client: %w", err)
 	}
 
-	timeout := config.Timeout
-	if timeout == 0 {
-		timeout = defaultTimeout()
-	}
-
 	return &googleClient{
 		client:      client,
-		timeout:     timeout,
+		timeout:     config.Timeout,
 		logger:      logger,
-		rateLimiter: newRateLimiter(config.RateLimiter, logger),
+		rateLimiter: newRateLimiter(ProviderGoogle, config.RateLimiter, logger),
 	}, nil
 }
 
+// googleOptionsModel is implemented by Gemini models through their embedded
+// googleOptions
+type googleOptionsModel interface {
+	generationOptions() *googleOptions
+}
+
 // getGoogleOptions extracts googleOptions from any model type
 func getGoogleOptions(model Model) *googleOptions {
-	switch m := model.(type) {
-	case *Gemini25Pro:
-		return &m.googleOptions
-	case *Gemini25Flash:
-		return &m.googleOptions
-	case *Gemini20Flash:
-		return &m.googleOptions
-	case *Gemini20FlashLite:
-		return &m.googleOptions
-	case *Gemini15Pro:
-		return &m.googleOptions
-	case *Gemini15Flash:
-		return &m.googleOptions
-	case *Gemini15Flash8b:
-		return &m.googleOptions
-	case *Gemini20FlashExp:
-		return &m.googleOptions
-	case *Gemini20FlashThinking:
-		return &m.googleOptions
-	case *Gemini20ProExp:
-		return &m.googleOptions
-	case *Gemini3Pro:
-		return &m.googleOptions
-	case *Gemini3Flash:
-		return &m.googleOptions
-	case *Gemini3Ultra:
-		return &m.googleOptions
-	default:
-		return nil
+	if m, ok := model.(googleOptionsModel); ok {
+		return m.generationOptions()
 	}
+	return nil
 }
 
 // Generate generates text using Google's Gemini API
-func (c *googleClient) Generate(ctx context.Context, model Model, prompt string) (*GenerationResponse, error) {
+func (c *googleClient) Generate(ctx context.Context, model Model, prompt string, genOpts ...GenerateOption) (*GenerationResponse, error) {
 	// Verify model is for Google
 	if model.Provider() != ProviderGoogle {
 		return nil, fmt.Errorf("model %s is not a Google model", model.ModelName())
 	}
 
+	reqOpts := newGenerateOptions(genOpts)
+	logger := reqOpts.logger(c.logger)
+
 	// Set timeout
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(c.timeout, ProviderGoogle, model.ModelName()))
 	defer cancel()
 
 	// Get model options
@@ -438,11 +558,14 @@ func (c *googleClient) Generate(ctx context.Context, model Model, prompt string)
 	if opts == nil {
 		return nil, fmt.Errorf("unsupported Google model type: %T", model)
 	}
+	if err := validateSampling(ProviderGoogle, opts.temperature, 2, opts.topP); err != nil {
+		return nil, err
+	}
 
 	// Build generation config
 	config := &genai.GenerateContentConfig{}
 
-	if opts.temperature > 0 {
+	if opts.temperatureSet || opts.temperature > 0 {
 		temp := float32(opts.temperature)
 		config.Temperature = &temp
 	}
@@ -457,12 +580,29 @@ func (c *googleClient) Generate(ctx context.Context, model Model, prompt string)
 		topK := float32(opts.topK)
 		config.TopK = &topK
 	}
-	if opts.systemPrompt != "" {
+	if system := reqOpts.systemPromptFor(model); system != "" {
 		config.SystemInstruction = &genai.Content{
-			Parts: []*genai.Part{{Text: opts.systemPrompt}},
+			Parts: []*genai.Part{{Text: system}},
 		}
 	}
 
+	if opts.codeExecution {
+		config.Tools = []*genai.Tool{{CodeExecution: &genai.ToolCodeExecution{}}}
+	}
+	if len(reqOpts.stopSequences) > 0 {
+		config.StopSequences = reqOpts.stopSequences
+	}
+
+	// Reproducibility settings
+	if seed, ok := reqOpts.samplingSeed(); ok {
+		s := int32(seed)
+		config.Seed = &s
+	}
+	if reqOpts.deterministic {
+		temp := float32(0)
+		config.Temperature = &temp
+	}
+
 	// Build content
 	contents := []*genai.Content{
 		{
@@ -471,54 +611,76 @@ func (c *googleClient) Generate(ctx context.Context, model Model, prompt string)
 		},
 	}
 
-	c.logger.Debug().
+	// Extra fields use the REST API's format, so compare them with the
+	// top-level fields the SDK sends for this config
+	restFields := map[string]any{"contents": true, "generationConfig": true}
+	if config.SystemInstruction != nil {
+		restFields["systemInstruction"] = true
+	}
+	if len(config.Tools) > 0 {
+		restFields["tools"] = true
+	}
+	extra, err := reqOpts.extraBodyFields(restFields)
+	if err != nil {
+		return nil, err
+	}
+	if len(extra) > 0 {
+		config.HTTPOptions = &genai.HTTPOptions{ExtraBody: extra}
+	}
+
+	// The SDK takes the request as separate arguments, so combine them for inspection
+	if reqOpts.dryRun || reqOpts.requestInspector != nil {
+		request := map[string]any{
+			"model":    model.ModelName(),
+			"contents": contents,
+			"config":   config,
+		}
+		reqOpts.inspectRequest(ProviderGoogle, request)
+		if reqOpts.dryRun {
+			return dryRunResponse(model, request)
+		}
+	}
+
+	logger.Debug().
 		Str("model", model.ModelName()).
 		Msg("Making Google AI API request")
 
 	// Make the request with rate limit handling
 	var resp *genai.GenerateContentResponse
-	err := c.rateLimiter.Execute(ctx, func() error {
+	err = c.rateLimiter.ExecuteRequest(ctx, model.ModelName(), reqOpts, func() error {
 		var reqErr error
 		resp, reqErr = c.client.Models.GenerateContent(ctx, model.ModelName(), contents, config)
 		return reqErr
 	})
 	if err != nil {
-		c.logger.Error().
+		logger.Error().
 			Err(err).
 			Str("model", model.ModelName()).
-			Str("prompt_preview", truncateString(prompt, 100)).
+			Str("prompt_preview", reqOpts.promptPreview(prompt)).
 			Msg("Google AI generation failed")
 		return nil, fmt.Errorf("google AI generation failed: %w", err)
 	}
 
-	if len(resp.Candidates) == 0 {
-		return nil, fmt.Errorf("no candidates returned from Google AI")
-	}
-
-	candidate := resp.Candidates[0]
-	if candidate.Content == nil || len(candidate.Content.Parts) == 0 {
-		return nil, fmt.Errorf("no content in Google AI response")
-	}
-
-	// Extract text from parts
-	var text string
-	for _, part := range candidate.Content.Parts {
-		if part.Text != "" {
-			text += part.Text
+	// Extract token usage. A blocked prompt returns no candidates but is
+	// still billed, so the usage is attached to those errors too. Gemini
+	// counts thoughts separately from the candidates; both are billed as
+	// output, so CompletionTokens includes them like the other providers.
+	var usage TokenUsage
+	if resp.UsageMetadata != nil {
+		usage = TokenUsage{
+			PromptTokens:       int(resp.UsageMetadata.PromptTokenCount),
+			CompletionTokens:   int(resp.UsageMetadata.CandidatesTokenCount + resp.UsageMetadata.ThoughtsTokenCount),
+			TotalTokens:        int(resp.UsageMetadata.TotalTokenCount),
+			CachedPromptTokens: int(resp.UsageMetadata.CachedContentTokenCount),
+			ReasoningTokens:    int(resp.UsageMetadata.ThoughtsTokenCount),
 		}
 	}
 
-	if text == "" {
-		return nil, fmt.Errorf("no text content found in Google AI response")
+	if len(resp.Candidates) == 0 {
+		return nil, withPartialUsage(fmt.Errorf("no candidates returned from Google AI"), usage)
 	}
 
-	// Extract token usage
-	var promptTokens, completionTokens, totalTokens int
-	if resp.UsageMetadata != nil {
-		promptTokens = int(resp.UsageMetadata.PromptTokenCount)
-		completionTokens = int(resp.UsageMetadata.CandidatesTokenCount)
-		totalTokens = int(resp.UsageMetadata.TotalTokenCount)
-	}
+	candidate := resp.Candidates[0]
 
 	// Determine finish reason
 	finishReason := "stop"
@@ -526,32 +688,214 @@ func (c *googleClient) Generate(ctx context.Context, model Model, prompt string)
 		finishReason = string(candidate.FinishReason)
 	}
 
+	// A candidate blocked by a safety filter comes back without content; it is
+	// returned with empty text so callers can tell it apart from a failure
+	blocked := (&GenerationResponse{FinishReason: finishReason}).FinishKind() == FinishContentFilter
+	var parts []*genai.Part
+	if candidate.Content != nil {
+		parts = candidate.Content.Parts
+	}
+	if len(parts) == 0 && !blocked {
+		return nil, withPartialUsage(fmt.Errorf("no content in Google AI response"), usage)
+	}
+
+	text, partMetadata := extractGoogleParts(parts)
+	if text == "" && len(partMetadata) == 0 && !blocked {
+		return nil, withPartialUsage(fmt.Errorf("no text content found in Google AI response"), usage)
+	}
+
 	// Build response
 	response := &GenerationResponse{
 		Text:         text,
 		Model:        model.ModelName(),
 		FinishReason: finishReason,
-		Usage: TokenUsage{
-			PromptTokens:     promptTokens,
-			CompletionTokens: completionTokens,
-			TotalTokens:      totalTokens,
-		},
-		Metadata: map[string]string{
+		Usage:        usage,
+	}
+	if !reqOpts.minimalResponse {
+		response.Metadata = map[string]string{
 			"provider": "google",
 			"model":    model.ModelName(),
-		},
+		}
+		for k, v := range partMetadata {
+			response.Metadata[k] = v
+		}
 	}
 
-	c.logger.Debug().
+	// The SDK doesn't keep the response body, so re-encode the decoded payload
+	if reqOpts.includeRaw {
+		if raw, err := json.Marshal(resp); err == nil {
+			response.RawJSON = raw
+		}
+	}
+
+	logger.Debug().
 		Str("model", model.ModelName()).
-		Int("prompt_tokens", promptTokens).
-		Int("completion_tokens", completionTokens).
-		Int("total_tokens", totalTokens).
+		Int("prompt_tokens", usage.PromptTokens).
+		Int("completion_tokens", usage.CompletionTokens).
+		Int("total_tokens", usage.TotalTokens).
 		Msg("Google AI generation completed")
 
 	return response, nil
 }
 
+// extractGoogleParts concatenates the answer text of a candidate's parts and
+// collects the other part types into metadata: thought summaries under
+// "thinking", code the model ran with the code execution tool under
+// "executable_code" (and "executable_code_language"), its output under
+// "code_execution_output" (and "code_execution_outcome"), and the MIME types
+// of inline data such as generated images under "inline_data_mime_types".
+// Multiple parts of a kind are joined with blank lines. Inline data itself is
+// only available through RawJSON.
+func extractGoogleParts(parts []*genai.Part) (string, map[string]string) {
+	var text strings.Builder
+	var thinking, code, languages, outputs, outcomes, mimeTypes []string
+	for _, part := range parts {
+		if part == nil {
+			continue
+		}
+		switch {
+		case part.Thought && part.Text != "":
+			thinking = append(thinking, part.Text)
+		case part.Text != "":
+			text.WriteString(part.Text)
+		}
+		if part.ExecutableCode != nil {
+			code = append(code, part.ExecutableCode.Code)
+			languages = append(languages, string(part.ExecutableCode.Language))
+		}
+		if part.CodeExecutionResult != nil {
+			outputs = append(outputs, part.CodeExecutionResult.Output)
+			outcomes = append(outcomes, string(part.CodeExecutionResult.Outcome))
+		}
+		if part.InlineData != nil {
+			mimeTypes = append(mimeTypes, part.InlineData.MIMEType)
+		}
+	}
+
+	metadata := make(map[string]string)
+	if len(thinking) > 0 {
+		metadata["thinking"] = strings.Join(thinking, "\n\n")
+	}
+	if len(code) > 0 {
+		metadata["executable_code"] = strings.Join(code, "\n\n")
+		metadata["executable_code_language"] = strings.Join(languages, ",")
+	}
+	if len(outputs) > 0 {
+		metadata["code_execution_output"] = strings.Join(outputs, "\n\n")
+		metadata["code_execution_outcome"] = strings.Join(outcomes, ",")
+	}
+	if len(mimeTypes) > 0 {
+		metadata["inline_data_mime_types"] = strings.Join(mimeTypes, ",")
+	}
+	return text.String(), metadata
+}
+
+// GeminiCodeExecution is the code a Gemini model ran with the code execution
+// tool and what it produced
+type GeminiCodeExecution struct {
+	Code     string // The code the model ran, blocks separated by blank lines
+	Language string // e.g. "PYTHON"
+	Output   string // The output of running the code
+	Outcome  string // e.g. "OUTCOME_OK" or "OUTCOME_FAILED"
+}
+
+// GetGeminiCodeExecution returns the code execution results of a Gemini
+// response from a model configured WithCodeExecution. The second result is
+// false if the model didn't run any code.
+func GetGeminiCodeExecution(resp *GenerationResponse) (GeminiCodeExecution, bool) {
+	if resp == nil || resp.Metadata["provider"] != "google" {
+		return GeminiCodeExecution{}, false
+	}
+	code, ok := resp.Metadata["executable_code"]
+	if !ok {
+		return GeminiCodeExecution{}, false
+	}
+	return GeminiCodeExecution{
+		Code:     code,
+		Language: resp.Metadata["executable_code_language"],
+		Output:   resp.Metadata["code_execution_output"],
+		Outcome:  resp.Metadata["code_execution_outcome"],
+	}, true
+}
+
+// GenerateImage generates images using Google's Imagen API
+func (c *googleClient) GenerateImage(ctx context.Context, model ImageModel, prompt string) ([]ImageResult, error) {
+	m, ok := model.(googleImageModel)
+	if !ok || model.Provider() != ProviderGoogle {
+		return nil, fmt.Errorf("model %s is not a Google image model", model.ModelName())
+	}
+	opts := m.imageOptions()
+
+	// Set timeout
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(c.timeout, ProviderGoogle, model.ModelName()))
+	defer cancel()
+
+	config := &genai.GenerateImagesConfig{}
+	if opts.numberOfImages > 0 {
+		config.NumberOfImages = int32(opts.numberOfImages)
+	}
+	if opts.aspectRatio != "" {
+		config.AspectRatio = opts.aspectRatio
+	}
+
+	c.logger.Debug().
+		Str("model", model.ModelName()).
+		Msg("Making Google AI image request")
+
+	// Make request with rate limit handling
+	var resp *genai.GenerateImagesResponse
+	err := c.rateLimiter.Execute(ctx, model.ModelName(), func() error {
+		var reqErr error
+		resp, reqErr = c.client.Models.GenerateImages(ctx, model.ModelName(), prompt, config)
+		return reqErr
+	})
+	if err != nil {
+		c.logger.Error().
+			Err(err).
+			Str("model", model.ModelName()).
+			Msg("Google AI image generation failed")
+		return nil, fmt.Errorf("google AI image generation failed: %w", err)
+	}
+
+	results := make([]ImageResult, 0, len(resp.GeneratedImages))
+	for _, img := range resp.GeneratedImages {
+		if img == nil || img.Image == nil {
+			continue
+		}
+		results = append(results, ImageResult{
+			URL:           img.Image.GCSURI,
+			B64JSON:       base64.StdEncoding.EncodeToString(img.Image.ImageBytes),
+			MIMEType:      img.Image.MIMEType,
+			RevisedPrompt: img.EnhancedPrompt,
+		})
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no images returned from Google AI")
+	}
+
+	c.logger.Debug().
+		Str("model", model.ModelName()).
+		Int("images", len(results)).
+		Msg("Google AI image generation completed")
+
+	return results, nil
+}
+
+// rateLimitStats returns the rate limit counters of the Google AI client
+func (c *googleClient) rateLimitStats() RateLimitStats { return c.rateLimiter.Stats() }
+
+// Ping checks connectivity and credentials by listing the available models
+func (c *googleClient) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if _, err := c.client.Models.List(ctx, &genai.ListModelsConfig{PageSize: 1}); err != nil {
+		return fmt.Errorf("google AI ping failed: %w", err)
+	}
+	return nil
+}
+
 // Health checks the health of the Google AI client
 func (c *googleClient) Health(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
@@ -0,0 +1,50 @@
+//go:build !lingo_no_google
+
+package lingo
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/genai"
+)
+
+func TestExtractGooglePartsMixed(t *testing.T) {
+	parts := []*genai.Part{
+		{Text: "Let me compute that.", Thought: true},
+		{Text: "The sum is "},
+		{ExecutableCode: &genai.ExecutableCode{Code: "print(1 + 2)", Language: genai.LanguagePython}},
+		{CodeExecutionResult: &genai.CodeExecutionResult{Output: "3\n", Outcome: genai.OutcomeOK}},
+		nil,
+		{Text: "3."},
+		{InlineData: &genai.Blob{MIMEType: "image/png", Data: []byte{0x89, 'P', 'N', 'G'}}},
+		{Text: "Double-checked.", Thought: true},
+	}
+
+	text, metadata := extractGoogleParts(parts)
+
+	if want := "The sum is 3."; text != want {
+		t.Errorf("text = %q, want %q", text, want)
+	}
+	want := map[string]string{
+		"thinking":                 "Let me compute that.\n\nDouble-checked.",
+		"executable_code":          "print(1 + 2)",
+		"executable_code_language": "PYTHON",
+		"code_execution_output":    "3\n",
+		"code_execution_outcome":   "OUTCOME_OK",
+		"inline_data_mime_types":   "image/png",
+	}
+	if !reflect.DeepEqual(metadata, want) {
+		t.Errorf("metadata = %v, want %v", metadata, want)
+	}
+}
+
+func TestExtractGooglePartsTextOnly(t *testing.T) {
+	text, metadata := extractGoogleParts([]*genai.Part{{Text: "Hello, "}, {Text: "world"}})
+	if text != "Hello, world" {
+		t.Errorf("text = %q, want %q", text, "Hello, world")
+	}
+	if len(metadata) != 0 {
+		t.Errorf("metadata = %v, want empty", metadata)
+	}
+}
@@ -0,0 +1,508 @@
+// Package httpserver exposes a lingo gateway as an OpenAI-compatible HTTP API,
+// so existing OpenAI SDK clients in any language can use lingo's providers and
+// rate limiting by pointing their base URL at it. Each request goes to the one
+// model it names; the server doesn't fall back to other models.
+//
+// It serves POST /v1/chat/completions (including streaming) and GET /v1/models.
+// The model field of a request selects one of the models registered on the
+// server with Handle, or otherwise any built-in model by a name or alias
+// lingo.ModelFromString accepts. Generation settings such as temperature and
+// max_tokens come from the model, so requests that set them are rejected.
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gerdou/lingo"
+)
+
+// DefaultMaxRequestBytes is the default limit on the size of a request body
+const DefaultMaxRequestBytes = 10 << 20
+
+// Server is an http.Handler serving the OpenAI-compatible API
+type Server struct {
+	gateway         *lingo.LLMGateway
+	opts            []lingo.GenerateOption
+	maxRequestBytes int64
+
+	mu     sync.RWMutex
+	models map[string]func() lingo.Model
+
+	mux *http.ServeMux
+}
+
+// New creates a server that sends requests through the gateway. The generate
+// options are applied to every request.
+func New(gateway *lingo.LLMGateway, opts ...lingo.GenerateOption) *Server {
+	s := &Server{
+		gateway:         gateway,
+		opts:            opts,
+		maxRequestBytes: DefaultMaxRequestBytes,
+		models:          make(map[string]func() lingo.Model),
+		mux:             http.NewServeMux(),
+	}
+	s.mux.HandleFunc("POST /v1/chat/completions", s.handleChatCompletions)
+	s.mux.HandleFunc("GET /v1/models", s.handleModels)
+	return s
+}
+
+// Handle makes a model available under the given name, which clients pass as
+// the model field. newModel is called for every request so each gets a fresh
+// model; configure its options (system prompt aside, which clients send as
+// messages) inside it:
+//
+//	server.Handle("claude", func() lingo.Model { return lingo.NewClaudeSonnet45().WithMaxTokens(4096) })
+func (s *Server) Handle(name string, newModel func() lingo.Model) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.models[name] = newModel
+	return s
+}
+
+// WithMaxRequestBytes limits the size of request bodies; larger requests are
+// rejected with 413 Request Entity Too Large (default: DefaultMaxRequestBytes)
+func (s *Server) WithMaxRequestBytes(n int64) *Server {
+	s.maxRequestBytes = n
+	return s
+}
+
+// lookupModel returns a fresh model for a request's model field, preferring
+// models registered with Handle over built-in model names
+func (s *Server) lookupModel(name string) (lingo.Model, error) {
+	s.mu.RLock()
+	newModel, ok := s.models[name]
+	s.mu.RUnlock()
+	if ok {
+		return newModel(), nil
+	}
+	return lingo.ModelFromString(name)
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// ============================================================================
+// WIRE FORMAT
+// ============================================================================
+
+// chatRequest is the subset of an OpenAI chat completion request the server understands
+type chatRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+	Stream   bool          `json:"stream"`
+	Stop     stopSequences `json:"stop"`
+
+	// Generation settings come from the model, so these are only decoded to
+	// reject requests that set them
+	MaxTokens           *int     `json:"max_tokens"`
+	MaxCompletionTokens *int     `json:"max_completion_tokens"`
+	Temperature         *float64 `json:"temperature"`
+	TopP                *float64 `json:"top_p"`
+}
+
+// unsupportedField returns the name of a generation setting the request sets,
+// or "" if it sets none
+func (r *chatRequest) unsupportedField() string {
+	switch {
+	case r.MaxTokens != nil:
+		return "max_tokens"
+	case r.MaxCompletionTokens != nil:
+		return "max_completion_tokens"
+	case r.Temperature != nil:
+		return "temperature"
+	case r.TopP != nil:
+		return "top_p"
+	default:
+		return ""
+	}
+}
+
+// chatMessage is a message of a chat completion request
+type chatMessage struct {
+	Role    string          `json:"role"`
+	Content json.RawMessage `json:"content"`
+}
+
+// text returns the message content, which is either a string or a list of parts
+func (m chatMessage) text() (string, error) {
+	var text string
+	if err := json.Unmarshal(m.Content, &text); err == nil {
+		return text, nil
+	}
+
+	var parts []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(m.Content, &parts); err != nil {
+		return "", fmt.Errorf("invalid content for %s message", m.Role)
+	}
+	var b strings.Builder
+	for _, part := range parts {
+		if part.Type != "text" {
+			return "", fmt.Errorf("unsupported content part type %q", part.Type)
+		}
+		b.WriteString(part.Text)
+	}
+	return b.String(), nil
+}
+
+// stopSequences accepts the stop field as either a string or a list of strings
+type stopSequences []string
+
+func (s *stopSequences) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*s = stopSequences{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return fmt.Errorf("stop must be a string or a list of strings")
+	}
+	*s = many
+	return nil
+}
+
+type chatCompletion struct {
+	ID      string       `json:"id"`
+	Object  string       `json:"object"`
+	Created int64        `json:"created"`
+	Model   string       `json:"model"`
+	Choices []chatChoice `json:"choices"`
+	Usage   *chatUsage   `json:"usage,omitempty"`
+}
+
+type chatChoice struct {
+	Index        int         `json:"index"`
+	Message      *chatOutput `json:"message,omitempty"`
+	Delta        *chatOutput `json:"delta,omitempty"`
+	FinishReason *string     `json:"finish_reason"`
+}
+
+type chatOutput struct {
+	Role    string `json:"role,omitempty"`
+	Content string `json:"content"`
+}
+
+type chatUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+type errorBody struct {
+	Error struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+	} `json:"error"`
+}
+
+// ============================================================================
+// HANDLERS
+// ============================================================================
+
+// handleModels lists the model names requests can use: the registered names
+// and the built-in models of the providers registered on the gateway
+func (s *Server) handleModels(w http.ResponseWriter, r *http.Request) {
+	seen := make(map[string]bool)
+	s.mu.RLock()
+	for name := range s.models {
+		seen[name] = true
+	}
+	s.mu.RUnlock()
+	for _, model := range lingo.BuiltinModels() {
+		if s.gateway.IsRegistered(model.Provider()) {
+			seen[model.ModelName()] = true
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	type modelEntry struct {
+		ID      string `json:"id"`
+		Object  string `json:"object"`
+		OwnedBy string `json:"owned_by"`
+	}
+	data := make([]modelEntry, len(names))
+	for i, name := range names {
+		data[i] = modelEntry{ID: name, Object: "model", OwnedBy: "lingo"}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"object": "list", "data": data})
+}
+
+// handleChatCompletions translates a chat completion request into a lingo generation
+func (s *Server) handleChatCompletions(w http.ResponseWriter, r *http.Request) {
+	var req chatRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.maxRequestBytes)).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, "invalid_request_error", fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit))
+			return
+		}
+		writeError(w, http.StatusBadRequest, "invalid_request_error", fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if field := req.unsupportedField(); field != "" {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", fmt.Sprintf("%s is not supported; it is set on the model registered with Handle", field))
+		return
+	}
+
+	model, err := s.lookupModel(req.Model)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "invalid_request_error", fmt.Sprintf("model %q does not exist", req.Model))
+		return
+	}
+
+	system, turns, err := splitMessages(req.Messages)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+	if len(turns) > 1 && !lingo.Describe(model).Has(lingo.CapabilityChat) {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", fmt.Sprintf("model %q does not support multi-turn conversations", req.Model))
+		return
+	}
+
+	opts := s.opts[:len(s.opts):len(s.opts)]
+	if system != nil {
+		opts = append(opts, lingo.WithSystemPromptOpt(*system))
+	}
+	if len(req.Stop) > 0 {
+		opts = append(opts, lingo.WithStopSequences(req.Stop...))
+	}
+
+	id := fmt.Sprintf("chatcmpl-%d", time.Now().UnixNano())
+	if req.Stream {
+		s.streamCompletion(w, r, id, req.Model, model, turns, opts)
+		return
+	}
+
+	resp, err := s.generate(r.Context(), model, turns, opts)
+	if err != nil {
+		writeProviderError(w, err)
+		return
+	}
+
+	finish := finishReason(resp)
+	writeJSON(w, http.StatusOK, chatCompletion{
+		ID:      id,
+		Object:  "chat.completion",
+		Created: time.Now().Unix(),
+		Model:   req.Model,
+		Choices: []chatChoice{{
+			Message:      &chatOutput{Role: "assistant", Content: resp.Text},
+			FinishReason: &finish,
+		}},
+		Usage: &chatUsage{
+			PromptTokens:     resp.Usage.PromptTokens,
+			CompletionTokens: resp.Usage.CompletionTokens,
+			TotalTokens:      resp.Usage.TotalTokens,
+		},
+	})
+}
+
+// generate sends a single user turn to Generate, which every provider
+// supports, and longer conversations to GenerateChat
+func (s *Server) generate(ctx context.Context, model lingo.Model, turns []lingo.ChatMessage, opts []lingo.GenerateOption) (*lingo.GenerationResponse, error) {
+	if len(turns) == 1 {
+		return s.gateway.Generate(ctx, model, turns[0].Content, opts...)
+	}
+	return s.gateway.GenerateChat(ctx, model, turns, opts...)
+}
+
+// streamCompletion answers with server-sent events in the OpenAI streaming
+// format. Models whose provider can't stream are generated in full and sent as
+// a single chunk.
+func (s *Server) streamCompletion(w http.ResponseWriter, r *http.Request, id, name string, model lingo.Model, turns []lingo.ChatMessage, opts []lingo.GenerateOption) {
+	flusher, _ := w.(http.Flusher)
+	created := time.Now().Unix()
+	started := false
+
+	send := func(choice chatChoice) error {
+		if !started {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+		data, err := json.Marshal(chatCompletion{
+			ID:      id,
+			Object:  "chat.completion.chunk",
+			Created: created,
+			Model:   name,
+			Choices: []chatChoice{choice},
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	}
+	sendText := func(text string) error {
+		if text == "" {
+			return nil
+		}
+		return send(chatChoice{Delta: &chatOutput{Role: "assistant", Content: text}})
+	}
+
+	var resp *lingo.GenerationResponse
+	var err error
+	handler := func(chunk lingo.StreamChunk) error {
+		return sendText(chunk.Text)
+	}
+	switch {
+	case !lingo.Describe(model).Has(lingo.CapabilityStreaming):
+		resp, err = s.generate(r.Context(), model, turns, opts)
+		if err == nil {
+			err = sendText(resp.Text)
+		}
+	case len(turns) == 1:
+		resp, err = s.gateway.GenerateStream(r.Context(), model, turns[0].Content, handler, opts...)
+	default:
+		resp, err = s.gateway.GenerateChatStream(r.Context(), model, turns, handler, opts...)
+	}
+	if err != nil {
+		if !started {
+			writeProviderError(w, err)
+			return
+		}
+		// The status line has been sent, so report the error in the stream
+		data, _ := json.Marshal(newErrorBody("server_error", err.Error()))
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		return
+	}
+
+	finish := finishReason(resp)
+	if err := send(chatChoice{Delta: &chatOutput{}, FinishReason: &finish}); err != nil {
+		return
+	}
+	fmt.Fprint(w, "data: [DONE]\n\n")
+	if flusher != nil {
+		flusher.Flush()
+	}
+}
+
+// ============================================================================
+// HELPERS
+// ============================================================================
+
+// splitMessages converts chat messages into a system prompt and conversation
+// turns. System and developer messages make up the system prompt, which is nil
+// when there are none; the other turns must alternate between user and
+// assistant and end with a user turn.
+func splitMessages(messages []chatMessage) (*string, []lingo.ChatMessage, error) {
+	var system []string
+	var turns []lingo.ChatMessage
+	for _, msg := range messages {
+		text, err := msg.text()
+		if err != nil {
+			return nil, nil, err
+		}
+		switch msg.Role {
+		case "system", "developer":
+			system = append(system, text)
+		case "user":
+			turns = append(turns, lingo.ChatMessage{Role: lingo.RoleUser, Content: text})
+		case "assistant":
+			turns = append(turns, lingo.ChatMessage{Role: lingo.RoleAssistant, Content: text})
+		default:
+			return nil, nil, fmt.Errorf("unsupported message role %q", msg.Role)
+		}
+	}
+	if len(turns) == 0 {
+		return nil, nil, fmt.Errorf("at least one user message is required")
+	}
+	for i, turn := range turns {
+		want := lingo.RoleUser
+		if (len(turns)-1-i)%2 == 1 {
+			want = lingo.RoleAssistant
+		}
+		if turn.Role != want {
+			return nil, nil, fmt.Errorf("message turns must alternate between user and assistant and end with a user message")
+		}
+	}
+
+	if system == nil {
+		return nil, turns, nil
+	}
+	prompt := strings.Join(system, "\n\n")
+	return &prompt, turns, nil
+}
+
+// finishReason maps a response's finish reason to the OpenAI value
+func finishReason(resp *lingo.GenerationResponse) string {
+	switch kind := resp.FinishKind(); kind {
+	case lingo.FinishLength, lingo.FinishContentFilter, lingo.FinishToolCalls:
+		return string(kind)
+	default:
+		return "stop"
+	}
+}
+
+// writeProviderError writes a gateway error with the status code matching its kind
+func writeProviderError(w http.ResponseWriter, err error) {
+	status, errType := http.StatusInternalServerError, "server_error"
+
+	var pe *lingo.ProviderError
+	if errors.As(err, &pe) {
+		switch pe.Kind {
+		case lingo.KindAuth:
+			status, errType = http.StatusBadGateway, "upstream_auth_error"
+		case lingo.KindInvalidRequest:
+			status, errType = http.StatusBadRequest, "invalid_request_error"
+		case lingo.KindNotFound:
+			status, errType = http.StatusNotFound, "invalid_request_error"
+		case lingo.KindRateLimit:
+			status, errType = http.StatusTooManyRequests, "rate_limit_error"
+			if pe.RetryAfter > 0 {
+				w.Header().Set("Retry-After", fmt.Sprintf("%d", int(pe.RetryAfter.Seconds()+0.5)))
+			}
+		case lingo.KindTimeout:
+			status, errType = http.StatusGatewayTimeout, "timeout_error"
+		}
+	} else if errors.Is(err, lingo.ErrProviderNotRegistered) || errors.Is(err, lingo.ErrInputTooLong) {
+		status, errType = http.StatusBadRequest, "invalid_request_error"
+	}
+
+	writeError(w, status, errType, err.Error())
+}
+
+// newErrorBody builds an OpenAI-style error body
+func newErrorBody(errType, message string) errorBody {
+	var body errorBody
+	body.Error.Type = errType
+	body.Error.Message = message
+	return body
+}
+
+// writeError writes an OpenAI-style error response
+func writeError(w http.ResponseWriter, status int, errType, message string) {
+	writeJSON(w, status, newErrorBody(errType, message))
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,375 @@
+package httpserver
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gerdou/lingo"
+)
+
+// ollamaRequest is the part of an Ollama chat request the tests inspect
+type ollamaRequest struct {
+	Messages []struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	} `json:"messages"`
+	Stream  bool `json:"stream"`
+	Options struct {
+		Stop []string `json:"stop"`
+	} `json:"options"`
+}
+
+// newTestServer starts a fake Ollama server that replies "Hello world", split
+// into two chunks when streaming, and fails mid-stream when the prompt is
+// "fail". It returns the lingo server in front of it and a pointer to the last
+// request the fake received.
+func newTestServer(t *testing.T) (*Server, *ollamaRequest) {
+	t.Helper()
+	last := &ollamaRequest{}
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ollamaRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		*last = req
+
+		if !req.Stream {
+			fmt.Fprint(w, `{"message":{"role":"assistant","content":"Hello world"},"done":true,"done_reason":"stop","prompt_eval_count":3,"eval_count":2}`)
+			return
+		}
+		fmt.Fprintln(w, `{"message":{"role":"assistant","content":"Hello"},"done":false}`)
+		if req.Messages[len(req.Messages)-1].Content == "fail" {
+			fmt.Fprintln(w, `{"error":"boom"}`)
+			return
+		}
+		fmt.Fprintln(w, `{"message":{"role":"assistant","content":" world"},"done":false}`)
+		fmt.Fprintln(w, `{"message":{"role":"assistant","content":""},"done":true,"done_reason":"stop"}`)
+	}))
+	t.Cleanup(upstream.Close)
+
+	gateway, err := lingo.New([]lingo.ProviderConfig{&lingo.OllamaConfig{BaseURL: upstream.URL}})
+	if err != nil {
+		t.Fatalf("lingo.New: %v", err)
+	}
+	t.Cleanup(func() { gateway.Close() })
+
+	server := New(gateway, lingo.WithNoRetry(true)).
+		Handle("local", func() lingo.Model { return lingo.NewOllamaModel("llama3") }).
+		Handle("gpt", func() lingo.Model { return lingo.NewGPT4o() })
+	return server, last
+}
+
+// post sends a chat completion request to the server
+func post(server *Server, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body)))
+	return rec
+}
+
+func TestChatCompletions(t *testing.T) {
+	server, last := newTestServer(t)
+
+	tests := []struct {
+		name     string
+		messages string
+		want     []string // Upstream messages as "role: content"
+	}{
+		{"string content", `[{"role":"user","content":"Hi"}]`, []string{"user: Hi"}},
+		{"part array content", `[{"role":"user","content":[{"type":"text","text":"Hi "},{"type":"text","text":"there"}]}]`, []string{"user: Hi there"}},
+		{"system prompt", `[{"role":"system","content":"Be brief"},{"role":"user","content":"Hi"}]`, []string{"system: Be brief", "user: Hi"}},
+		{"conversation", `[{"role":"developer","content":"Be brief"},{"role":"user","content":"Hi"},{"role":"assistant","content":"Hello"},{"role":"user","content":"Bye"}]`,
+			[]string{"system: Be brief", "user: Hi", "assistant: Hello", "user: Bye"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := post(server, `{"model":"local","messages":`+tt.messages+`}`)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+			}
+			var got []string
+			for _, m := range last.Messages {
+				got = append(got, m.Role+": "+m.Content)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("upstream messages = %q, want %q", got, tt.want)
+			}
+
+			var resp chatCompletion
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Object != "chat.completion" || resp.Model != "local" || len(resp.Choices) != 1 {
+				t.Fatalf("response = %+v", resp)
+			}
+			if got := resp.Choices[0].Message.Content; got != "Hello world" {
+				t.Errorf("content = %q, want %q", got, "Hello world")
+			}
+			if got := *resp.Choices[0].FinishReason; got != "stop" {
+				t.Errorf("finish_reason = %q, want stop", got)
+			}
+			if resp.Usage == nil || resp.Usage.TotalTokens != 5 {
+				t.Errorf("usage = %+v, want 5 total tokens", resp.Usage)
+			}
+		})
+	}
+}
+
+func TestChatCompletionsStop(t *testing.T) {
+	server, last := newTestServer(t)
+
+	tests := []struct {
+		name string
+		stop string
+		want []string
+	}{
+		{"string", `"END"`, []string{"END"}},
+		{"list", `["END", "###"]`, []string{"END", "###"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := post(server, `{"model":"local","messages":[{"role":"user","content":"Hi"}],"stop":`+tt.stop+`}`)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+			}
+			if got := last.Options.Stop; strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("stop sent upstream = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChatCompletionsBadRequest(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{"invalid json", `{"model":`, http.StatusBadRequest},
+		{"no messages", `{"model":"local","messages":[]}`, http.StatusBadRequest},
+		{"only a system message", `{"model":"local","messages":[{"role":"system","content":"Be brief"}]}`, http.StatusBadRequest},
+		{"unknown role", `{"model":"local","messages":[{"role":"tool","content":"42"}]}`, http.StatusBadRequest},
+		{"turns out of order", `{"model":"local","messages":[{"role":"user","content":"Hi"},{"role":"user","content":"Hi"}]}`, http.StatusBadRequest},
+		{"ends with assistant", `{"model":"local","messages":[{"role":"user","content":"Hi"},{"role":"assistant","content":"Hello"}]}`, http.StatusBadRequest},
+		{"multi-turn without chat support", `{"model":"gpt","messages":[{"role":"user","content":"Hi"},{"role":"assistant","content":"Hello"},{"role":"user","content":"Bye"}]}`, http.StatusBadRequest},
+		{"image part", `{"model":"local","messages":[{"role":"user","content":[{"type":"image_url"}]}]}`, http.StatusBadRequest},
+		{"invalid stop", `{"model":"local","messages":[{"role":"user","content":"Hi"}],"stop":3}`, http.StatusBadRequest},
+		{"max_tokens", `{"model":"local","messages":[{"role":"user","content":"Hi"}],"max_tokens":10}`, http.StatusBadRequest},
+		{"temperature", `{"model":"local","messages":[{"role":"user","content":"Hi"}],"temperature":0}`, http.StatusBadRequest},
+		{"top_p", `{"model":"local","messages":[{"role":"user","content":"Hi"}],"top_p":0.9}`, http.StatusBadRequest},
+		{"unknown model", `{"model":"nope","messages":[{"role":"user","content":"Hi"}]}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := post(server, tt.body)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			var body errorBody
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.Error.Message == "" {
+				t.Errorf("body = %s, want an OpenAI error", rec.Body)
+			}
+		})
+	}
+
+	// Null sampling fields, as some clients send, are accepted
+	if rec := post(server, `{"model":"local","messages":[{"role":"user","content":"Hi"}],"temperature":null}`); rec.Code != http.StatusOK {
+		t.Errorf("null temperature: status = %d, want 200", rec.Code)
+	}
+}
+
+func TestChatCompletionsBodyLimit(t *testing.T) {
+	server, _ := newTestServer(t)
+	server.WithMaxRequestBytes(64)
+
+	rec := post(server, `{"model":"local","messages":[{"role":"user","content":"`+strings.Repeat("a", 100)+`"}]}`)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+// readEvents returns the data payloads of a server-sent event stream
+func readEvents(t *testing.T, rec *httptest.ResponseRecorder) []string {
+	t.Helper()
+	if got := rec.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("Content-Type = %q, want text/event-stream", got)
+	}
+	var events []string
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		data, ok := strings.CutPrefix(line, "data: ")
+		if !ok {
+			t.Fatalf("line %q is not a data field", line)
+		}
+		events = append(events, data)
+	}
+	return events
+}
+
+func TestChatCompletionsStream(t *testing.T) {
+	server, last := newTestServer(t)
+
+	tests := []struct {
+		name      string
+		messages  string
+		wantTurns int
+	}{
+		{"single turn", `[{"role":"user","content":"Hi"}]`, 1},
+		{"conversation", `[{"role":"user","content":"Hi"},{"role":"assistant","content":"Hello"},{"role":"user","content":"Bye"}]`, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := post(server, `{"model":"local","stream":true,"messages":`+tt.messages+`}`)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+			}
+			if len(last.Messages) != tt.wantTurns {
+				t.Errorf("sent %d messages upstream, want %d", len(last.Messages), tt.wantTurns)
+			}
+			events := readEvents(t, rec)
+			if len(events) == 0 || events[len(events)-1] != "[DONE]" {
+				t.Fatalf("events = %q, want the stream to end with [DONE]", events)
+			}
+
+			var text strings.Builder
+			var finish string
+			for _, data := range events[:len(events)-1] {
+				var chunk chatCompletion
+				if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+					t.Fatalf("decode chunk %s: %v", data, err)
+				}
+				if chunk.Object != "chat.completion.chunk" || chunk.Model != "local" || len(chunk.Choices) != 1 {
+					t.Fatalf("chunk = %s", data)
+				}
+				if finish != "" {
+					t.Fatalf("chunk %s follows the finish chunk", data)
+				}
+				text.WriteString(chunk.Choices[0].Delta.Content)
+				if chunk.Choices[0].FinishReason != nil {
+					finish = *chunk.Choices[0].FinishReason
+				}
+			}
+			if text.String() != "Hello world" {
+				t.Errorf("streamed text = %q, want %q", text.String(), "Hello world")
+			}
+			if finish != "stop" {
+				t.Errorf("finish_reason = %q, want stop", finish)
+			}
+		})
+	}
+}
+
+func TestChatCompletionsStreamError(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	rec := post(server, `{"model":"local","stream":true,"messages":[{"role":"user","content":"fail"}]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want the error in the stream", rec.Code)
+	}
+	events := readEvents(t, rec)
+	if len(events) != 2 {
+		t.Fatalf("events = %q, want a chunk and an error", events)
+	}
+
+	var body errorBody
+	if err := json.Unmarshal([]byte(events[1]), &body); err != nil || !strings.Contains(body.Error.Message, "boom") {
+		t.Errorf("last event = %s, want the upstream error", events[1])
+	}
+}
+
+func TestWriteProviderError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantStatus     int
+		wantType       string
+		wantRetryAfter string
+	}{
+		{"auth", &lingo.ProviderError{Kind: lingo.KindAuth, Err: errors.New("bad key")}, http.StatusBadGateway, "upstream_auth_error", ""},
+		{"invalid request", &lingo.ProviderError{Kind: lingo.KindInvalidRequest, Err: errors.New("bad")}, http.StatusBadRequest, "invalid_request_error", ""},
+		{"not found", &lingo.ProviderError{Kind: lingo.KindNotFound, Err: errors.New("gone")}, http.StatusNotFound, "invalid_request_error", ""},
+		{"rate limit", &lingo.ProviderError{Kind: lingo.KindRateLimit, RetryAfter: 1600 * time.Millisecond, Err: errors.New("slow down")}, http.StatusTooManyRequests, "rate_limit_error", "2"},
+		{"timeout", &lingo.ProviderError{Kind: lingo.KindTimeout, Err: errors.New("slow")}, http.StatusGatewayTimeout, "timeout_error", ""},
+		{"wrapped", fmt.Errorf("all models failed: %w", &lingo.ProviderError{Kind: lingo.KindNotFound, Err: errors.New("gone")}), http.StatusNotFound, "invalid_request_error", ""},
+		{"provider not registered", fmt.Errorf("%w: openai", lingo.ErrProviderNotRegistered), http.StatusBadRequest, "invalid_request_error", ""},
+		{"input too long", fmt.Errorf("%w: 10 characters", lingo.ErrInputTooLong), http.StatusBadRequest, "invalid_request_error", ""},
+		{"other", errors.New("boom"), http.StatusInternalServerError, "server_error", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			writeProviderError(rec, tt.err)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Retry-After"); got != tt.wantRetryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.wantRetryAfter)
+			}
+			var body errorBody
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body.Error.Type != tt.wantType || body.Error.Message != tt.err.Error() {
+				t.Errorf("error = %+v, want type %s and message %q", body.Error, tt.wantType, tt.err)
+			}
+		})
+	}
+}
+
+func TestModels(t *testing.T) {
+	server, _ := newTestServer(t)
+	server.Handle("llama3", func() lingo.Model { return lingo.NewLlama3() })
+
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/models", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d", rec.Code)
+	}
+	var list struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+
+	count := make(map[string]int)
+	for _, m := range list.Data {
+		count[m.ID]++
+	}
+	for _, name := range []string{"local", "llama3", "mistral"} {
+		if count[name] != 1 {
+			t.Errorf("%s listed %d times, want once", name, count[name])
+		}
+	}
+	// Only the Ollama provider is registered
+	if count["gpt-4o"] != 0 {
+		t.Error("gpt-4o listed without an OpenAI provider")
+	}
+}
+
+func TestBuiltinModelFallback(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	if rec := post(server, `{"model":"Llama-3.1","messages":[{"role":"user","content":"Hi"}]}`); rec.Code != http.StatusOK {
+		t.Errorf("built-in alias: status = %d, body %s", rec.Code, rec.Body)
+	}
+	// Built-in models of providers the gateway lacks fail as bad requests
+	if rec := post(server, `{"model":"gpt-4o","messages":[{"role":"user","content":"Hi"}]}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("unregistered provider: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
@@ -31,6 +31,8 @@ func NewClient(config ClientConfig) (*Client, error) {
 	timeout := config.Timeout
 	if timeout == 0 {
 		timeout = 30 * time.Second
+	} else if timeout < 0 {
+		timeout = 0
 	}
 
 	return &Client{
@@ -124,6 +126,8 @@ func (c *Client) ChatCompletions(ctx context.Context, req ChatCompletionRequest)
 	if err := json.Unmarshal(respBody, &chatResp); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
+	chatResp.Raw = respBody
+	chatResp.Header = resp.Header
 
 	return &chatResp, nil
 }
@@ -9,11 +9,19 @@
 // Reference: https://docs.perplexity.ai/getting-started/overview
 package perplexity
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
 
 // BaseURL is the Perplexity API base URL
 const BaseURL = "https://api.perplexity.ai"
 
+// DateFormat is the MM/DD/YYYY layout of the search date filters
+const DateFormat = "01/02/2006"
+
 // ============================================================================
 // COMMON TYPES
 // ============================================================================
@@ -44,7 +52,8 @@ type ClientConfig struct {
 	// BaseURL is the API base URL (defaults to https://api.perplexity.ai)
 	BaseURL string
 
-	// Timeout is the HTTP client timeout (default: 30s)
+	// Timeout is the HTTP client timeout (default: 30s). A negative timeout
+	// disables it, leaving the request context to bound each request.
 	Timeout time.Duration
 }
 
@@ -136,6 +145,10 @@ type ChatCompletionRequest struct {
 	// Messages is the list of messages in the conversation (required)
 	Messages []Message `json:"messages"`
 
+	// ExtraBody holds additional top-level fields merged into the request body,
+	// for parameters this client doesn't model yet
+	ExtraBody map[string]any `json:"-"`
+
 	// MaxTokens is the maximum number of tokens to generate
 	MaxTokens int `json:"max_tokens,omitempty"`
 
@@ -172,6 +185,64 @@ type ChatCompletionRequest struct {
 
 	// SearchRecencyFilter filters search by recency: "hour", "day", "week", "month"
 	SearchRecencyFilter string `json:"search_recency_filter,omitempty"`
+
+	// SearchMode selects the sources searched: "web" (default) or "academic"
+	// for peer-reviewed papers and journals
+	SearchMode string `json:"search_mode,omitempty"`
+
+	// SearchAfterDateFilter limits search to content published after this date (DateFormat)
+	SearchAfterDateFilter string `json:"search_after_date_filter,omitempty"`
+
+	// SearchBeforeDateFilter limits search to content published before this date (DateFormat)
+	SearchBeforeDateFilter string `json:"search_before_date_filter,omitempty"`
+
+	// WebSearchOptions configures how much web search is done
+	WebSearchOptions *WebSearchOptions `json:"web_search_options,omitempty"`
+
+	// ResponseFormat constrains the answer to a JSON schema
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+}
+
+// ResponseFormat requests structured output
+type ResponseFormat struct {
+	// Type is "json_schema"
+	Type string `json:"type"`
+	// JSONSchema holds the schema the answer must follow
+	JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+}
+
+// JSONSchema wraps a JSON schema for ResponseFormat
+type JSONSchema struct {
+	Schema any `json:"schema"`
+}
+
+// WebSearchOptions configures web search for chat completions
+type WebSearchOptions struct {
+	// SearchContextSize is how much search context is retrieved: "low"
+	// (default, cheapest), "medium" or "high" (most thorough)
+	SearchContextSize string `json:"search_context_size,omitempty"`
+}
+
+// MarshalJSON encodes the request with ExtraBody merged into the top level
+func (r ChatCompletionRequest) MarshalJSON() ([]byte, error) {
+	type plain ChatCompletionRequest
+	body, err := json.Marshal(plain(r))
+	if err != nil || len(r.ExtraBody) == 0 {
+		return body, err
+	}
+
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(body, &merged); err != nil {
+		return nil, err
+	}
+	for k, v := range r.ExtraBody {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode extra body field %q: %w", k, err)
+		}
+		merged[k] = raw
+	}
+	return json.Marshal(merged)
 }
 
 // ChatCompletionResponse represents the response from chat completions
@@ -202,6 +273,12 @@ type ChatCompletionResponse struct {
 
 	// RelatedQuestions contains follow-up questions if requested
 	RelatedQuestions []string `json:"related_questions,omitempty"`
+
+	// Raw is the unparsed response body
+	Raw json.RawMessage `json:"-"`
+
+	// Header is the HTTP response header
+	Header http.Header `json:"-"`
 }
 
 // Choice represents a single completion choice
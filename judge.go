@@ -0,0 +1,67 @@
+package lingo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ============================================================================
+// LLM AS JUDGE
+// ============================================================================
+
+// judgeVerdict is the JSON object the judge model is asked to return
+type judgeVerdict struct {
+	Best      int    `json:"best"`
+	Rationale string `json:"rationale"`
+}
+
+// JudgeSchema returns a JSON schema for the judge's verdict, for judge models
+// that take one (such as an Ollama model's WithFormat or a Sonar model's
+// WithResponseSchema)
+func JudgeSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"best":      map[string]any{"type": "integer"},
+			"rationale": map[string]any{"type": "string"},
+		},
+		"required": []string{"best", "rationale"},
+	}
+}
+
+// Judge asks the judge model which candidate response best meets the
+// criteria and returns its index in candidates with the judge's rationale.
+// The judge answers in JSON; configure it with JudgeSchema where the model
+// supports structured output for more reliable verdicts.
+func (g *LLMGateway) Judge(ctx context.Context, judge Model, criteria string, candidates []string, opts ...GenerateOption) (int, string, error) {
+	if len(candidates) == 0 {
+		return 0, "", fmt.Errorf("at least one candidate is required for judging")
+	}
+
+	var verdict judgeVerdict
+	if _, err := g.GenerateInto(ctx, judge, judgePrompt(criteria, candidates), &verdict, opts...); err != nil {
+		return 0, "", fmt.Errorf("judge failed: %w", err)
+	}
+
+	// Candidates are numbered from 1 in the prompt
+	best := verdict.Best - 1
+	if best < 0 || best >= len(candidates) {
+		return 0, "", fmt.Errorf("judge picked candidate %d, want 1 to %d", verdict.Best, len(candidates))
+	}
+	return best, verdict.Rationale, nil
+}
+
+// judgePrompt builds the ranking prompt sent to the judge model
+func judgePrompt(criteria string, candidates []string) string {
+	var b strings.Builder
+	b.WriteString("You are judging candidate responses. Pick the one that best meets these criteria:\n\n")
+	b.WriteString(criteria)
+	b.WriteString("\n\n")
+	for i, c := range candidates {
+		fmt.Fprintf(&b, "<candidate number=\"%d\">\n%s\n</candidate>\n\n", i+1, c)
+	}
+	fmt.Fprintf(&b, "Respond with only a JSON object of the form "+
+		"{\"best\": <candidate number from 1 to %d>, \"rationale\": \"<why it is best>\"}.", len(candidates))
+	return b.String()
+}
@@ -0,0 +1,136 @@
+// Package langchain adapts a lingo gateway and model to the langchaingo
+// llms.Model interface, so lingo's providers and rate limiting can be used
+// inside langchaingo chains and agents.
+//
+// It lives in its own module so that the core lingo module doesn't depend on
+// langchaingo.
+package langchain
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gerdou/lingo"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// LLM is a langchaingo llms.Model backed by a lingo gateway and model
+type LLM struct {
+	gateway *lingo.LLMGateway
+	model   lingo.Model
+	opts    []lingo.GenerateOption
+}
+
+var _ llms.Model = (*LLM)(nil)
+
+// New creates an adapter that sends every request to the given model through
+// the gateway. The generate options are applied to every request.
+func New(gateway *lingo.LLMGateway, model lingo.Model, opts ...lingo.GenerateOption) *LLM {
+	return &LLM{gateway: gateway, model: model, opts: opts}
+}
+
+// GenerateContent implements llms.Model. lingo requests carry a single prompt,
+// so a conversation is flattened into a transcript with one line per message.
+// Generation settings such as temperature and max tokens come from the lingo
+// model; of the call options only StopWords and StreamingFunc are honored.
+// Tool calling and non-text content are not supported.
+func (l *LLM) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	callOpts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&callOpts)
+	}
+	if len(callOpts.Tools) > 0 || len(callOpts.Functions) > 0 {
+		return nil, fmt.Errorf("lingo langchain adapter does not support tool calling")
+	}
+
+	prompt, err := flattenMessages(messages)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := l.opts
+	if len(callOpts.StopWords) > 0 {
+		quoted := make([]string, len(callOpts.StopWords))
+		for i, word := range callOpts.StopWords {
+			quoted[i] = regexp.QuoteMeta(word)
+		}
+		opts = append(opts[:len(opts):len(opts)], lingo.WithStopOnRegex(strings.Join(quoted, "|")))
+	}
+
+	var resp *lingo.GenerationResponse
+	if callOpts.StreamingFunc != nil && lingo.Describe(l.model).Has(lingo.CapabilityStreaming) {
+		resp, err = l.gateway.GenerateStream(ctx, l.model, prompt, func(chunk lingo.StreamChunk) error {
+			return callOpts.StreamingFunc(ctx, []byte(chunk.Text))
+		}, opts...)
+	} else {
+		resp, err = l.gateway.Generate(ctx, l.model, prompt, opts...)
+		if err == nil && callOpts.StreamingFunc != nil {
+			// The provider can't stream, so deliver the whole text as one chunk
+			err = callOpts.StreamingFunc(ctx, []byte(resp.Text))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &llms.ContentResponse{
+		Choices: []*llms.ContentChoice{{
+			Content:    resp.Text,
+			StopReason: resp.FinishReason,
+			GenerationInfo: map[string]any{
+				"PromptTokens":     resp.Usage.PromptTokens,
+				"CompletionTokens": resp.Usage.CompletionTokens,
+				"TotalTokens":      resp.Usage.TotalTokens,
+				"Provider":         string(resp.Provider),
+				"Model":            resp.Model,
+			},
+			ReasoningContent: resp.Metadata["thinking"],
+		}},
+	}, nil
+}
+
+// Call implements the deprecated llms.Model method
+func (l *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, l, prompt, options...)
+}
+
+// flattenMessages converts langchaingo messages into a single prompt. A lone
+// message is sent as is; longer conversations are labeled by role.
+func flattenMessages(messages []llms.MessageContent) (string, error) {
+	if len(messages) == 0 {
+		return "", fmt.Errorf("at least one message is required")
+	}
+
+	lines := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		var text strings.Builder
+		for _, part := range msg.Parts {
+			textPart, ok := part.(llms.TextContent)
+			if !ok {
+				return "", fmt.Errorf("lingo langchain adapter does not support %T message parts", part)
+			}
+			text.WriteString(textPart.Text)
+		}
+		if len(messages) == 1 {
+			return text.String(), nil
+		}
+		lines = append(lines, roleLabel(msg.Role)+": "+text.String())
+	}
+	return strings.Join(lines, "\n\n"), nil
+}
+
+// roleLabel returns the transcript label for a message role
+func roleLabel(role llms.ChatMessageType) string {
+	switch role {
+	case llms.ChatMessageTypeSystem:
+		return "System"
+	case llms.ChatMessageTypeHuman, llms.ChatMessageTypeGeneric:
+		return "User"
+	case llms.ChatMessageTypeAI:
+		return "Assistant"
+	default:
+		return string(role)
+	}
+}
@@ -0,0 +1,170 @@
+package langchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gerdou/lingo"
+	"github.com/tmc/langchaingo/llms"
+)
+
+const reply = "Hello world. END of the reply"
+
+// newTestGateway starts fake Ollama and OpenAI servers that both answer with
+// reply, and returns a gateway with both providers pointed at them. The Ollama
+// server streams the reply in two chunks when asked to.
+func newTestGateway(t *testing.T) *lingo.LLMGateway {
+	t.Helper()
+	ollama := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Stream bool `json:"stream"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !req.Stream {
+			fmt.Fprintf(w, `{"message":{"role":"assistant","content":%q},"done":true,"done_reason":"stop"}`, reply)
+			return
+		}
+		first, rest, _ := strings.Cut(reply, " ")
+		fmt.Fprintf(w, `{"message":{"role":"assistant","content":%q},"done":false}`+"\n", first+" ")
+		fmt.Fprintf(w, `{"message":{"role":"assistant","content":%q},"done":false}`+"\n", rest)
+		fmt.Fprintln(w, `{"message":{"role":"assistant","content":""},"done":true,"done_reason":"stop"}`)
+	}))
+	t.Cleanup(ollama.Close)
+	openai := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id":"1","object":"chat.completion","model":"gpt-4o","choices":[{"index":0,"finish_reason":"stop","message":{"role":"assistant","content":%q}}]}`, reply)
+	}))
+	t.Cleanup(openai.Close)
+
+	gateway, err := lingo.New([]lingo.ProviderConfig{
+		&lingo.OllamaConfig{BaseURL: ollama.URL},
+		&lingo.OpenAIConfig{APIKey: "test", BaseURL: openai.URL},
+	})
+	if err != nil {
+		t.Fatalf("lingo.New: %v", err)
+	}
+	t.Cleanup(func() { gateway.Close() })
+	return gateway
+}
+
+func TestFlattenMessages(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []llms.MessageContent
+		want     string
+	}{
+		{
+			"single message",
+			[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Hi")},
+			"Hi",
+		},
+		{
+			"joined parts",
+			[]llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "Hi ", "there")},
+			"Hi there",
+		},
+		{
+			"conversation",
+			[]llms.MessageContent{
+				llms.TextParts(llms.ChatMessageTypeSystem, "Be brief"),
+				llms.TextParts(llms.ChatMessageTypeHuman, "Hi"),
+				llms.TextParts(llms.ChatMessageTypeAI, "Hello"),
+				llms.TextParts(llms.ChatMessageTypeGeneric, "Bye"),
+			},
+			"System: Be brief\n\nUser: Hi\n\nAssistant: Hello\n\nUser: Bye",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := flattenMessages(tt.messages)
+			if err != nil {
+				t.Fatalf("flattenMessages: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("flattenMessages = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlattenMessagesErrors(t *testing.T) {
+	if _, err := flattenMessages(nil); err == nil {
+		t.Error("flattenMessages(nil) succeeded, want an error")
+	}
+
+	image := []llms.MessageContent{{
+		Role:  llms.ChatMessageTypeHuman,
+		Parts: []llms.ContentPart{llms.ImageURLContent{URL: "https://example.com/cat.png"}},
+	}}
+	if _, err := flattenMessages(image); err == nil {
+		t.Error("flattenMessages with an image part succeeded, want an error")
+	}
+}
+
+func TestGenerateContent(t *testing.T) {
+	gateway := newTestGateway(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		model      lingo.Model
+		opts       []llms.CallOption
+		want       string
+		wantChunks int
+	}{
+		{"plain", lingo.NewLlama3(), nil, reply, 0},
+		{"stop words", lingo.NewLlama3(), []llms.CallOption{llms.WithStopWords([]string{"END", "."})}, "Hello world", 0},
+		{"streaming", lingo.NewLlama3(), nil, reply, 2},
+		{"streaming fallback", lingo.NewGPT4o(), nil, reply, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var chunks []string
+			opts := tt.opts
+			if tt.wantChunks > 0 {
+				opts = append(opts, llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+					chunks = append(chunks, string(chunk))
+					return nil
+				}))
+			}
+
+			resp, err := New(gateway, tt.model).GenerateContent(ctx, []llms.MessageContent{
+				llms.TextParts(llms.ChatMessageTypeHuman, "Hi"),
+			}, opts...)
+			if err != nil {
+				t.Fatalf("GenerateContent: %v", err)
+			}
+			if got := resp.Choices[0].Content; got != tt.want {
+				t.Errorf("content = %q, want %q", got, tt.want)
+			}
+			if len(chunks) != tt.wantChunks {
+				t.Errorf("streamed %d chunks, want %d", len(chunks), tt.wantChunks)
+			}
+			if tt.wantChunks > 0 && strings.Join(chunks, "") != tt.want {
+				t.Errorf("streamed %q, want %q", strings.Join(chunks, ""), tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateContentRejectsTools(t *testing.T) {
+	llm := New(newTestGateway(t), lingo.NewLlama3())
+
+	_, err := llm.GenerateContent(context.Background(), []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeHuman, "What's the weather?"),
+	}, llms.WithTools([]llms.Tool{{
+		Type:     "function",
+		Function: &llms.FunctionDefinition{Name: "weather"},
+	}}))
+	if err == nil || !strings.Contains(err.Error(), "tool calling") {
+		t.Errorf("GenerateContent error = %v, want tool calling to be rejected", err)
+	}
+}
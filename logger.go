@@ -1,6 +1,10 @@
 package lingo
 
 import (
+	"context"
+	"log/slog"
+	"time"
+
 	"github.com/rs/zerolog"
 )
 
@@ -22,6 +26,10 @@ func (z *ZerologAdapter) Info() LogEvent {
 	return &zerologEvent{event: z.logger.Info()}
 }
 
+func (z *ZerologAdapter) Warn() LogEvent {
+	return &zerologEvent{event: z.logger.Warn()}
+}
+
 func (z *ZerologAdapter) Error() LogEvent {
 	return &zerologEvent{event: z.logger.Error()}
 }
@@ -49,6 +57,16 @@ func (e *zerologEvent) Int64(key string, val int64) LogEvent {
 	return e
 }
 
+func (e *zerologEvent) Float(key string, val float64) LogEvent {
+	e.event = e.event.Float64(key, val)
+	return e
+}
+
+func (e *zerologEvent) Dur(key string, val time.Duration) LogEvent {
+	e.event = e.event.Dur(key, val)
+	return e
+}
+
 func (e *zerologEvent) Bool(key string, val bool) LogEvent {
 	e.event = e.event.Bool(key, val)
 	return e
@@ -59,19 +77,99 @@ func (e *zerologEvent) Err(err error) LogEvent {
 	return e
 }
 
+// SlogAdapter adapts slog.Logger to our Logger interface
+type SlogAdapter struct {
+	logger *slog.Logger
+}
+
+// NewSlogAdapter creates a new adapter for slog
+func NewSlogAdapter(logger *slog.Logger) *SlogAdapter {
+	return &SlogAdapter{logger: logger}
+}
+
+func (s *SlogAdapter) Debug() LogEvent {
+	return &slogEvent{logger: s.logger, level: slog.LevelDebug}
+}
+
+func (s *SlogAdapter) Info() LogEvent {
+	return &slogEvent{logger: s.logger, level: slog.LevelInfo}
+}
+
+func (s *SlogAdapter) Warn() LogEvent {
+	return &slogEvent{logger: s.logger, level: slog.LevelWarn}
+}
+
+func (s *SlogAdapter) Error() LogEvent {
+	return &slogEvent{logger: s.logger, level: slog.LevelError}
+}
+
+type slogEvent struct {
+	logger *slog.Logger
+	level  slog.Level
+	attrs  []slog.Attr
+}
+
+func (e *slogEvent) Msg(msg string) {
+	e.logger.LogAttrs(context.Background(), e.level, msg, e.attrs...)
+}
+
+func (e *slogEvent) Str(key, val string) LogEvent {
+	e.attrs = append(e.attrs, slog.String(key, val))
+	return e
+}
+
+func (e *slogEvent) Int(key string, val int) LogEvent {
+	e.attrs = append(e.attrs, slog.Int(key, val))
+	return e
+}
+
+func (e *slogEvent) Int64(key string, val int64) LogEvent {
+	e.attrs = append(e.attrs, slog.Int64(key, val))
+	return e
+}
+
+func (e *slogEvent) Float(key string, val float64) LogEvent {
+	e.attrs = append(e.attrs, slog.Float64(key, val))
+	return e
+}
+
+func (e *slogEvent) Dur(key string, val time.Duration) LogEvent {
+	e.attrs = append(e.attrs, slog.Duration(key, val))
+	return e
+}
+
+func (e *slogEvent) Bool(key string, val bool) LogEvent {
+	e.attrs = append(e.attrs, slog.Bool(key, val))
+	return e
+}
+
+func (e *slogEvent) Err(err error) LogEvent {
+	if err != nil {
+		e.attrs = append(e.attrs, slog.Any("error", err))
+	}
+	return e
+}
+
 // NopLogger is a no-op logger that discards all logs
 type NopLogger struct{}
 
 func (n *NopLogger) Debug() LogEvent { return &nopEvent{} }
 func (n *NopLogger) Info() LogEvent  { return &nopEvent{} }
+func (n *NopLogger) Warn() LogEvent  { return &nopEvent{} }
 func (n *NopLogger) Error() LogEvent { return &nopEvent{} }
 
 type nopEvent struct{}
 
-func (e *nopEvent) Msg(msg string)                 {}
-func (e *nopEvent) Str(key, val string) LogEvent   { return e }
-func (e *nopEvent) Int(key string, val int) LogEvent { return e }
-func (e *nopEvent) Int64(key string, val int64) LogEvent { return e }
-func (e *nopEvent) Bool(key string, val bool) LogEvent { return e }
-func (e *nopEvent) Err(err error) LogEvent         { return e }
+// quietLogger discards debug logs and passes the other levels through
+type quietLogger struct{ Logger }
+
+func (quietLogger) Debug() LogEvent { return &nopEvent{} }
 
+func (e *nopEvent) Msg(msg string)                             {}
+func (e *nopEvent) Str(key, val string) LogEvent               { return e }
+func (e *nopEvent) Int(key string, val int) LogEvent           { return e }
+func (e *nopEvent) Int64(key string, val int64) LogEvent       { return e }
+func (e *nopEvent) Float(key string, val float64) LogEvent     { return e }
+func (e *nopEvent) Dur(key string, val time.Duration) LogEvent { return e }
+func (e *nopEvent) Bool(key string, val bool) LogEvent         { return e }
+func (e *nopEvent) Err(err error) LogEvent                     { return e }
@@ -0,0 +1,358 @@
+package lingo
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ============================================================================
+// MODEL INFO
+// ============================================================================
+
+// ModelCapability names a feature a model supports beyond plain generation
+type ModelCapability string
+
+const (
+	// CapabilityStreaming means the model can be used with GenerateStream
+	CapabilityStreaming ModelCapability = "streaming"
+	// CapabilityChat means the model can be used with GenerateChat
+	CapabilityChat ModelCapability = "chat"
+	// CapabilityBatch means the model can be used in batch jobs
+	CapabilityBatch ModelCapability = "batch"
+	// CapabilityReasoning means the model reasons internally before answering
+	CapabilityReasoning ModelCapability = "reasoning"
+	// CapabilityThinking means the model supports extended thinking with visible output
+	CapabilityThinking ModelCapability = "thinking"
+	// CapabilityAudioOutput means the model can answer with speech
+	CapabilityAudioOutput ModelCapability = "audio_output"
+)
+
+// ModelInfo is a serializable description of a model, for admin and debug
+// endpoints that list what's available
+type ModelInfo struct {
+	// Name is the API model identifier
+	Name string
+	// Provider is the provider serving the model
+	Provider ProviderType
+	// Capabilities lists the features the model supports
+	Capabilities []ModelCapability
+	// Timeout is the request timeout used when the provider config doesn't
+	// set one
+	Timeout time.Duration
+	// ContextWindow is the number of tokens the model accepts, prompt and
+	// output combined
+	ContextWindow int
+}
+
+// Describe returns a description of the model and its capabilities
+func Describe(model Model) ModelInfo {
+	info := ModelInfo{
+		Name:          model.ModelName(),
+		Provider:      model.Provider(),
+		Timeout:       requestTimeout(0, model.Provider(), model.ModelName()),
+		ContextWindow: contextWindow(model),
+	}
+
+	switch model.Provider() {
+	case ProviderOllama:
+		info.Capabilities = append(info.Capabilities, CapabilityStreaming, CapabilityChat)
+	case ProviderPerplexity:
+		info.Capabilities = append(info.Capabilities, CapabilityChat)
+	case ProviderOpenAI, ProviderAnthropic:
+		info.Capabilities = append(info.Capabilities, CapabilityBatch)
+	}
+
+	if _, ok := model.(openAIReasoningModel); ok {
+		info.Capabilities = append(info.Capabilities, CapabilityReasoning)
+	}
+	if m, ok := model.(anthropicThinkingModel); ok && m.supportsThinking() {
+		info.Capabilities = append(info.Capabilities, CapabilityThinking)
+	}
+	if _, ok := model.(openAIAudioOutputModel); ok {
+		info.Capabilities = append(info.Capabilities, CapabilityAudioOutput)
+	}
+
+	return info
+}
+
+// Has reports whether the model supports the capability
+func (i ModelInfo) Has(c ModelCapability) bool {
+	for _, have := range i.Capabilities {
+		if have == c {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON encodes the info with snake_case keys, an empty capability list
+// instead of null and the timeout in seconds
+func (i ModelInfo) MarshalJSON() ([]byte, error) {
+	capabilities := i.Capabilities
+	if capabilities == nil {
+		capabilities = []ModelCapability{}
+	}
+	return json.Marshal(struct {
+		Name           string            `json:"name"`
+		Provider       ProviderType      `json:"provider"`
+		Capabilities   []ModelCapability `json:"capabilities"`
+		TimeoutSeconds float64           `json:"timeout_seconds"`
+		ContextWindow  int               `json:"context_window"`
+	}{i.Name, i.Provider, capabilities, i.Timeout.Seconds(), i.ContextWindow})
+}
+
+// ============================================================================
+// CONTEXT WINDOWS
+// ============================================================================
+
+// fallbackContextWindow is the context window assumed for models missing from
+// contextWindows, small enough to be safe for most local models
+const fallbackContextWindow = 8192
+
+// contextWindows is the number of tokens each model accepts, prompt and output
+// combined, keyed by default model name
+var contextWindows = map[string]int{
+	// OpenAI
+	"gpt-4":                     8192,
+	"gpt-4-turbo":               128000,
+	"gpt-3.5-turbo":             16385,
+	"gpt-4o":                    128000,
+	"gpt-4o-mini":               128000,
+	"gpt-4o-audio-preview":      128000,
+	"gpt-4o-mini-audio-preview": 128000,
+	"gpt-4.1":                   1047576,
+	"gpt-4.1-mini":              1047576,
+	"gpt-4.1-nano":              1047576,
+	"o1":                        200000,
+	"o1-mini":                   128000,
+	"o1-pro":                    200000,
+	"o1-preview":                128000,
+	"o3":                        200000,
+	"o3-mini":                   200000,
+	"o3-pro":                    200000,
+	"o4-mini":                   200000,
+	"gpt-5":                     400000,
+	"gpt-5-mini":                400000,
+	"gpt-5-nano":                400000,
+	"gpt-5-pro":                 400000,
+	"gpt-5-turbo":               400000,
+	"gpt-5.1":                   400000,
+	"gpt-5.1-mini":              400000,
+	"gpt-5.1-nano":              400000,
+	"gpt-5.1-codex":             400000,
+	"gpt-5.1-codex-mini":        400000,
+
+	// Anthropic
+	"claude-3-haiku-20240307":    200000,
+	"claude-3-sonnet-20240229":   200000,
+	"claude-3-opus-20240229":     200000,
+	"claude-3-5-haiku-20241022":  200000,
+	"claude-3-5-sonnet-20241022": 200000,
+	"claude-3-7-sonnet-20250219": 200000,
+	"claude-sonnet-4-20250514":   200000,
+	"claude-opus-4-20250514":     200000,
+	"claude-sonnet-4-5-20250929": 200000,
+	"claude-opus-4-5-20251124":   200000,
+	"claude-haiku-4-5-20251015":  200000,
+
+	// Google
+	"gemini-1.5-pro":                2097152,
+	"gemini-1.5-flash":              1048576,
+	"gemini-1.5-flash-8b":           1048576,
+	"gemini-2.0-flash":              1048576,
+	"gemini-2.0-flash-lite":         1048576,
+	"gemini-2.0-flash-exp":          1048576,
+	"gemini-2.0-pro-exp":            2097152,
+	"gemini-2.0-flash-thinking-exp": 1048576,
+	"gemini-2.5-pro":                1048576,
+	"gemini-2.5-flash":              1048576,
+	"gemini-3-pro":                  1048576,
+	"gemini-3-flash":                1048576,
+	"gemini-3-ultra":                1048576,
+
+	// Perplexity
+	"sonar":               127072,
+	"sonar-pro":           200000,
+	"sonar-reasoning":     127072,
+	"sonar-reasoning-pro": 127072,
+	"sonar-deep-research": 127072,
+
+	// Bedrock
+	"anthropic.claude-3-haiku-20240307-v1:0":    200000,
+	"anthropic.claude-3-sonnet-20240229-v1:0":   200000,
+	"anthropic.claude-3-opus-20240229-v1:0":     200000,
+	"anthropic.claude-3-5-haiku-20241022-v1:0":  200000,
+	"anthropic.claude-3-5-sonnet-20241022-v2:0": 200000,
+	"meta.llama3-1-8b-instruct-v1:0":            128000,
+	"meta.llama3-1-70b-instruct-v1:0":           128000,
+	"meta.llama3-1-405b-instruct-v1:0":          128000,
+	"meta.llama3-2-1b-instruct-v1:0":            128000,
+	"meta.llama3-2-3b-instruct-v1:0":            128000,
+	"mistral.mistral-7b-instruct-v0:2":          32768,
+	"mistral.mixtral-8x7b-instruct-v0:1":        32768,
+	"mistral.mistral-large-2402-v1:0":           32768,
+	"amazon.titan-text-express-v1":              8192,
+	"amazon.titan-text-lite-v1":                 4096,
+	"amazon.titan-text-premier-v1:0":            32768,
+}
+
+// contextWindow returns the number of tokens the model accepts. Ollama models
+// use their num_ctx option when it's set.
+func contextWindow(model Model) int {
+	if n := getOllamaOptions(model).numCtx; n > 0 {
+		return n
+	}
+	if n, ok := contextWindows[model.ModelName()]; ok {
+		return n
+	}
+	return fallbackContextWindow
+}
+
+// ============================================================================
+// OUTPUT TOKEN DEFAULTS
+// ============================================================================
+
+// fallbackMaxTokens is the default output token limit for models missing from
+// defaultOutputTokens
+const fallbackMaxTokens = 4096
+
+// defaultOutputTokens is the output token limit model constructors start with,
+// keyed by default model name. Limits are generous enough that high-capacity
+// models aren't truncated, especially reasoning models whose limit also covers
+// their hidden reasoning, without exceeding what smaller models accept.
+// Claude models are capped at 16384 since the Anthropic SDK refuses larger
+// non-streaming requests.
+var defaultOutputTokens = map[string]int{
+	// OpenAI
+	"gpt-4":                     2048, // 8K context shared with the prompt
+	"gpt-4-turbo":               4096,
+	"gpt-3.5-turbo":             4096,
+	"gpt-4o":                    16384,
+	"gpt-4o-mini":               16384,
+	"gpt-4o-audio-preview":      16384,
+	"gpt-4o-mini-audio-preview": 16384,
+	"gpt-4.1":                   32768,
+	"gpt-4.1-mini":              32768,
+	"gpt-4.1-nano":              32768,
+	"o1":                        32768,
+	"o1-mini":                   32768,
+	"o1-pro":                    32768,
+	"o1-preview":                32768,
+	"o3":                        32768,
+	"o3-mini":                   32768,
+	"o3-pro":                    32768,
+	"o4-mini":                   32768,
+	"gpt-5":                     32768,
+	"gpt-5-mini":                32768,
+	"gpt-5-nano":                32768,
+	"gpt-5-pro":                 32768,
+	"gpt-5-turbo":               32768,
+	"gpt-5.1":                   32768,
+	"gpt-5.1-mini":              32768,
+	"gpt-5.1-nano":              32768,
+	"gpt-5.1-codex":             32768,
+	"gpt-5.1-codex-mini":        32768,
+
+	// Anthropic
+	"claude-3-haiku-20240307":    4096,
+	"claude-3-sonnet-20240229":   4096,
+	"claude-3-opus-20240229":     4096,
+	"claude-3-5-haiku-20241022":  8192,
+	"claude-3-5-sonnet-20241022": 8192,
+	"claude-3-7-sonnet-20250219": 16384,
+	"claude-sonnet-4-20250514":   16384,
+	"claude-opus-4-20250514":     16384,
+	"claude-sonnet-4-5-20250929": 16384,
+	"claude-opus-4-5-20251124":   16384,
+	"claude-haiku-4-5-20251015":  16384,
+
+	// Google
+	"gemini-1.5-pro":                8192,
+	"gemini-1.5-flash":              8192,
+	"gemini-1.5-flash-8b":           8192,
+	"gemini-2.0-flash":              8192,
+	"gemini-2.0-flash-lite":         8192,
+	"gemini-2.0-flash-exp":          8192,
+	"gemini-2.0-pro-exp":            8192,
+	"gemini-2.0-flash-thinking-exp": 65536,
+	"gemini-2.5-pro":                65536,
+	"gemini-2.5-flash":              65536,
+	"gemini-3-pro":                  65536,
+	"gemini-3-flash":                65536,
+	"gemini-3-ultra":                65536,
+}
+
+// defaultMaxTokens returns the output token limit a new model starts with
+func defaultMaxTokens(modelName string) int {
+	if n, ok := defaultOutputTokens[modelName]; ok {
+		return n
+	}
+	return fallbackMaxTokens
+}
+
+// ============================================================================
+// TIMEOUT DEFAULTS
+// ============================================================================
+
+// fallbackTimeout is the request timeout for providers missing from
+// defaultProviderTimeouts
+const fallbackTimeout = 60 * time.Second
+
+// defaultProviderTimeouts is the request timeout of each provider when the
+// config doesn't set one and the model has no recommended timeout
+var defaultProviderTimeouts = map[ProviderType]time.Duration{
+	ProviderPerplexity: 2 * time.Minute, // Answers wait on web searches
+	ProviderOllama:     2 * time.Minute, // Local models may have to load first
+}
+
+// recommendedTimeouts is the request timeout of models that routinely take
+// longer than their provider's default, keyed by model name. Reasoning models
+// think before answering, and the pro and deep research variants can take
+// several minutes.
+var recommendedTimeouts = map[string]time.Duration{
+	// OpenAI
+	"o1":         3 * time.Minute,
+	"o1-mini":    3 * time.Minute,
+	"o1-preview": 3 * time.Minute,
+	"o1-pro":     10 * time.Minute,
+	"o3":         3 * time.Minute,
+	"o3-mini":    3 * time.Minute,
+	"o3-pro":     10 * time.Minute,
+	"o4-mini":    3 * time.Minute,
+	"gpt-5":      3 * time.Minute,
+	"gpt-5-pro":  10 * time.Minute,
+	"gpt-5.1":    3 * time.Minute,
+
+	// Google
+	"gemini-2.5-pro": 3 * time.Minute,
+	"gemini-3-pro":   3 * time.Minute,
+	"gemini-3-ultra": 5 * time.Minute,
+
+	// Perplexity
+	"sonar-reasoning":     3 * time.Minute,
+	"sonar-reasoning-pro": 5 * time.Minute,
+	"sonar-deep-research": 10 * time.Minute,
+}
+
+// defaultTimeout returns the request timeout of a provider when neither the
+// config nor the model sets one
+func defaultTimeout(provider ProviderType) time.Duration {
+	if d, ok := defaultProviderTimeouts[provider]; ok {
+		return d
+	}
+	return fallbackTimeout
+}
+
+// requestTimeout returns the timeout for a request to the named model. A
+// timeout set in the provider config always wins; otherwise the model's
+// recommended timeout is used, or else the provider's default. modelName may
+// be empty for requests not tied to a model.
+func requestTimeout(configured time.Duration, provider ProviderType, modelName string) time.Duration {
+	if configured > 0 {
+		return configured
+	}
+	if d, ok := recommendedTimeouts[modelName]; ok {
+		return d
+	}
+	return defaultTimeout(provider)
+}
@@ -0,0 +1,197 @@
+package lingo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ============================================================================
+// MODEL LOOKUP BY NAME
+// ============================================================================
+
+// ErrUnknownModel is returned by ModelFromString for names that don't match a
+// built-in model or alias
+var ErrUnknownModel = errors.New("unknown model")
+
+// builtinModels lists the constructors of the built-in text generation models.
+// Providers that can be left out of the build add theirs in init().
+var builtinModels = []func() Model{
+	// OpenAI
+	func() Model { return NewGPT4o() },
+	func() Model { return NewGPT4oMini() },
+	func() Model { return NewGPT4oAudio() },
+	func() Model { return NewGPT4oMiniAudio() },
+	func() Model { return NewGPT4Turbo() },
+	func() Model { return NewGPT4() },
+	func() Model { return NewGPT41() },
+	func() Model { return NewGPT41Mini() },
+	func() Model { return NewGPT41Nano() },
+	func() Model { return NewGPT35Turbo() },
+	func() Model { return NewO1() },
+	func() Model { return NewO1Mini() },
+	func() Model { return NewO1Pro() },
+	func() Model { return NewO1Preview() },
+	func() Model { return NewO3() },
+	func() Model { return NewO3Mini() },
+	func() Model { return NewO3Pro() },
+	func() Model { return NewO4Mini() },
+	func() Model { return NewGPT5() },
+	func() Model { return NewGPT5Mini() },
+	func() Model { return NewGPT5Nano() },
+	func() Model { return NewGPT5Pro() },
+	func() Model { return NewGPT5Turbo() },
+	func() Model { return NewGPT51() },
+	func() Model { return NewGPT51Mini() },
+	func() Model { return NewGPT51Nano() },
+	func() Model { return NewGPT51Codex() },
+	func() Model { return NewGPT51CodexMini() },
+
+	// Anthropic
+	func() Model { return NewClaude35Sonnet() },
+	func() Model { return NewClaude35Haiku() },
+	func() Model { return NewClaude3Opus() },
+	func() Model { return NewClaude3Haiku() },
+	func() Model { return NewClaude3Sonnet() },
+	func() Model { return NewClaude37Sonnet() },
+	func() Model { return NewClaudeSonnet4() },
+	func() Model { return NewClaudeOpus4() },
+	func() Model { return NewClaudeSonnet45() },
+	func() Model { return NewClaudeOpus45() },
+	func() Model { return NewClaudeHaiku45() },
+
+	// Ollama
+	func() Model { return NewLlama3() },
+	func() Model { return NewLlama31() },
+	func() Model { return NewLlama32() },
+	func() Model { return NewMistral() },
+	func() Model { return NewMixtral() },
+	func() Model { return NewCodeLlama() },
+	func() Model { return NewPhi3() },
+	func() Model { return NewGemma2() },
+	func() Model { return NewQwen2() },
+	func() Model { return NewDeepSeekCoder() },
+
+	// Perplexity
+	func() Model { return NewSonar() },
+	func() Model { return NewSonarPro() },
+	func() Model { return NewSonarReasoning() },
+	func() Model { return NewSonarReasoningPro() },
+	func() Model { return NewSonarDeepResearch() },
+}
+
+// modelAliases maps common alternative names to canonical model names. Keys
+// are matched after normalization, so casing and separators don't matter and
+// only names that differ by more than that need an entry.
+var modelAliases = map[string]string{
+	// Anthropic names without a date, and the family-first naming used
+	// before Claude 4
+	"claude-3-haiku":           "claude-3-haiku-20240307",
+	"claude-3-sonnet":          "claude-3-sonnet-20240229",
+	"claude-3-opus":            "claude-3-opus-20240229",
+	"claude-3-opus-latest":     "claude-3-opus-20240229",
+	"claude-3-5-haiku":         "claude-3-5-haiku-20241022",
+	"claude-3-5-haiku-latest":  "claude-3-5-haiku-20241022",
+	"claude-3-5-sonnet":        "claude-3-5-sonnet-20241022",
+	"claude-3-5-sonnet-latest": "claude-3-5-sonnet-20241022",
+	"claude-3-7-sonnet":        "claude-3-7-sonnet-20250219",
+	"claude-3-7-sonnet-latest": "claude-3-7-sonnet-20250219",
+	"claude-sonnet-4":          "claude-sonnet-4-20250514",
+	"claude-sonnet-4-0":        "claude-sonnet-4-20250514",
+	"claude-4-sonnet":          "claude-sonnet-4-20250514",
+	"claude-opus-4":            "claude-opus-4-20250514",
+	"claude-opus-4-0":          "claude-opus-4-20250514",
+	"claude-4-opus":            "claude-opus-4-20250514",
+	"claude-sonnet-4-5":        "claude-sonnet-4-5-20250929",
+	"claude-4-5-sonnet":        "claude-sonnet-4-5-20250929",
+	"claude-opus-4-5":          "claude-opus-4-5-20251124",
+	"claude-4-5-opus":          "claude-opus-4-5-20251124",
+	"claude-haiku-4-5":         "claude-haiku-4-5-20251015",
+	"claude-4-5-haiku":         "claude-haiku-4-5-20251015",
+
+	// OpenAI
+	"gpt-4o-latest":       "gpt-4o",
+	"chatgpt-4o-latest":   "gpt-4o",
+	"gpt-4-turbo-preview": "gpt-4-turbo",
+	"gpt-3.5":             "gpt-3.5-turbo",
+
+	// Google
+	"gemini-pro":   "gemini-1.5-pro",
+	"gemini-flash": "gemini-2.5-flash",
+}
+
+var (
+	modelIndexOnce sync.Once
+	modelIndex     map[string]func() Model // Keyed by normalized name or alias
+)
+
+// normalizeModelKey folds the casing and separators of a model name, so that
+// "GPT-4o", "gpt4o" and "gpt_4o" all match
+func normalizeModelKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '.', '_', ' ':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(name)))
+}
+
+// lookupModel returns the constructor for a model name or alias
+func lookupModel(name string) (func() Model, bool) {
+	modelIndexOnce.Do(func() {
+		modelIndex = make(map[string]func() Model, len(builtinModels)+len(modelAliases))
+		canonical := make(map[string]func() Model, len(builtinModels))
+		for _, newModel := range builtinModels {
+			name := newModel().ModelName()
+			canonical[name] = newModel
+			modelIndex[normalizeModelKey(name)] = newModel
+		}
+		// Aliases of models left out by build tags don't resolve
+		for alias, name := range modelAliases {
+			if newModel, ok := canonical[name]; ok {
+				modelIndex[normalizeModelKey(alias)] = newModel
+			}
+		}
+	})
+
+	newModel, ok := modelIndex[normalizeModelKey(name)]
+	return newModel, ok
+}
+
+// BuiltinModels returns a new instance of every built-in text generation
+// model included in the build, with its default options
+func BuiltinModels() []Model {
+	models := make([]Model, len(builtinModels))
+	for i, newModel := range builtinModels {
+		models[i] = newModel()
+	}
+	return models
+}
+
+// NormalizeModelName returns the canonical API name of a built-in model given
+// its name in any casing, with or without separators, or a common alias:
+// "GPT-4o" and "gpt4o" both become "gpt-4o", and "claude-3.5-sonnet" becomes
+// "claude-3-5-sonnet-20241022". Names that don't match a built-in model are
+// returned trimmed but otherwise unchanged.
+func NormalizeModelName(name string) string {
+	newModel, ok := lookupModel(name)
+	if !ok {
+		return strings.TrimSpace(name)
+	}
+	return newModel().ModelName()
+}
+
+// ModelFromString returns a new built-in model with its default options, given
+// its name or an alias as accepted by NormalizeModelName. This is meant for
+// model names from user input or configuration. Names that don't match return
+// an error wrapping ErrUnknownModel; use NewOllamaModel or NewBedrockModel for
+// models without a built-in type.
+func ModelFromString(name string) (Model, error) {
+	newModel, ok := lookupModel(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownModel, name)
+	}
+	return newModel(), nil
+}
@@ -0,0 +1,63 @@
+package lingo
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeModelKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"gpt-4o", "gpt4o"},
+		{"GPT-4o", "gpt4o"},
+		{" gpt_4o ", "gpt4o"},
+		{"claude-3.5-sonnet", "claude35sonnet"},
+		{"Claude 3.5 Sonnet", "claude35sonnet"},
+	}
+	for _, tt := range tests {
+		if got := normalizeModelKey(tt.name); got != tt.want {
+			t.Errorf("normalizeModelKey(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestModelFromString(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"gpt-4o", "gpt-4o"},
+		{"GPT-4o", "gpt-4o"},
+		{"gpt4o", "gpt-4o"},
+		{" gpt_4o ", "gpt-4o"},
+		{"chatgpt-4o-latest", "gpt-4o"},
+		{"claude-3.5-sonnet", "claude-3-5-sonnet-20241022"},
+		{"Claude-3-5-Sonnet-Latest", "claude-3-5-sonnet-20241022"},
+		{"claude-3-5-sonnet-20241022", "claude-3-5-sonnet-20241022"},
+		{"llama3", "llama3"},
+	}
+	for _, tt := range tests {
+		model, err := ModelFromString(tt.name)
+		if err != nil {
+			t.Errorf("ModelFromString(%q): %v", tt.name, err)
+			continue
+		}
+		if got := model.ModelName(); got != tt.want {
+			t.Errorf("ModelFromString(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+		if got := NormalizeModelName(tt.name); got != tt.want {
+			t.Errorf("NormalizeModelName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestModelFromStringUnknown(t *testing.T) {
+	if _, err := ModelFromString("gpt-99"); !errors.Is(err, ErrUnknownModel) {
+		t.Errorf("ModelFromString error = %v, want ErrUnknownModel", err)
+	}
+	if got := NormalizeModelName(" my-finetune "); got != "my-finetune" {
+		t.Errorf("NormalizeModelName = %q, want the trimmed name", got)
+	}
+}
@@ -1,12 +1,15 @@
 package lingo
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -28,10 +31,14 @@ func init() {
 type OllamaConfig struct {
 	// BaseURL is the Ollama server URL (default: http://localhost:11434)
 	BaseURL string
-	// Timeout is the request timeout (default: 60s)
+	// Timeout is the request timeout (default: the model's recommended timeout,
+	// reported by Describe)
 	Timeout time.Duration
 	// RateLimiter is the optional rate limit configuration
 	RateLimiter *RateLimitConfig
+	// KeepAlive is how long models stay loaded after a request (default: server
+	// setting). A negative value keeps them loaded indefinitely.
+	KeepAlive time.Duration
 }
 
 // Implement ProviderConfig interface
@@ -56,8 +63,17 @@ type ollamaOptions struct {
 	numCtx        int     // Context window size
 	repeatPenalty float64 // Repetition penalty
 	seed          int     // Random seed for reproducibility
+	format        any     // "json" or a JSON schema for structured output
+	rawGenerate   bool    // Use /api/generate with raw prompts instead of /api/chat
+
+	temperatureSet bool // Distinguishes an explicit temperature of 0 from unset
 }
 
+func (o *ollamaOptions) generationOptions() ollamaOptions     { return *o }
+func (o *ollamaOptions) setTemperature(t float64)             { o.temperature = t; o.temperatureSet = true }
+func (o *ollamaOptions) explicitTemperature() (float64, bool) { return o.temperature, o.temperatureSet }
+func (o *ollamaOptions) modelSeed() (int, bool)               { return o.seed, o.seed > 0 }
+
 // ============================================================================
 // OLLAMA MODELS
 // ============================================================================
@@ -71,13 +87,20 @@ func (m *OllamaModel) Provider() ProviderType { return ProviderOllama }
 func (m *OllamaModel) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *OllamaModel) WithMaxTokens(n int) *OllamaModel         { m.maxTokens = n; return m }
-func (m *OllamaModel) WithTemperature(t float64) *OllamaModel   { m.temperature = t; return m }
+func (m *OllamaModel) WithTemperature(t float64) *OllamaModel   { m.setTemperature(t); return m }
 func (m *OllamaModel) WithTopP(p float64) *OllamaModel          { m.topP = p; return m }
 func (m *OllamaModel) WithTopK(k int) *OllamaModel              { m.topK = k; return m }
 func (m *OllamaModel) WithSystemPrompt(s string) *OllamaModel   { m.systemPrompt = s; return m }
 func (m *OllamaModel) WithNumCtx(n int) *OllamaModel            { m.numCtx = n; return m }
 func (m *OllamaModel) WithRepeatPenalty(p float64) *OllamaModel { m.repeatPenalty = p; return m }
 func (m *OllamaModel) WithSeed(s int) *OllamaModel              { m.seed = s; return m }
+func (m *OllamaModel) WithFormat(f any) *OllamaModel            { m.format = f; return m }
+
+// WithRawGenerate sends the prompt verbatim to /api/generate with raw: true,
+// bypassing the model's chat template, for base models and custom prompt
+// formats. Raw prompts have no system prompt or history, so generating with a
+// system prompt or a multi-turn chat returns an error.
+func (m *OllamaModel) WithRawGenerate(raw bool) *OllamaModel { m.rawGenerate = raw; return m }
 
 // NewOllamaModel creates a new Ollama model with the specified model name
 func NewOllamaModel(modelName string) *OllamaModel {
@@ -96,13 +119,14 @@ func (m *Llama3) Provider() ProviderType { return ProviderOllama }
 func (m *Llama3) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Llama3) WithMaxTokens(n int) *Llama3         { m.maxTokens = n; return m }
-func (m *Llama3) WithTemperature(t float64) *Llama3   { m.temperature = t; return m }
+func (m *Llama3) WithTemperature(t float64) *Llama3   { m.setTemperature(t); return m }
 func (m *Llama3) WithTopP(p float64) *Llama3          { m.topP = p; return m }
 func (m *Llama3) WithTopK(k int) *Llama3              { m.topK = k; return m }
 func (m *Llama3) WithSystemPrompt(s string) *Llama3   { m.systemPrompt = s; return m }
 func (m *Llama3) WithNumCtx(n int) *Llama3            { m.numCtx = n; return m }
 func (m *Llama3) WithRepeatPenalty(p float64) *Llama3 { m.repeatPenalty = p; return m }
 func (m *Llama3) WithSeed(s int) *Llama3              { m.seed = s; return m }
+func (m *Llama3) WithFormat(f any) *Llama3            { m.format = f; return m }
 
 // NewLlama3 creates a new Llama 3 model with default options
 func NewLlama3() *Llama3 {
@@ -117,13 +141,14 @@ func (m *Llama31) Provider() ProviderType { return ProviderOllama }
 func (m *Llama31) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Llama31) WithMaxTokens(n int) *Llama31         { m.maxTokens = n; return m }
-func (m *Llama31) WithTemperature(t float64) *Llama31   { m.temperature = t; return m }
+func (m *Llama31) WithTemperature(t float64) *Llama31   { m.setTemperature(t); return m }
 func (m *Llama31) WithTopP(p float64) *Llama31          { m.topP = p; return m }
 func (m *Llama31) WithTopK(k int) *Llama31              { m.topK = k; return m }
 func (m *Llama31) WithSystemPrompt(s string) *Llama31   { m.systemPrompt = s; return m }
 func (m *Llama31) WithNumCtx(n int) *Llama31            { m.numCtx = n; return m }
 func (m *Llama31) WithRepeatPenalty(p float64) *Llama31 { m.repeatPenalty = p; return m }
 func (m *Llama31) WithSeed(s int) *Llama31              { m.seed = s; return m }
+func (m *Llama31) WithFormat(f any) *Llama31            { m.format = f; return m }
 
 // NewLlama31 creates a new Llama 3.1 model with default options
 func NewLlama31() *Llama31 {
@@ -138,13 +163,14 @@ func (m *Llama32) Provider() ProviderType { return ProviderOllama }
 func (m *Llama32) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Llama32) WithMaxTokens(n int) *Llama32         { m.maxTokens = n; return m }
-func (m *Llama32) WithTemperature(t float64) *Llama32   { m.temperature = t; return m }
+func (m *Llama32) WithTemperature(t float64) *Llama32   { m.setTemperature(t); return m }
 func (m *Llama32) WithTopP(p float64) *Llama32          { m.topP = p; return m }
 func (m *Llama32) WithTopK(k int) *Llama32              { m.topK = k; return m }
 func (m *Llama32) WithSystemPrompt(s string) *Llama32   { m.systemPrompt = s; return m }
 func (m *Llama32) WithNumCtx(n int) *Llama32            { m.numCtx = n; return m }
 func (m *Llama32) WithRepeatPenalty(p float64) *Llama32 { m.repeatPenalty = p; return m }
 func (m *Llama32) WithSeed(s int) *Llama32              { m.seed = s; return m }
+func (m *Llama32) WithFormat(f any) *Llama32            { m.format = f; return m }
 
 // NewLlama32 creates a new Llama 3.2 model with default options
 func NewLlama32() *Llama32 {
@@ -159,13 +185,14 @@ func (m *Mistral) Provider() ProviderType { return ProviderOllama }
 func (m *Mistral) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Mistral) WithMaxTokens(n int) *Mistral         { m.maxTokens = n; return m }
-func (m *Mistral) WithTemperature(t float64) *Mistral   { m.temperature = t; return m }
+func (m *Mistral) WithTemperature(t float64) *Mistral   { m.setTemperature(t); return m }
 func (m *Mistral) WithTopP(p float64) *Mistral          { m.topP = p; return m }
 func (m *Mistral) WithTopK(k int) *Mistral              { m.topK = k; return m }
 func (m *Mistral) WithSystemPrompt(s string) *Mistral   { m.systemPrompt = s; return m }
 func (m *Mistral) WithNumCtx(n int) *Mistral            { m.numCtx = n; return m }
 func (m *Mistral) WithRepeatPenalty(p float64) *Mistral { m.repeatPenalty = p; return m }
 func (m *Mistral) WithSeed(s int) *Mistral              { m.seed = s; return m }
+func (m *Mistral) WithFormat(f any) *Mistral            { m.format = f; return m }
 
 // NewMistral creates a new Mistral model with default options
 func NewMistral() *Mistral {
@@ -180,13 +207,14 @@ func (m *Mixtral) Provider() ProviderType { return ProviderOllama }
 func (m *Mixtral) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Mixtral) WithMaxTokens(n int) *Mixtral         { m.maxTokens = n; return m }
-func (m *Mixtral) WithTemperature(t float64) *Mixtral   { m.temperature = t; return m }
+func (m *Mixtral) WithTemperature(t float64) *Mixtral   { m.setTemperature(t); return m }
 func (m *Mixtral) WithTopP(p float64) *Mixtral          { m.topP = p; return m }
 func (m *Mixtral) WithTopK(k int) *Mixtral              { m.topK = k; return m }
 func (m *Mixtral) WithSystemPrompt(s string) *Mixtral   { m.systemPrompt = s; return m }
 func (m *Mixtral) WithNumCtx(n int) *Mixtral            { m.numCtx = n; return m }
 func (m *Mixtral) WithRepeatPenalty(p float64) *Mixtral { m.repeatPenalty = p; return m }
 func (m *Mixtral) WithSeed(s int) *Mixtral              { m.seed = s; return m }
+func (m *Mixtral) WithFormat(f any) *Mixtral            { m.format = f; return m }
 
 // NewMixtral creates a new Mixtral model with default options
 func NewMixtral() *Mixtral {
@@ -201,13 +229,14 @@ func (m *CodeLlama) Provider() ProviderType { return ProviderOllama }
 func (m *CodeLlama) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *CodeLlama) WithMaxTokens(n int) *CodeLlama         { m.maxTokens = n; return m }
-func (m *CodeLlama) WithTemperature(t float64) *CodeLlama   { m.temperature = t; return m }
+func (m *CodeLlama) WithTemperature(t float64) *CodeLlama   { m.setTemperature(t); return m }
 func (m *CodeLlama) WithTopP(p float64) *CodeLlama          { m.topP = p; return m }
 func (m *CodeLlama) WithTopK(k int) *CodeLlama              { m.topK = k; return m }
 func (m *CodeLlama) WithSystemPrompt(s string) *CodeLlama   { m.systemPrompt = s; return m }
 func (m *CodeLlama) WithNumCtx(n int) *CodeLlama            { m.numCtx = n; return m }
 func (m *CodeLlama) WithRepeatPenalty(p float64) *CodeLlama { m.repeatPenalty = p; return m }
 func (m *CodeLlama) WithSeed(s int) *CodeLlama              { m.seed = s; return m }
+func (m *CodeLlama) WithFormat(f any) *CodeLlama            { m.format = f; return m }
 
 // NewCodeLlama creates a new Code Llama model with default options
 func NewCodeLlama() *CodeLlama {
@@ -222,13 +251,14 @@ func (m *Phi3) Provider() ProviderType { return ProviderOllama }
 func (m *Phi3) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Phi3) WithMaxTokens(n int) *Phi3         { m.maxTokens = n; return m }
-func (m *Phi3) WithTemperature(t float64) *Phi3   { m.temperature = t; return m }
+func (m *Phi3) WithTemperature(t float64) *Phi3   { m.setTemperature(t); return m }
 func (m *Phi3) WithTopP(p float64) *Phi3          { m.topP = p; return m }
 func (m *Phi3) WithTopK(k int) *Phi3              { m.topK = k; return m }
 func (m *Phi3) WithSystemPrompt(s string) *Phi3   { m.systemPrompt = s; return m }
 func (m *Phi3) WithNumCtx(n int) *Phi3            { m.numCtx = n; return m }
 func (m *Phi3) WithRepeatPenalty(p float64) *Phi3 { m.repeatPenalty = p; return m }
 func (m *Phi3) WithSeed(s int) *Phi3              { m.seed = s; return m }
+func (m *Phi3) WithFormat(f any) *Phi3            { m.format = f; return m }
 
 // NewPhi3 creates a new Phi-3 model with default options
 func NewPhi3() *Phi3 {
@@ -243,13 +273,14 @@ func (m *Gemma2) Provider() ProviderType { return ProviderOllama }
 func (m *Gemma2) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Gemma2) WithMaxTokens(n int) *Gemma2         { m.maxTokens = n; return m }
-func (m *Gemma2) WithTemperature(t float64) *Gemma2   { m.temperature = t; return m }
+func (m *Gemma2) WithTemperature(t float64) *Gemma2   { m.setTemperature(t); return m }
 func (m *Gemma2) WithTopP(p float64) *Gemma2          { m.topP = p; return m }
 func (m *Gemma2) WithTopK(k int) *Gemma2              { m.topK = k; return m }
 func (m *Gemma2) WithSystemPrompt(s string) *Gemma2   { m.systemPrompt = s; return m }
 func (m *Gemma2) WithNumCtx(n int) *Gemma2            { m.numCtx = n; return m }
 func (m *Gemma2) WithRepeatPenalty(p float64) *Gemma2 { m.repeatPenalty = p; return m }
 func (m *Gemma2) WithSeed(s int) *Gemma2              { m.seed = s; return m }
+func (m *Gemma2) WithFormat(f any) *Gemma2            { m.format = f; return m }
 
 // NewGemma2 creates a new Gemma 2 model with default options
 func NewGemma2() *Gemma2 {
@@ -264,13 +295,14 @@ func (m *Qwen2) Provider() ProviderType { return ProviderOllama }
 func (m *Qwen2) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Qwen2) WithMaxTokens(n int) *Qwen2         { m.maxTokens = n; return m }
-func (m *Qwen2) WithTemperature(t float64) *Qwen2   { m.temperature = t; return m }
+func (m *Qwen2) WithTemperature(t float64) *Qwen2   { m.setTemperature(t); return m }
 func (m *Qwen2) WithTopP(p float64) *Qwen2          { m.topP = p; return m }
 func (m *Qwen2) WithTopK(k int) *Qwen2              { m.topK = k; return m }
 func (m *Qwen2) WithSystemPrompt(s string) *Qwen2   { m.systemPrompt = s; return m }
 func (m *Qwen2) WithNumCtx(n int) *Qwen2            { m.numCtx = n; return m }
 func (m *Qwen2) WithRepeatPenalty(p float64) *Qwen2 { m.repeatPenalty = p; return m }
 func (m *Qwen2) WithSeed(s int) *Qwen2              { m.seed = s; return m }
+func (m *Qwen2) WithFormat(f any) *Qwen2            { m.format = f; return m }
 
 // NewQwen2 creates a new Qwen 2 model with default options
 func NewQwen2() *Qwen2 {
@@ -285,13 +317,14 @@ func (m *DeepSeekCoder) Provider() ProviderType { return ProviderOllama }
 func (m *DeepSeekCoder) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *DeepSeekCoder) WithMaxTokens(n int) *DeepSeekCoder         { m.maxTokens = n; return m }
-func (m *DeepSeekCoder) WithTemperature(t float64) *DeepSeekCoder   { m.temperature = t; return m }
+func (m *DeepSeekCoder) WithTemperature(t float64) *DeepSeekCoder   { m.setTemperature(t); return m }
 func (m *DeepSeekCoder) WithTopP(p float64) *DeepSeekCoder          { m.topP = p; return m }
 func (m *DeepSeekCoder) WithTopK(k int) *DeepSeekCoder              { m.topK = k; return m }
 func (m *DeepSeekCoder) WithSystemPrompt(s string) *DeepSeekCoder   { m.systemPrompt = s; return m }
 func (m *DeepSeekCoder) WithNumCtx(n int) *DeepSeekCoder            { m.numCtx = n; return m }
 func (m *DeepSeekCoder) WithRepeatPenalty(p float64) *DeepSeekCoder { m.repeatPenalty = p; return m }
 func (m *DeepSeekCoder) WithSeed(s int) *DeepSeekCoder              { m.seed = s; return m }
+func (m *DeepSeekCoder) WithFormat(f any) *DeepSeekCoder            { m.format = f; return m }
 
 // NewDeepSeekCoder creates a new DeepSeek Coder model with default options
 func NewDeepSeekCoder() *DeepSeekCoder {
@@ -306,17 +339,30 @@ func NewDeepSeekCoder() *DeepSeekCoder {
 type ollamaClient struct {
 	httpClient  *http.Client
 	baseURL     string
-	timeout     time.Duration
+	timeout     time.Duration // Zero uses the model's recommended timeout
 	logger      Logger
 	rateLimiter *rateLimiter
+	keepAlive   string
 }
 
 // Ollama API request/response types
 type ollamaChatRequest struct {
-	Model    string              `json:"model"`
-	Messages []ollamaChatMessage `json:"messages"`
-	Stream   bool                `json:"stream"`
-	Options  *ollamaModelOptions `json:"options,omitempty"`
+	Model     string              `json:"model"`
+	Messages  []ollamaChatMessage `json:"messages"`
+	Stream    bool                `json:"stream"`
+	Format    any                 `json:"format,omitempty"`
+	Options   *ollamaModelOptions `json:"options,omitempty"`
+	KeepAlive string              `json:"keep_alive,omitempty"`
+}
+
+type ollamaGenerateRequest struct {
+	Model     string              `json:"model"`
+	Prompt    string              `json:"prompt"`
+	Raw       bool                `json:"raw"`
+	Stream    bool                `json:"stream"`
+	Format    any                 `json:"format,omitempty"`
+	Options   *ollamaModelOptions `json:"options,omitempty"`
+	KeepAlive string              `json:"keep_alive,omitempty"`
 }
 
 type ollamaChatMessage struct {
@@ -325,19 +371,21 @@ type ollamaChatMessage struct {
 }
 
 type ollamaModelOptions struct {
-	NumPredict    int     `json:"num_predict,omitempty"`
-	Temperature   float64 `json:"temperature,omitempty"`
-	TopP          float64 `json:"top_p,omitempty"`
-	TopK          int     `json:"top_k,omitempty"`
-	NumCtx        int     `json:"num_ctx,omitempty"`
-	RepeatPenalty float64 `json:"repeat_penalty,omitempty"`
-	Seed          int     `json:"seed,omitempty"`
+	NumPredict    int      `json:"num_predict,omitempty"`
+	Temperature   *float64 `json:"temperature,omitempty"`
+	TopP          float64  `json:"top_p,omitempty"`
+	TopK          int      `json:"top_k,omitempty"`
+	NumCtx        int      `json:"num_ctx,omitempty"`
+	RepeatPenalty float64  `json:"repeat_penalty,omitempty"`
+	Seed          int      `json:"seed,omitempty"`
+	Stop          []string `json:"stop,omitempty"`
 }
 
 type ollamaChatResponse struct {
 	Model              string            `json:"model"`
 	CreatedAt          string            `json:"created_at"`
 	Message            ollamaChatMessage `json:"message"`
+	Response           string            `json:"response"` // Set by /api/generate instead of Message
 	Done               bool              `json:"done"`
 	DoneReason         string            `json:"done_reason"`
 	TotalDuration      int64             `json:"total_duration"`
@@ -346,6 +394,25 @@ type ollamaChatResponse struct {
 	PromptEvalDuration int64             `json:"prompt_eval_duration"`
 	EvalCount          int               `json:"eval_count"`
 	EvalDuration       int64             `json:"eval_duration"`
+	Error              string            `json:"error,omitempty"` // Set on mid-stream failures
+}
+
+// text returns the generated text from either the chat or generate endpoint
+func (r *ollamaChatResponse) text() string {
+	if r.Message.Content != "" {
+		return r.Message.Content
+	}
+	return r.Response
+}
+
+// finishReason returns the done reason, defaulting to "stop" for completed
+// responses where Ollama leaves it empty. "length" already matches the other
+// providers.
+func (r *ollamaChatResponse) finishReason() string {
+	if r.DoneReason == "" && r.Done {
+		return "stop"
+	}
+	return r.DoneReason
 }
 
 // newOllamaClient creates a new Ollama client
@@ -355,84 +422,255 @@ func newOllamaClient(config *OllamaConfig, logger Logger) (*ollamaClient, error)
 		baseURL = "http://localhost:11434"
 	}
 
-	timeout := config.Timeout
-	if timeout == 0 {
-		timeout = defaultTimeout()
+	var keepAlive string
+	if config.KeepAlive != 0 {
+		keepAlive = config.KeepAlive.String()
 	}
 
 	return &ollamaClient{
 		httpClient: &http.Client{
-			Timeout: timeout,
+			Timeout: config.Timeout,
 		},
 		baseURL:     baseURL,
-		timeout:     timeout,
+		timeout:     config.Timeout,
 		logger:      logger,
-		rateLimiter: newRateLimiter(config.RateLimiter, logger),
+		rateLimiter: newRateLimiter(ProviderOllama, config.RateLimiter, logger),
+		keepAlive:   keepAlive,
 	}, nil
 }
 
+// ollamaOptionsModel is implemented by Ollama models through their embedded
+// ollamaOptions
+type ollamaOptionsModel interface {
+	generationOptions() ollamaOptions
+}
+
 // getOllamaOptions extracts options from an Ollama model
 func getOllamaOptions(model Model) ollamaOptions {
-	switch m := model.(type) {
-	case *OllamaModel:
-		return m.ollamaOptions
-	case *Llama3:
-		return m.ollamaOptions
-	case *Llama31:
-		return m.ollamaOptions
-	case *Llama32:
-		return m.ollamaOptions
-	case *Mistral:
-		return m.ollamaOptions
-	case *Mixtral:
-		return m.ollamaOptions
-	case *CodeLlama:
-		return m.ollamaOptions
-	case *Phi3:
-		return m.ollamaOptions
-	case *Gemma2:
-		return m.ollamaOptions
-	case *Qwen2:
-		return m.ollamaOptions
-	case *DeepSeekCoder:
-		return m.ollamaOptions
+	if m, ok := model.(ollamaOptionsModel); ok {
+		return m.generationOptions()
+	}
+	return ollamaOptions{}
+}
+
+// ollamaFormat normalizes a format option for the request body. Schemas given
+// as raw JSON bytes or strings other than "json" are sent as JSON objects.
+func ollamaFormat(format any) any {
+	switch f := format.(type) {
+	case []byte:
+		return json.RawMessage(f)
+	case string:
+		if f != "json" && json.Valid([]byte(f)) {
+			return json.RawMessage(f)
+		}
+		return f
 	default:
-		return ollamaOptions{}
+		return f
 	}
 }
 
 // Generate generates text using Ollama's API
-func (c *ollamaClient) Generate(ctx context.Context, model Model, prompt string) (*GenerationResponse, error) {
+func (c *ollamaClient) Generate(ctx context.Context, model Model, prompt string, genOpts ...GenerateOption) (*GenerationResponse, error) {
+	// Verify model is for Ollama
+	if model.Provider() != ProviderOllama {
+		return nil, fmt.Errorf("model %s is not an Ollama model", model.ModelName())
+	}
+
+	return c.generate(ctx, model, []ChatMessage{{Role: RoleUser, Content: prompt}}, newGenerateOptions(genOpts))
+}
+
+// GenerateChat continues a multi-turn conversation through the chat endpoint
+func (c *ollamaClient) GenerateChat(ctx context.Context, model Model, messages []ChatMessage, genOpts ...GenerateOption) (*GenerationResponse, error) {
 	// Verify model is for Ollama
 	if model.Provider() != ProviderOllama {
 		return nil, fmt.Errorf("model %s is not an Ollama model", model.ModelName())
 	}
+	if err := validateChat(messages); err != nil {
+		return nil, err
+	}
+
+	return c.generate(ctx, model, messages, newGenerateOptions(genOpts))
+}
+
+// generate sends a conversation to Ollama and waits for the whole reply
+func (c *ollamaClient) generate(ctx context.Context, model Model, turns []ChatMessage, reqOpts *generateOptions) (*GenerationResponse, error) {
+	logger := reqOpts.logger(c.logger)
+	prompt := turns[len(turns)-1].Content
 
 	// Set timeout
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(c.timeout, ProviderOllama, model.ModelName()))
 	defer cancel()
 
-	// Get model options
-	opts := getOllamaOptions(model)
+	endpoint, jsonBody, err := c.buildRequest(model, turns, false, reqOpts)
+	if err != nil {
+		return nil, err
+	}
+	if jsonBody, err = reqOpts.applyExtraBody(jsonBody); err != nil {
+		return nil, err
+	}
+	reqOpts.inspectRequest(ProviderOllama, json.RawMessage(jsonBody))
+	if reqOpts.dryRun {
+		return dryRunResponse(model, json.RawMessage(jsonBody))
+	}
 
-	// Build messages
-	messages := []ollamaChatMessage{}
-	if model.SystemPrompt() != "" {
-		messages = append(messages, ollamaChatMessage{
-			Role:    "system",
-			Content: model.SystemPrompt(),
-		})
-	}
-	messages = append(messages, ollamaChatMessage{
-		Role:    "user",
-		Content: prompt,
-	})
+	resp, err := c.doRequest(ctx, model, prompt, endpoint, jsonBody, reqOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Parse response
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var ollamaResp ollamaChatResponse
+	if err := json.Unmarshal(respBody, &ollamaResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	response := c.buildResponse(&ollamaResp, ollamaResp.text(), reqOpts.minimalResponse)
+
+	if reqOpts.includeRaw {
+		response.RawJSON = respBody
+	}
+
+	logger.Debug().
+		Str("model", ollamaResp.Model).
+		Int("prompt_tokens", ollamaResp.PromptEvalCount).
+		Int("completion_tokens", ollamaResp.EvalCount).
+		Int("total_tokens", ollamaResp.PromptEvalCount+ollamaResp.EvalCount).
+		Msg("Ollama generation completed")
+
+	return response, nil
+}
+
+// GenerateStream generates text using Ollama's API, calling handler with each
+// delta as it arrives. Ollama streams newline-delimited JSON objects; the final
+// object (done: true) carries the usage counts.
+func (c *ollamaClient) GenerateStream(ctx context.Context, model Model, prompt string, handler StreamHandler, genOpts ...GenerateOption) (*GenerationResponse, error) {
+	// Verify model is for Ollama
+	if model.Provider() != ProviderOllama {
+		return nil, fmt.Errorf("model %s is not an Ollama model", model.ModelName())
+	}
+
+	return c.generateStream(ctx, model, []ChatMessage{{Role: RoleUser, Content: prompt}}, handler, newGenerateOptions(genOpts))
+}
+
+// GenerateChatStream continues a multi-turn conversation, calling handler
+// with each delta of the assistant's reply as it arrives
+func (c *ollamaClient) GenerateChatStream(ctx context.Context, model Model, messages []ChatMessage, handler StreamHandler, genOpts ...GenerateOption) (*GenerationResponse, error) {
+	// Verify model is for Ollama
+	if model.Provider() != ProviderOllama {
+		return nil, fmt.Errorf("model %s is not an Ollama model", model.ModelName())
+	}
+	if err := validateChat(messages); err != nil {
+		return nil, err
+	}
+
+	return c.generateStream(ctx, model, messages, handler, newGenerateOptions(genOpts))
+}
+
+// generateStream sends a conversation to Ollama and decodes the streamed reply
+func (c *ollamaClient) generateStream(ctx context.Context, model Model, turns []ChatMessage, handler StreamHandler, reqOpts *generateOptions) (*GenerationResponse, error) {
+	logger := reqOpts.logger(c.logger)
+	prompt := turns[len(turns)-1].Content
+
+	// Set timeout
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(c.timeout, ProviderOllama, model.ModelName()))
+	defer cancel()
+
+	endpoint, jsonBody, err := c.buildRequest(model, turns, true, reqOpts)
+	if err != nil {
+		return nil, err
+	}
+	if jsonBody, err = reqOpts.applyExtraBody(jsonBody); err != nil {
+		return nil, err
+	}
+	reqOpts.inspectRequest(ProviderOllama, json.RawMessage(jsonBody))
+	if reqOpts.dryRun {
+		return dryRunResponse(model, json.RawMessage(jsonBody))
+	}
+
+	resp, err := c.doRequest(ctx, model, prompt, endpoint, jsonBody, reqOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode NDJSON line by line until the final object
+	var text strings.Builder
+	var final ollamaChatResponse
+	var lastLine []byte
+	reader := bufio.NewReader(resp.Body)
+	for {
+		// Lines may already be buffered, so stop delivering chunks as soon as
+		// the context is done rather than at the next network read
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("ollama stream canceled: %w", err)
+		}
 
-	// Build request
-	reqBody := ollamaChatRequest{
-		Model:    model.ModelName(),
-		Messages: messages,
-		Stream:   false,
+		line, readErr := reader.ReadBytes('\n')
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			var chunk ollamaChatResponse
+			if err := json.Unmarshal(line, &chunk); err != nil {
+				return nil, fmt.Errorf("failed to decode stream chunk: %w", err)
+			}
+			if chunk.Error != "" {
+				return nil, fmt.Errorf("ollama stream error: %s", chunk.Error)
+			}
+
+			if delta := chunk.text(); delta != "" {
+				text.WriteString(delta)
+				if err := handler(StreamChunk{Text: delta}); err != nil {
+					return nil, err
+				}
+			}
+
+			if chunk.Done {
+				final = chunk
+				lastLine = line
+				break
+			}
+		}
+
+		if readErr == io.EOF {
+			return nil, fmt.Errorf("ollama stream ended before completion")
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read stream: %w", readErr)
+		}
+	}
+
+	response := c.buildResponse(&final, text.String(), reqOpts.minimalResponse)
+
+	// The final object is the closest thing to a raw response for a stream
+	if reqOpts.includeRaw {
+		response.RawJSON = lastLine
+	}
+
+	logger.Debug().
+		Str("model", final.Model).
+		Int("prompt_tokens", final.PromptEvalCount).
+		Int("completion_tokens", final.EvalCount).
+		Int("total_tokens", final.PromptEvalCount+final.EvalCount).
+		Msg("Ollama stream completed")
+
+	return response, nil
+}
+
+// buildRequest builds the endpoint URL and JSON body for a generation request.
+// The prompt is the last turn of the conversation.
+func (c *ollamaClient) buildRequest(model Model, turns []ChatMessage, stream bool, reqOpts *generateOptions) (string, []byte, error) {
+	// Get model options
+	opts := getOllamaOptions(model)
+	if opts.rawGenerate && len(turns) > 1 {
+		return "", nil, fmt.Errorf("ollama raw generate mode does not support multi-turn chat")
+	}
+	if opts.rawGenerate && reqOpts.systemPromptFor(model) != "" {
+		return "", nil, fmt.Errorf("ollama raw generate mode does not support system prompts; include it in the prompt")
 	}
 
 	// Add options if any are set
@@ -442,8 +680,9 @@ func (c *ollamaClient) Generate(ctx context.Context, model Model, prompt string)
 		modelOpts.NumPredict = opts.maxTokens
 		hasOpts = true
 	}
-	if opts.temperature > 0 {
-		modelOpts.Temperature = opts.temperature
+	if opts.temperatureSet || opts.temperature > 0 {
+		temperature := opts.temperature
+		modelOpts.Temperature = &temperature
 		hasOpts = true
 	}
 	if opts.topP > 0 {
@@ -466,24 +705,85 @@ func (c *ollamaClient) Generate(ctx context.Context, model Model, prompt string)
 		modelOpts.Seed = opts.seed
 		hasOpts = true
 	}
-	if hasOpts {
-		reqBody.Options = modelOpts
+	if seed, ok := reqOpts.samplingSeed(); ok {
+		modelOpts.Seed = int(seed)
+		hasOpts = true
+	}
+	if reqOpts.deterministic {
+		temperature := 0.0
+		modelOpts.Temperature = &temperature
+		hasOpts = true
+	}
+	if len(reqOpts.stopSequences) > 0 {
+		modelOpts.Stop = reqOpts.stopSequences
+		hasOpts = true
+	}
+	if !hasOpts {
+		modelOpts = nil
 	}
 
-	jsonBody, err := json.Marshal(reqBody)
+	var format any
+	if opts.format != nil {
+		format = ollamaFormat(opts.format)
+	}
+
+	// Raw mode sends the prompt verbatim to the completion endpoint
+	endpoint := c.baseURL + "/api/chat"
+	var payload any
+	if opts.rawGenerate {
+		endpoint = c.baseURL + "/api/generate"
+		payload = ollamaGenerateRequest{
+			Model:     model.ModelName(),
+			Prompt:    turns[len(turns)-1].Content,
+			Raw:       true,
+			Stream:    stream,
+			Format:    format,
+			Options:   modelOpts,
+			KeepAlive: c.keepAlive,
+		}
+	} else {
+		// Build messages
+		messages := []ollamaChatMessage{}
+		if system := reqOpts.systemPromptFor(model); system != "" {
+			messages = append(messages, ollamaChatMessage{
+				Role:    "system",
+				Content: system,
+			})
+		}
+		for _, turn := range turns {
+			messages = append(messages, ollamaChatMessage{
+				Role:    string(turn.Role),
+				Content: turn.Content,
+			})
+		}
+
+		payload = ollamaChatRequest{
+			Model:     model.ModelName(),
+			Messages:  messages,
+			Stream:    stream,
+			Format:    format,
+			Options:   modelOpts,
+			KeepAlive: c.keepAlive,
+		}
+	}
+
+	jsonBody, err := json.Marshal(payload)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return "", nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
+	return endpoint, jsonBody, nil
+}
 
+// doRequest sends a request to Ollama with rate limit handling and checks the status code
+func (c *ollamaClient) doRequest(ctx context.Context, model Model, prompt, endpoint string, jsonBody []byte, reqOpts *generateOptions) (*http.Response, error) {
 	c.logger.Debug().
 		Str("model", model.ModelName()).
-		Str("url", c.baseURL+"/api/chat").
+		Str("url", endpoint).
 		Msg("Making Ollama API request")
 
-	// Make request with rate limit handling
 	var resp *http.Response
-	err = c.rateLimiter.Execute(ctx, func() error {
-		req, reqErr := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/chat", bytes.NewBuffer(jsonBody))
+	err := c.rateLimiter.ExecuteRequest(ctx, model.ModelName(), reqOpts, func() error {
+		req, reqErr := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(jsonBody))
 		if reqErr != nil {
 			return reqErr
 		}
@@ -496,49 +796,156 @@ func (c *ollamaClient) Generate(ctx context.Context, model Model, prompt string)
 		c.logger.Error().
 			Err(err).
 			Str("model", model.ModelName()).
-			Str("prompt_preview", truncateString(prompt, 100)).
+			Str("prompt_preview", reqOpts.promptPreview(prompt)).
 			Msg("Ollama generation failed")
 		return nil, fmt.Errorf("ollama generation failed: %w", err)
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("ollama API error: status %d, body: %s", resp.StatusCode, string(body))
+		return nil, &HTTPStatusError{
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("ollama API error: status %d, body: %s", resp.StatusCode, string(body)),
+		}
 	}
 
-	// Parse response
-	var ollamaResp ollamaChatResponse
-	if err := json.NewDecoder(resp.Body).Decode(&ollamaResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
+	return resp, nil
+}
 
-	// Build response
+// buildResponse converts a final Ollama response object into a GenerationResponse
+func (c *ollamaClient) buildResponse(ollamaResp *ollamaChatResponse, text string, minimal bool) *GenerationResponse {
 	response := &GenerationResponse{
-		Text:         ollamaResp.Message.Content,
+		Text:         text,
 		Model:        ollamaResp.Model,
-		FinishReason: ollamaResp.DoneReason,
+		FinishReason: ollamaResp.finishReason(),
 		Usage: TokenUsage{
 			PromptTokens:     ollamaResp.PromptEvalCount,
 			CompletionTokens: ollamaResp.EvalCount,
 			TotalTokens:      ollamaResp.PromptEvalCount + ollamaResp.EvalCount,
 		},
-		Metadata: map[string]string{
-			"provider":       "ollama",
-			"model":          ollamaResp.Model,
-			"total_duration": fmt.Sprintf("%d", ollamaResp.TotalDuration),
-			"load_duration":  fmt.Sprintf("%d", ollamaResp.LoadDuration),
+		ollamaTimings: &OllamaTimings{
+			Total:      time.Duration(ollamaResp.TotalDuration),
+			Load:       time.Duration(ollamaResp.LoadDuration),
+			PromptEval: time.Duration(ollamaResp.PromptEvalDuration),
+			Eval:       time.Duration(ollamaResp.EvalDuration),
 		},
 	}
+	if minimal {
+		return response
+	}
+
+	response.Metadata = map[string]string{
+		"provider":             "ollama",
+		"model":                ollamaResp.Model,
+		"total_duration":       fmt.Sprintf("%d", ollamaResp.TotalDuration),
+		"load_duration":        fmt.Sprintf("%d", ollamaResp.LoadDuration),
+		"prompt_eval_duration": fmt.Sprintf("%d", ollamaResp.PromptEvalDuration),
+		"eval_duration":        fmt.Sprintf("%d", ollamaResp.EvalDuration),
+	}
+	return response
+}
+
+// OllamaTimings is the timing breakdown Ollama reports for a generation
+type OllamaTimings struct {
+	Total      time.Duration // The whole request, including loading the model
+	Load       time.Duration // Loading the model into memory
+	PromptEval time.Duration // Processing the prompt
+	Eval       time.Duration // Generating the completion
+
+	PromptTokensPerSecond float64 // Prompt processing throughput
+	TokensPerSecond       float64 // Generation throughput
+}
+
+// GetOllamaTimings returns the timing breakdown of an Ollama response, for
+// benchmarking local models. It works with WithMinimalResponse too. The second
+// result is false for responses from other providers.
+func GetOllamaTimings(resp *GenerationResponse) (OllamaTimings, bool) {
+	if resp == nil {
+		return OllamaTimings{}, false
+	}
+
+	var timings OllamaTimings
+	switch {
+	case resp.ollamaTimings != nil:
+		timings = *resp.ollamaTimings
+	case resp.Metadata["provider"] == "ollama":
+		// Responses decoded from JSON, such as cached ones, only keep the metadata
+		duration := func(key string) time.Duration {
+			ns, _ := strconv.ParseInt(resp.Metadata[key], 10, 64)
+			return time.Duration(ns)
+		}
+		timings = OllamaTimings{
+			Total:      duration("total_duration"),
+			Load:       duration("load_duration"),
+			PromptEval: duration("prompt_eval_duration"),
+			Eval:       duration("eval_duration"),
+		}
+	default:
+		return OllamaTimings{}, false
+	}
+	if timings.PromptEval > 0 {
+		timings.PromptTokensPerSecond = float64(resp.Usage.PromptTokens) / timings.PromptEval.Seconds()
+	}
+	if timings.Eval > 0 {
+		timings.TokensPerSecond = float64(resp.Usage.CompletionTokens) / timings.Eval.Seconds()
+	}
+	return timings, true
+}
+
+// rateLimitStats returns the rate limit counters of the Ollama client
+func (c *ollamaClient) rateLimitStats() RateLimitStats { return c.rateLimiter.Stats() }
+
+// Warmup loads the given models into memory by sending an empty generate
+// request for each. Without models it only checks that the server is reachable.
+func (c *ollamaClient) Warmup(ctx context.Context, models []Model) error {
+	if len(models) == 0 {
+		return c.Health(ctx)
+	}
+
+	for _, model := range models {
+		if err := c.warmupModel(ctx, model); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// warmupModel loads a single model into memory
+func (c *ollamaClient) warmupModel(ctx context.Context, model Model) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(c.timeout, ProviderOllama, model.ModelName()))
+	defer cancel()
+
+	body, err := json.Marshal(ollamaGenerateRequest{
+		Model:     model.ModelName(),
+		KeepAlive: c.keepAlive,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/generate", bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("ollama warmup failed: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("ollama warmup failed for %s: %w", model.ModelName(), err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("ollama warmup failed for %s: status %d", model.ModelName(), resp.StatusCode)
+	}
 
 	c.logger.Debug().
-		Str("model", ollamaResp.Model).
-		Int("prompt_tokens", ollamaResp.PromptEvalCount).
-		Int("completion_tokens", ollamaResp.EvalCount).
-		Int("total_tokens", ollamaResp.PromptEvalCount+ollamaResp.EvalCount).
-		Msg("Ollama generation completed")
+		Str("model", model.ModelName()).
+		Msg("Ollama model loaded")
 
-	return response, nil
+	return nil
 }
 
 // Health checks the health of the Ollama client
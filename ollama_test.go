@@ -0,0 +1,185 @@
+package lingo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// newStreamingOllamaServer serves an NDJSON stream that sends one chunk and
+// then holds the connection open until the client goes away
+func newStreamingOllamaServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		fmt.Fprintln(w, `{"message":{"role":"assistant","content":"Hello"},"done":false}`)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+}
+
+func newOllamaTestGateway(t *testing.T, baseURL string) *LLMGateway {
+	t.Helper()
+	g, err := New([]ProviderConfig{&OllamaConfig{BaseURL: baseURL}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return g
+}
+
+// waitForGoroutines polls until the goroutine count drops back to at most want
+func waitForGoroutines(t *testing.T, want int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		n := runtime.NumGoroutine()
+		if n <= want {
+			return
+		}
+		if time.Now().After(deadline) {
+			buf := make([]byte, 1<<16)
+			t.Fatalf("goroutines leaked: have %d, want at most %d\n%s", n, want, buf[:runtime.Stack(buf, true)])
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestOllamaGenerateStreamCancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	srv := newStreamingOllamaServer(t)
+	g := newOllamaTestGateway(t, srv.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var chunks int
+	done := make(chan error, 1)
+	go func() {
+		_, err := g.GenerateStream(ctx, NewLlama31(), "Say hello", func(chunk StreamChunk) error {
+			chunks++
+			cancel()
+			return nil
+		})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("GenerateStream error = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("GenerateStream did not return after cancellation")
+	}
+	if chunks != 1 {
+		t.Errorf("got %d chunks, want 1", chunks)
+	}
+
+	srv.Close()
+	g.Close()
+	waitForGoroutines(t, before)
+}
+
+func TestOllamaGenerateStreamCancelBeforeChunk(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	g := newOllamaTestGateway(t, srv.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := g.GenerateStream(ctx, NewLlama31(), "Say hello", func(StreamChunk) error {
+		t.Error("unexpected chunk")
+		return nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GenerateStream error = %v, want context.DeadlineExceeded", err)
+	}
+
+	srv.Close()
+	g.Close()
+	waitForGoroutines(t, before)
+}
+
+func TestOllamaErrorStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   ErrorKind
+	}{
+		{http.StatusTooManyRequests, KindRateLimit},
+		{http.StatusServiceUnavailable, KindServer},
+		{http.StatusNotFound, KindNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, `{"error":"nope"}`, tt.status)
+			}))
+			defer srv.Close()
+			g := newOllamaTestGateway(t, srv.URL)
+			defer g.Close()
+
+			_, err := g.Generate(context.Background(), NewLlama31(), "Say hello")
+			var pe *ProviderError
+			if !errors.As(err, &pe) {
+				t.Fatalf("Generate error = %v, want a ProviderError", err)
+			}
+			if pe.StatusCode != tt.status || pe.Kind != tt.want {
+				t.Errorf("StatusCode, Kind = %d, %s, want %d, %s", pe.StatusCode, pe.Kind, tt.status, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetOllamaTimings(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"model":"llama3","message":{"role":"assistant","content":"Hello"},"done":true,`+
+			`"total_duration":3000000000,"load_duration":1000000000,"prompt_eval_count":10,"prompt_eval_duration":500000000,"eval_count":20,"eval_duration":1000000000}`)
+	}))
+	defer srv.Close()
+	g := newOllamaTestGateway(t, srv.URL)
+	defer g.Close()
+
+	want := OllamaTimings{
+		Total:                 3 * time.Second,
+		Load:                  time.Second,
+		PromptEval:            500 * time.Millisecond,
+		Eval:                  time.Second,
+		PromptTokensPerSecond: 20,
+		TokensPerSecond:       20,
+	}
+	tests := []struct {
+		name string
+		opts []GenerateOption
+	}{
+		{"full response", nil},
+		{"minimal response", []GenerateOption{WithMinimalResponse()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := g.Generate(context.Background(), NewLlama31(), "Say hello", tt.opts...)
+			if err != nil {
+				t.Fatalf("Generate: %v", err)
+			}
+			got, ok := GetOllamaTimings(resp)
+			if !ok || got != want {
+				t.Errorf("GetOllamaTimings = %+v, %t, want %+v, true", got, ok, want)
+			}
+		})
+	}
+
+	if _, ok := GetOllamaTimings(&GenerationResponse{Metadata: map[string]string{"provider": "openai"}}); ok {
+		t.Error("GetOllamaTimings reported timings for an OpenAI response")
+	}
+}
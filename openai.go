@@ -1,12 +1,19 @@
 package lingo
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/option"
+	"github.com/openai/openai-go/responses"
 	"github.com/openai/openai-go/shared"
 )
 
@@ -28,12 +35,25 @@ func init() {
 type OpenAIConfig struct {
 	// APIKey is the OpenAI API key (required)
 	APIKey string
-	// Timeout is the request timeout (default: 60s)
+	// Timeout is the request timeout (default: the model's recommended timeout,
+	// reported by Describe)
 	Timeout time.Duration
 	// RateLimiter is the optional rate limit configuration
 	RateLimiter *RateLimitConfig
 	// BaseURL is an optional custom base URL (for Azure OpenAI or proxies)
 	BaseURL string
+	// Headers are extra HTTP headers sent with every request, such as the
+	// auth or caching headers of an observability proxy (optional)
+	Headers map[string]string
+	// DefaultMaxTokens is applied to models that haven't set max tokens
+	// explicitly (max completion tokens for reasoning models)
+	DefaultMaxTokens int
+	// DefaultTemperature is applied to standard models that haven't set a
+	// temperature explicitly (optional; 0 is sent as a temperature of 0)
+	DefaultTemperature *float64
+	// UseResponsesAPI sends Generate requests to the Responses API instead of
+	// Chat Completions. Streaming and batch jobs always use Chat Completions.
+	UseResponsesAPI bool
 }
 
 // Implement ProviderConfig interface
@@ -53,6 +73,28 @@ type openAIStandardOptions struct {
 	temperature  float64
 	topP         float64
 	systemPrompt string
+	logitBias    map[int]int       // Token ID -> bias in [-100, 100]
+	serviceTier  string            // "auto", "default", "flex", "priority"
+	store        *bool             // Whether OpenAI stores the completion; nil leaves the API default
+	metadata     map[string]string // Tags for stored completions
+
+	// Track explicitly set options so config-level defaults don't override them
+	maxTokensSet   bool
+	temperatureSet bool
+}
+
+func (o *openAIStandardOptions) setMaxTokens(n int)       { o.maxTokens = n; o.maxTokensSet = true }
+func (o *openAIStandardOptions) setTemperature(t float64) { o.temperature = t; o.temperatureSet = true }
+func (o *openAIStandardOptions) explicitOptions() explicitOptions {
+	return explicitOptions{maxTokens: o.maxTokensSet, temperature: o.temperatureSet}
+}
+func (o *openAIStandardOptions) explicitTemperature() (float64, bool) {
+	return o.temperature, o.temperatureSet
+}
+func (o *openAIStandardOptions) tier() string                            { return o.serviceTier }
+func (o *openAIStandardOptions) standardOptions() *openAIStandardOptions { return o }
+func (o *openAIStandardOptions) storage() (*bool, map[string]string) {
+	return o.store, o.metadata
 }
 
 // openAIReasoningOptions contains options for reasoning models (o1, o3, o4, GPT-5)
@@ -60,7 +102,55 @@ type openAIReasoningOptions struct {
 	modelVersion        string // Optional: override model name with specific version
 	maxCompletionTokens int
 	reasoningEffort     string // "low", "medium", "high"
+	reasoningSummary    string // "auto", "concise", "detailed"; requires UseResponsesAPI
 	systemPrompt        string
+	serviceTier         string            // "auto", "default", "flex", "priority"
+	store               *bool             // Whether OpenAI stores the completion; nil leaves the API default
+	metadata            map[string]string // Tags for stored completions
+
+	// Track explicitly set options so config-level defaults don't override them
+	maxCompletionTokensSet bool
+}
+
+func (o *openAIReasoningOptions) setMaxCompletionTokens(n int) {
+	o.maxCompletionTokens = n
+	o.maxCompletionTokensSet = true
+}
+func (o *openAIReasoningOptions) explicitOptions() explicitOptions {
+	// Reasoning models don't accept a temperature, so never apply a default one
+	return explicitOptions{maxTokens: o.maxCompletionTokensSet, temperature: true}
+}
+func (o *openAIReasoningOptions) summary() string                           { return o.reasoningSummary }
+func (o *openAIReasoningOptions) tier() string                              { return o.serviceTier }
+func (o *openAIReasoningOptions) reasoningOptions() *openAIReasoningOptions { return o }
+func (o *openAIReasoningOptions) storage() (*bool, map[string]string) {
+	return o.store, o.metadata
+}
+
+// openAIAudioOutputOptions contains options for models that can answer with speech
+type openAIAudioOutputOptions struct {
+	voice       string // e.g. "alloy", "coral"; empty answers with text only
+	audioFormat string // "wav", "mp3", "aac", "flac", "opus", "pcm16"
+}
+
+func (o *openAIAudioOutputOptions) audioOutput() (voice, format string) {
+	return o.voice, o.audioFormat
+}
+
+// openAITranscriptionOptions contains options for speech-to-text models
+type openAITranscriptionOptions struct {
+	language    string // ISO-639-1 code of the input audio, e.g. "en"
+	prompt      string // Optional text to guide style or continue a previous segment
+	temperature float64
+	filename    string // Used by the API to detect the audio format
+}
+
+// openAIImageOptions contains options for image generation models
+type openAIImageOptions struct {
+	size           string // e.g. "1024x1024", "1792x1024"
+	quality        string // "standard" or "hd"
+	style          string // "vivid" or "natural"
+	responseFormat ImageResponseFormat
 }
 
 // ============================================================================
@@ -81,15 +171,19 @@ func (m *GPT4o) Provider() ProviderType { return ProviderOpenAI }
 func (m *GPT4o) SystemPrompt() string   { return m.systemPrompt }
 func (m *GPT4o) isStandard() bool       { return true }
 
-func (m *GPT4o) WithVersion(v string) *GPT4o      { m.modelVersion = v; return m }
-func (m *GPT4o) WithMaxTokens(n int) *GPT4o       { m.maxTokens = n; return m }
-func (m *GPT4o) WithTemperature(t float64) *GPT4o { m.temperature = t; return m }
-func (m *GPT4o) WithTopP(p float64) *GPT4o        { m.topP = p; return m }
-func (m *GPT4o) WithSystemPrompt(s string) *GPT4o { m.systemPrompt = s; return m }
+func (m *GPT4o) WithVersion(v string) *GPT4o              { m.modelVersion = v; return m }
+func (m *GPT4o) WithMaxTokens(n int) *GPT4o               { m.setMaxTokens(n); return m }
+func (m *GPT4o) WithTemperature(t float64) *GPT4o         { m.setTemperature(t); return m }
+func (m *GPT4o) WithTopP(p float64) *GPT4o                { m.topP = p; return m }
+func (m *GPT4o) WithSystemPrompt(s string) *GPT4o         { m.systemPrompt = s; return m }
+func (m *GPT4o) WithServiceTier(t string) *GPT4o          { m.serviceTier = t; return m }
+func (m *GPT4o) WithStore(store bool) *GPT4o              { m.store = &store; return m }
+func (m *GPT4o) WithMetadata(md map[string]string) *GPT4o { m.metadata = md; return m }
+func (m *GPT4o) WithLogitBias(b map[int]int) *GPT4o       { m.logitBias = b; return m }
 
 // NewGPT4o creates a new GPT-4o model with default options
 func NewGPT4o() *GPT4o {
-	return &GPT4o{openAIStandardOptions{maxTokens: 4096, temperature: 1.0}}
+	return &GPT4o{openAIStandardOptions{maxTokens: defaultMaxTokens("gpt-4o"), temperature: 1.0}}
 }
 
 // GPT4oMini represents the GPT-4o-mini model
@@ -106,15 +200,19 @@ func (m *GPT4oMini) Provider() ProviderType { return ProviderOpenAI }
 func (m *GPT4oMini) SystemPrompt() string   { return m.systemPrompt }
 func (m *GPT4oMini) isStandard() bool       { return true }
 
-func (m *GPT4oMini) WithVersion(v string) *GPT4oMini      { m.modelVersion = v; return m }
-func (m *GPT4oMini) WithMaxTokens(n int) *GPT4oMini       { m.maxTokens = n; return m }
-func (m *GPT4oMini) WithTemperature(t float64) *GPT4oMini { m.temperature = t; return m }
-func (m *GPT4oMini) WithTopP(p float64) *GPT4oMini        { m.topP = p; return m }
-func (m *GPT4oMini) WithSystemPrompt(s string) *GPT4oMini { m.systemPrompt = s; return m }
+func (m *GPT4oMini) WithVersion(v string) *GPT4oMini              { m.modelVersion = v; return m }
+func (m *GPT4oMini) WithMaxTokens(n int) *GPT4oMini               { m.setMaxTokens(n); return m }
+func (m *GPT4oMini) WithTemperature(t float64) *GPT4oMini         { m.setTemperature(t); return m }
+func (m *GPT4oMini) WithTopP(p float64) *GPT4oMini                { m.topP = p; return m }
+func (m *GPT4oMini) WithSystemPrompt(s string) *GPT4oMini         { m.systemPrompt = s; return m }
+func (m *GPT4oMini) WithServiceTier(t string) *GPT4oMini          { m.serviceTier = t; return m }
+func (m *GPT4oMini) WithStore(store bool) *GPT4oMini              { m.store = &store; return m }
+func (m *GPT4oMini) WithMetadata(md map[string]string) *GPT4oMini { m.metadata = md; return m }
+func (m *GPT4oMini) WithLogitBias(b map[int]int) *GPT4oMini       { m.logitBias = b; return m }
 
 // NewGPT4oMini creates a new GPT-4o-mini model with default options
 func NewGPT4oMini() *GPT4oMini {
-	return &GPT4oMini{openAIStandardOptions{maxTokens: 4096, temperature: 1.0}}
+	return &GPT4oMini{openAIStandardOptions{maxTokens: defaultMaxTokens("gpt-4o-mini"), temperature: 1.0}}
 }
 
 // GPT4Turbo represents the GPT-4-turbo model
@@ -131,15 +229,19 @@ func (m *GPT4Turbo) Provider() ProviderType { return ProviderOpenAI }
 func (m *GPT4Turbo) SystemPrompt() string   { return m.systemPrompt }
 func (m *GPT4Turbo) isStandard() bool       { return true }
 
-func (m *GPT4Turbo) WithVersion(v string) *GPT4Turbo      { m.modelVersion = v; return m }
-func (m *GPT4Turbo) WithMaxTokens(n int) *GPT4Turbo       { m.maxTokens = n; return m }
-func (m *GPT4Turbo) WithTemperature(t float64) *GPT4Turbo { m.temperature = t; return m }
-func (m *GPT4Turbo) WithTopP(p float64) *GPT4Turbo        { m.topP = p; return m }
-func (m *GPT4Turbo) WithSystemPrompt(s string) *GPT4Turbo { m.systemPrompt = s; return m }
+func (m *GPT4Turbo) WithVersion(v string) *GPT4Turbo              { m.modelVersion = v; return m }
+func (m *GPT4Turbo) WithMaxTokens(n int) *GPT4Turbo               { m.setMaxTokens(n); return m }
+func (m *GPT4Turbo) WithTemperature(t float64) *GPT4Turbo         { m.setTemperature(t); return m }
+func (m *GPT4Turbo) WithTopP(p float64) *GPT4Turbo                { m.topP = p; return m }
+func (m *GPT4Turbo) WithSystemPrompt(s string) *GPT4Turbo         { m.systemPrompt = s; return m }
+func (m *GPT4Turbo) WithServiceTier(t string) *GPT4Turbo          { m.serviceTier = t; return m }
+func (m *GPT4Turbo) WithStore(store bool) *GPT4Turbo              { m.store = &store; return m }
+func (m *GPT4Turbo) WithMetadata(md map[string]string) *GPT4Turbo { m.metadata = md; return m }
+func (m *GPT4Turbo) WithLogitBias(b map[int]int) *GPT4Turbo       { m.logitBias = b; return m }
 
 // NewGPT4Turbo creates a new GPT-4-turbo model with default options
 func NewGPT4Turbo() *GPT4Turbo {
-	return &GPT4Turbo{openAIStandardOptions{maxTokens: 4096, temperature: 1.0}}
+	return &GPT4Turbo{openAIStandardOptions{maxTokens: defaultMaxTokens("gpt-4-turbo"), temperature: 1.0}}
 }
 
 // GPT4 represents the GPT-4 model
@@ -156,15 +258,19 @@ func (m *GPT4) Provider() ProviderType { return ProviderOpenAI }
 func (m *GPT4) SystemPrompt() string   { return m.systemPrompt }
 func (m *GPT4) isStandard() bool       { return true }
 
-func (m *GPT4) WithVersion(v string) *GPT4      { m.modelVersion = v; return m }
-func (m *GPT4) WithMaxTokens(n int) *GPT4       { m.maxTokens = n; return m }
-func (m *GPT4) WithTemperature(t float64) *GPT4 { m.temperature = t; return m }
-func (m *GPT4) WithTopP(p float64) *GPT4        { m.topP = p; return m }
-func (m *GPT4) WithSystemPrompt(s string) *GPT4 { m.systemPrompt = s; return m }
+func (m *GPT4) WithVersion(v string) *GPT4              { m.modelVersion = v; return m }
+func (m *GPT4) WithMaxTokens(n int) *GPT4               { m.setMaxTokens(n); return m }
+func (m *GPT4) WithTemperature(t float64) *GPT4         { m.setTemperature(t); return m }
+func (m *GPT4) WithTopP(p float64) *GPT4                { m.topP = p; return m }
+func (m *GPT4) WithSystemPrompt(s string) *GPT4         { m.systemPrompt = s; return m }
+func (m *GPT4) WithServiceTier(t string) *GPT4          { m.serviceTier = t; return m }
+func (m *GPT4) WithStore(store bool) *GPT4              { m.store = &store; return m }
+func (m *GPT4) WithMetadata(md map[string]string) *GPT4 { m.metadata = md; return m }
+func (m *GPT4) WithLogitBias(b map[int]int) *GPT4       { m.logitBias = b; return m }
 
 // NewGPT4 creates a new GPT-4 model with default options
 func NewGPT4() *GPT4 {
-	return &GPT4{openAIStandardOptions{maxTokens: 4096, temperature: 1.0}}
+	return &GPT4{openAIStandardOptions{maxTokens: defaultMaxTokens("gpt-4"), temperature: 1.0}}
 }
 
 // GPT41 represents the GPT-4.1 model
@@ -181,15 +287,19 @@ func (m *GPT41) Provider() ProviderType { return ProviderOpenAI }
 func (m *GPT41) SystemPrompt() string   { return m.systemPrompt }
 func (m *GPT41) isStandard() bool       { return true }
 
-func (m *GPT41) WithVersion(v string) *GPT41      { m.modelVersion = v; return m }
-func (m *GPT41) WithMaxTokens(n int) *GPT41       { m.maxTokens = n; return m }
-func (m *GPT41) WithTemperature(t float64) *GPT41 { m.temperature = t; return m }
-func (m *GPT41) WithTopP(p float64) *GPT41        { m.topP = p; return m }
-func (m *GPT41) WithSystemPrompt(s string) *GPT41 { m.systemPrompt = s; return m }
+func (m *GPT41) WithVersion(v string) *GPT41              { m.modelVersion = v; return m }
+func (m *GPT41) WithMaxTokens(n int) *GPT41               { m.setMaxTokens(n); return m }
+func (m *GPT41) WithTemperature(t float64) *GPT41         { m.setTemperature(t); return m }
+func (m *GPT41) WithTopP(p float64) *GPT41                { m.topP = p; return m }
+func (m *GPT41) WithSystemPrompt(s string) *GPT41         { m.systemPrompt = s; return m }
+func (m *GPT41) WithServiceTier(t string) *GPT41          { m.serviceTier = t; return m }
+func (m *GPT41) WithStore(store bool) *GPT41              { m.store = &store; return m }
+func (m *GPT41) WithMetadata(md map[string]string) *GPT41 { m.metadata = md; return m }
+func (m *GPT41) WithLogitBias(b map[int]int) *GPT41       { m.logitBias = b; return m }
 
 // NewGPT41 creates a new GPT-4.1 model with default options
 func NewGPT41() *GPT41 {
-	return &GPT41{openAIStandardOptions{maxTokens: 4096, temperature: 1.0}}
+	return &GPT41{openAIStandardOptions{maxTokens: defaultMaxTokens("gpt-4.1"), temperature: 1.0}}
 }
 
 // GPT41Mini represents the GPT-4.1-mini model
@@ -200,14 +310,18 @@ func (m *GPT41Mini) Provider() ProviderType { return ProviderOpenAI }
 func (m *GPT41Mini) SystemPrompt() string   { return m.systemPrompt }
 func (m *GPT41Mini) isStandard() bool       { return true }
 
-func (m *GPT41Mini) WithMaxTokens(n int) *GPT41Mini       { m.maxTokens = n; return m }
-func (m *GPT41Mini) WithTemperature(t float64) *GPT41Mini { m.temperature = t; return m }
-func (m *GPT41Mini) WithTopP(p float64) *GPT41Mini        { m.topP = p; return m }
-func (m *GPT41Mini) WithSystemPrompt(s string) *GPT41Mini { m.systemPrompt = s; return m }
+func (m *GPT41Mini) WithMaxTokens(n int) *GPT41Mini               { m.setMaxTokens(n); return m }
+func (m *GPT41Mini) WithTemperature(t float64) *GPT41Mini         { m.setTemperature(t); return m }
+func (m *GPT41Mini) WithTopP(p float64) *GPT41Mini                { m.topP = p; return m }
+func (m *GPT41Mini) WithSystemPrompt(s string) *GPT41Mini         { m.systemPrompt = s; return m }
+func (m *GPT41Mini) WithServiceTier(t string) *GPT41Mini          { m.serviceTier = t; return m }
+func (m *GPT41Mini) WithStore(store bool) *GPT41Mini              { m.store = &store; return m }
+func (m *GPT41Mini) WithMetadata(md map[string]string) *GPT41Mini { m.metadata = md; return m }
+func (m *GPT41Mini) WithLogitBias(b map[int]int) *GPT41Mini       { m.logitBias = b; return m }
 
 // NewGPT41Mini creates a new GPT-4.1-mini model with default options
 func NewGPT41Mini() *GPT41Mini {
-	return &GPT41Mini{openAIStandardOptions{maxTokens: 4096, temperature: 1.0}}
+	return &GPT41Mini{openAIStandardOptions{maxTokens: defaultMaxTokens("gpt-4.1-mini"), temperature: 1.0}}
 }
 
 // GPT41Nano represents the GPT-4.1-nano model
@@ -218,14 +332,18 @@ func (m *GPT41Nano) Provider() ProviderType { return ProviderOpenAI }
 func (m *GPT41Nano) SystemPrompt() string   { return m.systemPrompt }
 func (m *GPT41Nano) isStandard() bool       { return true }
 
-func (m *GPT41Nano) WithMaxTokens(n int) *GPT41Nano       { m.maxTokens = n; return m }
-func (m *GPT41Nano) WithTemperature(t float64) *GPT41Nano { m.temperature = t; return m }
-func (m *GPT41Nano) WithTopP(p float64) *GPT41Nano        { m.topP = p; return m }
-func (m *GPT41Nano) WithSystemPrompt(s string) *GPT41Nano { m.systemPrompt = s; return m }
+func (m *GPT41Nano) WithMaxTokens(n int) *GPT41Nano               { m.setMaxTokens(n); return m }
+func (m *GPT41Nano) WithTemperature(t float64) *GPT41Nano         { m.setTemperature(t); return m }
+func (m *GPT41Nano) WithTopP(p float64) *GPT41Nano                { m.topP = p; return m }
+func (m *GPT41Nano) WithSystemPrompt(s string) *GPT41Nano         { m.systemPrompt = s; return m }
+func (m *GPT41Nano) WithServiceTier(t string) *GPT41Nano          { m.serviceTier = t; return m }
+func (m *GPT41Nano) WithStore(store bool) *GPT41Nano              { m.store = &store; return m }
+func (m *GPT41Nano) WithMetadata(md map[string]string) *GPT41Nano { m.metadata = md; return m }
+func (m *GPT41Nano) WithLogitBias(b map[int]int) *GPT41Nano       { m.logitBias = b; return m }
 
 // NewGPT41Nano creates a new GPT-4.1-nano model with default options
 func NewGPT41Nano() *GPT41Nano {
-	return &GPT41Nano{openAIStandardOptions{maxTokens: 4096, temperature: 1.0}}
+	return &GPT41Nano{openAIStandardOptions{maxTokens: defaultMaxTokens("gpt-4.1-nano"), temperature: 1.0}}
 }
 
 // GPT35Turbo represents the GPT-3.5-turbo model
@@ -242,15 +360,108 @@ func (m *GPT35Turbo) Provider() ProviderType { return ProviderOpenAI }
 func (m *GPT35Turbo) SystemPrompt() string   { return m.systemPrompt }
 func (m *GPT35Turbo) isStandard() bool       { return true }
 
-func (m *GPT35Turbo) WithVersion(v string) *GPT35Turbo      { m.modelVersion = v; return m }
-func (m *GPT35Turbo) WithMaxTokens(n int) *GPT35Turbo       { m.maxTokens = n; return m }
-func (m *GPT35Turbo) WithTemperature(t float64) *GPT35Turbo { m.temperature = t; return m }
-func (m *GPT35Turbo) WithTopP(p float64) *GPT35Turbo        { m.topP = p; return m }
-func (m *GPT35Turbo) WithSystemPrompt(s string) *GPT35Turbo { m.systemPrompt = s; return m }
+func (m *GPT35Turbo) WithVersion(v string) *GPT35Turbo              { m.modelVersion = v; return m }
+func (m *GPT35Turbo) WithMaxTokens(n int) *GPT35Turbo               { m.setMaxTokens(n); return m }
+func (m *GPT35Turbo) WithTemperature(t float64) *GPT35Turbo         { m.setTemperature(t); return m }
+func (m *GPT35Turbo) WithTopP(p float64) *GPT35Turbo                { m.topP = p; return m }
+func (m *GPT35Turbo) WithSystemPrompt(s string) *GPT35Turbo         { m.systemPrompt = s; return m }
+func (m *GPT35Turbo) WithServiceTier(t string) *GPT35Turbo          { m.serviceTier = t; return m }
+func (m *GPT35Turbo) WithStore(store bool) *GPT35Turbo              { m.store = &store; return m }
+func (m *GPT35Turbo) WithMetadata(md map[string]string) *GPT35Turbo { m.metadata = md; return m }
+func (m *GPT35Turbo) WithLogitBias(b map[int]int) *GPT35Turbo       { m.logitBias = b; return m }
 
 // NewGPT35Turbo creates a new GPT-3.5-turbo model with default options
 func NewGPT35Turbo() *GPT35Turbo {
-	return &GPT35Turbo{openAIStandardOptions{maxTokens: 4096, temperature: 1.0}}
+	return &GPT35Turbo{openAIStandardOptions{maxTokens: defaultMaxTokens("gpt-3.5-turbo"), temperature: 1.0}}
+}
+
+// ============================================================================
+// AUDIO OUTPUT MODELS (GPT-4o Audio)
+// ============================================================================
+
+// GPT4oAudio represents the GPT-4o Audio model, which can answer with speech
+// Versions: gpt-4o-audio-preview, gpt-4o-audio-preview-2024-12-17
+type GPT4oAudio struct {
+	openAIStandardOptions
+	openAIAudioOutputOptions
+}
+
+func (m *GPT4oAudio) ModelName() string {
+	if m.modelVersion != "" {
+		return m.modelVersion
+	}
+	return "gpt-4o-audio-preview"
+}
+func (m *GPT4oAudio) Provider() ProviderType { return ProviderOpenAI }
+func (m *GPT4oAudio) SystemPrompt() string   { return m.systemPrompt }
+func (m *GPT4oAudio) isStandard() bool       { return true }
+
+func (m *GPT4oAudio) WithVersion(v string) *GPT4oAudio              { m.modelVersion = v; return m }
+func (m *GPT4oAudio) WithMaxTokens(n int) *GPT4oAudio               { m.setMaxTokens(n); return m }
+func (m *GPT4oAudio) WithTemperature(t float64) *GPT4oAudio         { m.setTemperature(t); return m }
+func (m *GPT4oAudio) WithTopP(p float64) *GPT4oAudio                { m.topP = p; return m }
+func (m *GPT4oAudio) WithSystemPrompt(s string) *GPT4oAudio         { m.systemPrompt = s; return m }
+func (m *GPT4oAudio) WithServiceTier(t string) *GPT4oAudio          { m.serviceTier = t; return m }
+func (m *GPT4oAudio) WithStore(store bool) *GPT4oAudio              { m.store = &store; return m }
+func (m *GPT4oAudio) WithMetadata(md map[string]string) *GPT4oAudio { m.metadata = md; return m }
+func (m *GPT4oAudio) WithLogitBias(b map[int]int) *GPT4oAudio       { m.logitBias = b; return m }
+
+// WithAudioOutput makes the model answer with speech in the given voice (e.g.
+// "alloy", "coral") and audio format ("wav", "mp3", "aac", "flac", "opus" or
+// "pcm16"). The audio is returned in GenerationResponse.Audio and its
+// transcript in Text.
+func (m *GPT4oAudio) WithAudioOutput(voice, format string) *GPT4oAudio {
+	m.voice, m.audioFormat = voice, format
+	return m
+}
+
+// NewGPT4oAudio creates a new GPT-4o Audio model with default options
+func NewGPT4oAudio() *GPT4oAudio {
+	return &GPT4oAudio{openAIStandardOptions: openAIStandardOptions{maxTokens: defaultMaxTokens("gpt-4o-audio-preview"), temperature: 1.0}}
+}
+
+// GPT4oMiniAudio represents the GPT-4o-mini Audio model, which can answer with speech
+// Versions: gpt-4o-mini-audio-preview, gpt-4o-mini-audio-preview-2024-12-17
+type GPT4oMiniAudio struct {
+	openAIStandardOptions
+	openAIAudioOutputOptions
+}
+
+func (m *GPT4oMiniAudio) ModelName() string {
+	if m.modelVersion != "" {
+		return m.modelVersion
+	}
+	return "gpt-4o-mini-audio-preview"
+}
+func (m *GPT4oMiniAudio) Provider() ProviderType { return ProviderOpenAI }
+func (m *GPT4oMiniAudio) SystemPrompt() string   { return m.systemPrompt }
+func (m *GPT4oMiniAudio) isStandard() bool       { return true }
+
+func (m *GPT4oMiniAudio) WithVersion(v string) *GPT4oMiniAudio      { m.modelVersion = v; return m }
+func (m *GPT4oMiniAudio) WithMaxTokens(n int) *GPT4oMiniAudio       { m.setMaxTokens(n); return m }
+func (m *GPT4oMiniAudio) WithTemperature(t float64) *GPT4oMiniAudio { m.setTemperature(t); return m }
+func (m *GPT4oMiniAudio) WithTopP(p float64) *GPT4oMiniAudio        { m.topP = p; return m }
+func (m *GPT4oMiniAudio) WithSystemPrompt(s string) *GPT4oMiniAudio { m.systemPrompt = s; return m }
+func (m *GPT4oMiniAudio) WithServiceTier(t string) *GPT4oMiniAudio  { m.serviceTier = t; return m }
+func (m *GPT4oMiniAudio) WithStore(store bool) *GPT4oMiniAudio      { m.store = &store; return m }
+func (m *GPT4oMiniAudio) WithMetadata(md map[string]string) *GPT4oMiniAudio {
+	m.metadata = md
+	return m
+}
+func (m *GPT4oMiniAudio) WithLogitBias(b map[int]int) *GPT4oMiniAudio { m.logitBias = b; return m }
+
+// WithAudioOutput makes the model answer with speech in the given voice (e.g.
+// "alloy", "coral") and audio format ("wav", "mp3", "aac", "flac", "opus" or
+// "pcm16"). The audio is returned in GenerationResponse.Audio and its
+// transcript in Text.
+func (m *GPT4oMiniAudio) WithAudioOutput(voice, format string) *GPT4oMiniAudio {
+	m.voice, m.audioFormat = voice, format
+	return m
+}
+
+// NewGPT4oMiniAudio creates a new GPT-4o-mini Audio model with default options
+func NewGPT4oMiniAudio() *GPT4oMiniAudio {
+	return &GPT4oMiniAudio{openAIStandardOptions: openAIStandardOptions{maxTokens: defaultMaxTokens("gpt-4o-mini-audio-preview"), temperature: 1.0}}
 }
 
 // ============================================================================
@@ -271,18 +482,25 @@ func (m *O1) Provider() ProviderType { return ProviderOpenAI }
 func (m *O1) SystemPrompt() string   { return m.systemPrompt }
 func (m *O1) isReasoning() bool      { return true }
 
-func (m *O1) WithVersion(v string) *O1          { m.modelVersion = v; return m }
-func (m *O1) WithMaxCompletionTokens(n int) *O1 { m.maxCompletionTokens = n; return m }
-func (m *O1) WithReasoningEffort(e string) *O1  { m.reasoningEffort = e; return m }
-func (m *O1) WithSystemPrompt(s string) *O1     { m.systemPrompt = s; return m }
+func (m *O1) WithVersion(v string) *O1              { m.modelVersion = v; return m }
+func (m *O1) WithMaxCompletionTokens(n int) *O1     { m.setMaxCompletionTokens(n); return m }
+func (m *O1) WithMaxTokens(n int) *O1               { m.setMaxCompletionTokens(n); return m }
+func (m *O1) WithReasoningEffort(e string) *O1      { m.reasoningEffort = e; return m }
+func (m *O1) WithReasoningSummary(s string) *O1     { m.reasoningSummary = s; return m }
+func (m *O1) WithSystemPrompt(s string) *O1         { m.systemPrompt = s; return m }
+func (m *O1) WithServiceTier(t string) *O1          { m.serviceTier = t; return m }
+func (m *O1) WithStore(store bool) *O1              { m.store = &store; return m }
+func (m *O1) WithMetadata(md map[string]string) *O1 { m.metadata = md; return m }
 
 // NewO1 creates a new O1 model with default options
 func NewO1() *O1 {
-	return &O1{openAIReasoningOptions{maxCompletionTokens: 4096, reasoningEffort: "medium"}}
+	return &O1{openAIReasoningOptions{maxCompletionTokens: defaultMaxTokens("o1"), reasoningEffort: "medium"}}
 }
 
 // O1Mini represents the O1-mini reasoning model
 // Versions: o1-mini, o1-mini-2024-09-12
+// o1-mini accepts no system or developer messages, so the system prompt is
+// prepended to the user message instead.
 type O1Mini struct{ openAIReasoningOptions }
 
 func (m *O1Mini) ModelName() string {
@@ -295,14 +513,19 @@ func (m *O1Mini) Provider() ProviderType { return ProviderOpenAI }
 func (m *O1Mini) SystemPrompt() string   { return m.systemPrompt }
 func (m *O1Mini) isReasoning() bool      { return true }
 
-func (m *O1Mini) WithVersion(v string) *O1Mini          { m.modelVersion = v; return m }
-func (m *O1Mini) WithMaxCompletionTokens(n int) *O1Mini { m.maxCompletionTokens = n; return m }
-func (m *O1Mini) WithReasoningEffort(e string) *O1Mini  { m.reasoningEffort = e; return m }
-func (m *O1Mini) WithSystemPrompt(s string) *O1Mini     { m.systemPrompt = s; return m }
+func (m *O1Mini) WithVersion(v string) *O1Mini              { m.modelVersion = v; return m }
+func (m *O1Mini) WithMaxCompletionTokens(n int) *O1Mini     { m.setMaxCompletionTokens(n); return m }
+func (m *O1Mini) WithMaxTokens(n int) *O1Mini               { m.setMaxCompletionTokens(n); return m }
+func (m *O1Mini) WithReasoningEffort(e string) *O1Mini      { m.reasoningEffort = e; return m }
+func (m *O1Mini) WithReasoningSummary(s string) *O1Mini     { m.reasoningSummary = s; return m }
+func (m *O1Mini) WithSystemPrompt(s string) *O1Mini         { m.systemPrompt = s; return m }
+func (m *O1Mini) WithServiceTier(t string) *O1Mini          { m.serviceTier = t; return m }
+func (m *O1Mini) WithStore(store bool) *O1Mini              { m.store = &store; return m }
+func (m *O1Mini) WithMetadata(md map[string]string) *O1Mini { m.metadata = md; return m }
 
 // NewO1Mini creates a new O1-mini model with default options
 func NewO1Mini() *O1Mini {
-	return &O1Mini{openAIReasoningOptions{maxCompletionTokens: 4096, reasoningEffort: "medium"}}
+	return &O1Mini{openAIReasoningOptions{maxCompletionTokens: defaultMaxTokens("o1-mini"), reasoningEffort: "medium"}}
 }
 
 // O1Pro represents the O1-pro reasoning model
@@ -319,14 +542,19 @@ func (m *O1Pro) Provider() ProviderType { return ProviderOpenAI }
 func (m *O1Pro) SystemPrompt() string   { return m.systemPrompt }
 func (m *O1Pro) isReasoning() bool      { return true }
 
-func (m *O1Pro) WithVersion(v string) *O1Pro          { m.modelVersion = v; return m }
-func (m *O1Pro) WithMaxCompletionTokens(n int) *O1Pro { m.maxCompletionTokens = n; return m }
-func (m *O1Pro) WithReasoningEffort(e string) *O1Pro  { m.reasoningEffort = e; return m }
-func (m *O1Pro) WithSystemPrompt(s string) *O1Pro     { m.systemPrompt = s; return m }
+func (m *O1Pro) WithVersion(v string) *O1Pro              { m.modelVersion = v; return m }
+func (m *O1Pro) WithMaxCompletionTokens(n int) *O1Pro     { m.setMaxCompletionTokens(n); return m }
+func (m *O1Pro) WithMaxTokens(n int) *O1Pro               { m.setMaxCompletionTokens(n); return m }
+func (m *O1Pro) WithReasoningEffort(e string) *O1Pro      { m.reasoningEffort = e; return m }
+func (m *O1Pro) WithReasoningSummary(s string) *O1Pro     { m.reasoningSummary = s; return m }
+func (m *O1Pro) WithSystemPrompt(s string) *O1Pro         { m.systemPrompt = s; return m }
+func (m *O1Pro) WithServiceTier(t string) *O1Pro          { m.serviceTier = t; return m }
+func (m *O1Pro) WithStore(store bool) *O1Pro              { m.store = &store; return m }
+func (m *O1Pro) WithMetadata(md map[string]string) *O1Pro { m.metadata = md; return m }
 
 // NewO1Pro creates a new O1-pro model with default options
 func NewO1Pro() *O1Pro {
-	return &O1Pro{openAIReasoningOptions{maxCompletionTokens: 8192, reasoningEffort: "high"}}
+	return &O1Pro{openAIReasoningOptions{maxCompletionTokens: defaultMaxTokens("o1-pro"), reasoningEffort: "high"}}
 }
 
 // O3 represents the O3 reasoning model
@@ -343,14 +571,19 @@ func (m *O3) Provider() ProviderType { return ProviderOpenAI }
 func (m *O3) SystemPrompt() string   { return m.systemPrompt }
 func (m *O3) isReasoning() bool      { return true }
 
-func (m *O3) WithVersion(v string) *O3          { m.modelVersion = v; return m }
-func (m *O3) WithMaxCompletionTokens(n int) *O3 { m.maxCompletionTokens = n; return m }
-func (m *O3) WithReasoningEffort(e string) *O3  { m.reasoningEffort = e; return m }
-func (m *O3) WithSystemPrompt(s string) *O3     { m.systemPrompt = s; return m }
+func (m *O3) WithVersion(v string) *O3              { m.modelVersion = v; return m }
+func (m *O3) WithMaxCompletionTokens(n int) *O3     { m.setMaxCompletionTokens(n); return m }
+func (m *O3) WithMaxTokens(n int) *O3               { m.setMaxCompletionTokens(n); return m }
+func (m *O3) WithReasoningEffort(e string) *O3      { m.reasoningEffort = e; return m }
+func (m *O3) WithReasoningSummary(s string) *O3     { m.reasoningSummary = s; return m }
+func (m *O3) WithSystemPrompt(s string) *O3         { m.systemPrompt = s; return m }
+func (m *O3) WithServiceTier(t string) *O3          { m.serviceTier = t; return m }
+func (m *O3) WithStore(store bool) *O3              { m.store = &store; return m }
+func (m *O3) WithMetadata(md map[string]string) *O3 { m.metadata = md; return m }
 
 // NewO3 creates a new O3 model with default options
 func NewO3() *O3 {
-	return &O3{openAIReasoningOptions{maxCompletionTokens: 8192, reasoningEffort: "medium"}}
+	return &O3{openAIReasoningOptions{maxCompletionTokens: defaultMaxTokens("o3"), reasoningEffort: "medium"}}
 }
 
 // O3Mini represents the O3-mini reasoning model
@@ -367,14 +600,19 @@ func (m *O3Mini) Provider() ProviderType { return ProviderOpenAI }
 func (m *O3Mini) SystemPrompt() string   { return m.systemPrompt }
 func (m *O3Mini) isReasoning() bool      { return true }
 
-func (m *O3Mini) WithVersion(v string) *O3Mini          { m.modelVersion = v; return m }
-func (m *O3Mini) WithMaxCompletionTokens(n int) *O3Mini { m.maxCompletionTokens = n; return m }
-func (m *O3Mini) WithReasoningEffort(e string) *O3Mini  { m.reasoningEffort = e; return m }
-func (m *O3Mini) WithSystemPrompt(s string) *O3Mini     { m.systemPrompt = s; return m }
+func (m *O3Mini) WithVersion(v string) *O3Mini              { m.modelVersion = v; return m }
+func (m *O3Mini) WithMaxCompletionTokens(n int) *O3Mini     { m.setMaxCompletionTokens(n); return m }
+func (m *O3Mini) WithMaxTokens(n int) *O3Mini               { m.setMaxCompletionTokens(n); return m }
+func (m *O3Mini) WithReasoningEffort(e string) *O3Mini      { m.reasoningEffort = e; return m }
+func (m *O3Mini) WithReasoningSummary(s string) *O3Mini     { m.reasoningSummary = s; return m }
+func (m *O3Mini) WithSystemPrompt(s string) *O3Mini         { m.systemPrompt = s; return m }
+func (m *O3Mini) WithServiceTier(t string) *O3Mini          { m.serviceTier = t; return m }
+func (m *O3Mini) WithStore(store bool) *O3Mini              { m.store = &store; return m }
+func (m *O3Mini) WithMetadata(md map[string]string) *O3Mini { m.metadata = md; return m }
 
 // NewO3Mini creates a new O3-mini model with default options
 func NewO3Mini() *O3Mini {
-	return &O3Mini{openAIReasoningOptions{maxCompletionTokens: 4096, reasoningEffort: "medium"}}
+	return &O3Mini{openAIReasoningOptions{maxCompletionTokens: defaultMaxTokens("o3-mini"), reasoningEffort: "medium"}}
 }
 
 // O4Mini represents the O4-mini reasoning model
@@ -391,14 +629,19 @@ func (m *O4Mini) Provider() ProviderType { return ProviderOpenAI }
 func (m *O4Mini) SystemPrompt() string   { return m.systemPrompt }
 func (m *O4Mini) isReasoning() bool      { return true }
 
-func (m *O4Mini) WithVersion(v string) *O4Mini          { m.modelVersion = v; return m }
-func (m *O4Mini) WithMaxCompletionTokens(n int) *O4Mini { m.maxCompletionTokens = n; return m }
-func (m *O4Mini) WithReasoningEffort(e string) *O4Mini  { m.reasoningEffort = e; return m }
-func (m *O4Mini) WithSystemPrompt(s string) *O4Mini     { m.systemPrompt = s; return m }
+func (m *O4Mini) WithVersion(v string) *O4Mini              { m.modelVersion = v; return m }
+func (m *O4Mini) WithMaxCompletionTokens(n int) *O4Mini     { m.setMaxCompletionTokens(n); return m }
+func (m *O4Mini) WithMaxTokens(n int) *O4Mini               { m.setMaxCompletionTokens(n); return m }
+func (m *O4Mini) WithReasoningEffort(e string) *O4Mini      { m.reasoningEffort = e; return m }
+func (m *O4Mini) WithReasoningSummary(s string) *O4Mini     { m.reasoningSummary = s; return m }
+func (m *O4Mini) WithSystemPrompt(s string) *O4Mini         { m.systemPrompt = s; return m }
+func (m *O4Mini) WithServiceTier(t string) *O4Mini          { m.serviceTier = t; return m }
+func (m *O4Mini) WithStore(store bool) *O4Mini              { m.store = &store; return m }
+func (m *O4Mini) WithMetadata(md map[string]string) *O4Mini { m.metadata = md; return m }
 
 // NewO4Mini creates a new O4-mini model with default options
 func NewO4Mini() *O4Mini {
-	return &O4Mini{openAIReasoningOptions{maxCompletionTokens: 4096, reasoningEffort: "medium"}}
+	return &O4Mini{openAIReasoningOptions{maxCompletionTokens: defaultMaxTokens("o4-mini"), reasoningEffort: "medium"}}
 }
 
 // GPT5 represents the GPT-5 reasoning model
@@ -409,13 +652,18 @@ func (m *GPT5) Provider() ProviderType { return ProviderOpenAI }
 func (m *GPT5) SystemPrompt() string   { return m.systemPrompt }
 func (m *GPT5) isReasoning() bool      { return true }
 
-func (m *GPT5) WithMaxCompletionTokens(n int) *GPT5 { m.maxCompletionTokens = n; return m }
-func (m *GPT5) WithReasoningEffort(e string) *GPT5  { m.reasoningEffort = e; return m }
-func (m *GPT5) WithSystemPrompt(s string) *GPT5     { m.systemPrompt = s; return m }
+func (m *GPT5) WithMaxCompletionTokens(n int) *GPT5     { m.setMaxCompletionTokens(n); return m }
+func (m *GPT5) WithMaxTokens(n int) *GPT5               { m.setMaxCompletionTokens(n); return m }
+func (m *GPT5) WithReasoningEffort(e string) *GPT5      { m.reasoningEffort = e; return m }
+func (m *GPT5) WithReasoningSummary(s string) *GPT5     { m.reasoningSummary = s; return m }
+func (m *GPT5) WithSystemPrompt(s string) *GPT5         { m.systemPrompt = s; return m }
+func (m *GPT5) WithServiceTier(t string) *GPT5          { m.serviceTier = t; return m }
+func (m *GPT5) WithStore(store bool) *GPT5              { m.store = &store; return m }
+func (m *GPT5) WithMetadata(md map[string]string) *GPT5 { m.metadata = md; return m }
 
 // NewGPT5 creates a new GPT-5 model with default options
 func NewGPT5() *GPT5 {
-	return &GPT5{openAIReasoningOptions{maxCompletionTokens: 8192, reasoningEffort: "medium"}}
+	return &GPT5{openAIReasoningOptions{maxCompletionTokens: defaultMaxTokens("gpt-5"), reasoningEffort: "medium"}}
 }
 
 // GPT5Mini represents the GPT-5-mini reasoning model
@@ -426,13 +674,18 @@ func (m *GPT5Mini) Provider() ProviderType { return ProviderOpenAI }
 func (m *GPT5Mini) SystemPrompt() string   { return m.systemPrompt }
 func (m *GPT5Mini) isReasoning() bool      { return true }
 
-func (m *GPT5Mini) WithMaxCompletionTokens(n int) *GPT5Mini { m.maxCompletionTokens = n; return m }
-func (m *GPT5Mini) WithReasoningEffort(e string) *GPT5Mini  { m.reasoningEffort = e; return m }
-func (m *GPT5Mini) WithSystemPrompt(s string) *GPT5Mini     { m.systemPrompt = s; return m }
+func (m *GPT5Mini) WithMaxCompletionTokens(n int) *GPT5Mini     { m.setMaxCompletionTokens(n); return m }
+func (m *GPT5Mini) WithMaxTokens(n int) *GPT5Mini               { m.setMaxCompletionTokens(n); return m }
+func (m *GPT5Mini) WithReasoningEffort(e string) *GPT5Mini      { m.reasoningEffort = e; return m }
+func (m *GPT5Mini) WithReasoningSummary(s string) *GPT5Mini     { m.reasoningSummary = s; return m }
+func (m *GPT5Mini) WithSystemPrompt(s string) *GPT5Mini         { m.systemPrompt = s; return m }
+func (m *GPT5Mini) WithServiceTier(t string) *GPT5Mini          { m.serviceTier = t; return m }
+func (m *GPT5Mini) WithStore(store bool) *GPT5Mini              { m.store = &store; return m }
+func (m *GPT5Mini) WithMetadata(md map[string]string) *GPT5Mini { m.metadata = md; return m }
 
 // NewGPT5Mini creates a new GPT-5-mini model with default options
 func NewGPT5Mini() *GPT5Mini {
-	return &GPT5Mini{openAIReasoningOptions{maxCompletionTokens: 4096, reasoningEffort: "medium"}}
+	return &GPT5Mini{openAIReasoningOptions{maxCompletionTokens: defaultMaxTokens("gpt-5-mini"), reasoningEffort: "medium"}}
 }
 
 // GPT5Nano represents the GPT-5-nano reasoning model
@@ -443,13 +696,18 @@ func (m *GPT5Nano) Provider() ProviderType { return ProviderOpenAI }
 func (m *GPT5Nano) SystemPrompt() string   { return m.systemPrompt }
 func (m *GPT5Nano) isReasoning() bool      { return true }
 
-func (m *GPT5Nano) WithMaxCompletionTokens(n int) *GPT5Nano { m.maxCompletionTokens = n; return m }
-func (m *GPT5Nano) WithReasoningEffort(e string) *GPT5Nano  { m.reasoningEffort = e; return m }
-func (m *GPT5Nano) WithSystemPrompt(s string) *GPT5Nano     { m.systemPrompt = s; return m }
+func (m *GPT5Nano) WithMaxCompletionTokens(n int) *GPT5Nano     { m.setMaxCompletionTokens(n); return m }
+func (m *GPT5Nano) WithMaxTokens(n int) *GPT5Nano               { m.setMaxCompletionTokens(n); return m }
+func (m *GPT5Nano) WithReasoningEffort(e string) *GPT5Nano      { m.reasoningEffort = e; return m }
+func (m *GPT5Nano) WithReasoningSummary(s string) *GPT5Nano     { m.reasoningSummary = s; return m }
+func (m *GPT5Nano) WithSystemPrompt(s string) *GPT5Nano         { m.systemPrompt = s; return m }
+func (m *GPT5Nano) WithServiceTier(t string) *GPT5Nano          { m.serviceTier = t; return m }
+func (m *GPT5Nano) WithStore(store bool) *GPT5Nano              { m.store = &store; return m }
+func (m *GPT5Nano) WithMetadata(md map[string]string) *GPT5Nano { m.metadata = md; return m }
 
 // NewGPT5Nano creates a new GPT-5-nano model with default options
 func NewGPT5Nano() *GPT5Nano {
-	return &GPT5Nano{openAIReasoningOptions{maxCompletionTokens: 4096, reasoningEffort: "medium"}}
+	return &GPT5Nano{openAIReasoningOptions{maxCompletionTokens: defaultMaxTokens("gpt-5-nano"), reasoningEffort: "medium"}}
 }
 
 // GPT5Pro represents the GPT-5-pro reasoning model
@@ -460,13 +718,18 @@ func (m *GPT5Pro) Provider() ProviderType { return ProviderOpenAI }
 func (m *GPT5Pro) SystemPrompt() string   { return m.systemPrompt }
 func (m *GPT5Pro) isReasoning() bool      { return true }
 
-func (m *GPT5Pro) WithMaxCompletionTokens(n int) *GPT5Pro { m.maxCompletionTokens = n; return m }
-func (m *GPT5Pro) WithReasoningEffort(e string) *GPT5Pro  { m.reasoningEffort = e; return m }
-func (m *GPT5Pro) WithSystemPrompt(s string) *GPT5Pro     { m.systemPrompt = s; return m }
+func (m *GPT5Pro) WithMaxCompletionTokens(n int) *GPT5Pro     { m.setMaxCompletionTokens(n); return m }
+func (m *GPT5Pro) WithMaxTokens(n int) *GPT5Pro               { m.setMaxCompletionTokens(n); return m }
+func (m *GPT5Pro) WithReasoningEffort(e string) *GPT5Pro      { m.reasoningEffort = e; return m }
+func (m *GPT5Pro) WithReasoningSummary(s string) *GPT5Pro     { m.reasoningSummary = s; return m }
+func (m *GPT5Pro) WithSystemPrompt(s string) *GPT5Pro         { m.systemPrompt = s; return m }
+func (m *GPT5Pro) WithServiceTier(t string) *GPT5Pro          { m.serviceTier = t; return m }
+func (m *GPT5Pro) WithStore(store bool) *GPT5Pro              { m.store = &store; return m }
+func (m *GPT5Pro) WithMetadata(md map[string]string) *GPT5Pro { m.metadata = md; return m }
 
 // NewGPT5Pro creates a new GPT-5-pro model with default options
 func NewGPT5Pro() *GPT5Pro {
-	return &GPT5Pro{openAIReasoningOptions{maxCompletionTokens: 8192, reasoningEffort: "high"}}
+	return &GPT5Pro{openAIReasoningOptions{maxCompletionTokens: defaultMaxTokens("gpt-5-pro"), reasoningEffort: "high"}}
 }
 
 // GPT5Turbo represents the GPT-5-turbo reasoning model
@@ -477,13 +740,18 @@ func (m *GPT5Turbo) Provider() ProviderType { return ProviderOpenAI }
 func (m *GPT5Turbo) SystemPrompt() string   { return m.systemPrompt }
 func (m *GPT5Turbo) isReasoning() bool      { return true }
 
-func (m *GPT5Turbo) WithMaxCompletionTokens(n int) *GPT5Turbo { m.maxCompletionTokens = n; return m }
-func (m *GPT5Turbo) WithReasoningEffort(e string) *GPT5Turbo  { m.reasoningEffort = e; return m }
-func (m *GPT5Turbo) WithSystemPrompt(s string) *GPT5Turbo     { m.systemPrompt = s; return m }
+func (m *GPT5Turbo) WithMaxCompletionTokens(n int) *GPT5Turbo     { m.setMaxCompletionTokens(n); return m }
+func (m *GPT5Turbo) WithMaxTokens(n int) *GPT5Turbo               { m.setMaxCompletionTokens(n); return m }
+func (m *GPT5Turbo) WithReasoningEffort(e string) *GPT5Turbo      { m.reasoningEffort = e; return m }
+func (m *GPT5Turbo) WithReasoningSummary(s string) *GPT5Turbo     { m.reasoningSummary = s; return m }
+func (m *GPT5Turbo) WithSystemPrompt(s string) *GPT5Turbo         { m.systemPrompt = s; return m }
+func (m *GPT5Turbo) WithServiceTier(t string) *GPT5Turbo          { m.serviceTier = t; return m }
+func (m *GPT5Turbo) WithStore(store bool) *GPT5Turbo              { m.store = &store; return m }
+func (m *GPT5Turbo) WithMetadata(md map[string]string) *GPT5Turbo { m.metadata = md; return m }
 
 // NewGPT5Turbo creates a new GPT-5-turbo model with default options
 func NewGPT5Turbo() *GPT5Turbo {
-	return &GPT5Turbo{openAIReasoningOptions{maxCompletionTokens: 8192, reasoningEffort: "medium"}}
+	return &GPT5Turbo{openAIReasoningOptions{maxCompletionTokens: defaultMaxTokens("gpt-5-turbo"), reasoningEffort: "medium"}}
 }
 
 // GPT51 represents the GPT-5.1 reasoning model
@@ -494,13 +762,18 @@ func (m *GPT51) Provider() ProviderType { return ProviderOpenAI }
 func (m *GPT51) SystemPrompt() string   { return m.systemPrompt }
 func (m *GPT51) isReasoning() bool      { return true }
 
-func (m *GPT51) WithMaxCompletionTokens(n int) *GPT51 { m.maxCompletionTokens = n; return m }
-func (m *GPT51) WithReasoningEffort(e string) *GPT51  { m.reasoningEffort = e; return m }
-func (m *GPT51) WithSystemPrompt(s string) *GPT51     { m.systemPrompt = s; return m }
+func (m *GPT51) WithMaxCompletionTokens(n int) *GPT51     { m.setMaxCompletionTokens(n); return m }
+func (m *GPT51) WithMaxTokens(n int) *GPT51               { m.setMaxCompletionTokens(n); return m }
+func (m *GPT51) WithReasoningEffort(e string) *GPT51      { m.reasoningEffort = e; return m }
+func (m *GPT51) WithReasoningSummary(s string) *GPT51     { m.reasoningSummary = s; return m }
+func (m *GPT51) WithSystemPrompt(s string) *GPT51         { m.systemPrompt = s; return m }
+func (m *GPT51) WithServiceTier(t string) *GPT51          { m.serviceTier = t; return m }
+func (m *GPT51) WithStore(store bool) *GPT51              { m.store = &store; return m }
+func (m *GPT51) WithMetadata(md map[string]string) *GPT51 { m.metadata = md; return m }
 
 // NewGPT51 creates a new GPT-5.1 model with default options
 func NewGPT51() *GPT51 {
-	return &GPT51{openAIReasoningOptions{maxCompletionTokens: 8192, reasoningEffort: "medium"}}
+	return &GPT51{openAIReasoningOptions{maxCompletionTokens: defaultMaxTokens("gpt-5.1"), reasoningEffort: "medium"}}
 }
 
 // GPT51Mini represents the GPT-5.1-mini reasoning model
@@ -511,13 +784,18 @@ func (m *GPT51Mini) Provider() ProviderType { return ProviderOpenAI }
 func (m *GPT51Mini) SystemPrompt() string   { return m.systemPrompt }
 func (m *GPT51Mini) isReasoning() bool      { return true }
 
-func (m *GPT51Mini) WithMaxCompletionTokens(n int) *GPT51Mini { m.maxCompletionTokens = n; return m }
-func (m *GPT51Mini) WithReasoningEffort(e string) *GPT51Mini  { m.reasoningEffort = e; return m }
-func (m *GPT51Mini) WithSystemPrompt(s string) *GPT51Mini     { m.systemPrompt = s; return m }
+func (m *GPT51Mini) WithMaxCompletionTokens(n int) *GPT51Mini     { m.setMaxCompletionTokens(n); return m }
+func (m *GPT51Mini) WithMaxTokens(n int) *GPT51Mini               { m.setMaxCompletionTokens(n); return m }
+func (m *GPT51Mini) WithReasoningEffort(e string) *GPT51Mini      { m.reasoningEffort = e; return m }
+func (m *GPT51Mini) WithReasoningSummary(s string) *GPT51Mini     { m.reasoningSummary = s; return m }
+func (m *GPT51Mini) WithSystemPrompt(s string) *GPT51Mini         { m.systemPrompt = s; return m }
+func (m *GPT51Mini) WithServiceTier(t string) *GPT51Mini          { m.serviceTier = t; return m }
+func (m *GPT51Mini) WithStore(store bool) *GPT51Mini              { m.store = &store; return m }
+func (m *GPT51Mini) WithMetadata(md map[string]string) *GPT51Mini { m.metadata = md; return m }
 
 // NewGPT51Mini creates a new GPT-5.1-mini model with default options
 func NewGPT51Mini() *GPT51Mini {
-	return &GPT51Mini{openAIReasoningOptions{maxCompletionTokens: 4096, reasoningEffort: "medium"}}
+	return &GPT51Mini{openAIReasoningOptions{maxCompletionTokens: defaultMaxTokens("gpt-5.1-mini"), reasoningEffort: "medium"}}
 }
 
 // GPT51Nano represents the GPT-5.1-nano reasoning model
@@ -528,13 +806,18 @@ func (m *GPT51Nano) Provider() ProviderType { return ProviderOpenAI }
 func (m *GPT51Nano) SystemPrompt() string   { return m.systemPrompt }
 func (m *GPT51Nano) isReasoning() bool      { return true }
 
-func (m *GPT51Nano) WithMaxCompletionTokens(n int) *GPT51Nano { m.maxCompletionTokens = n; return m }
-func (m *GPT51Nano) WithReasoningEffort(e string) *GPT51Nano  { m.reasoningEffort = e; return m }
-func (m *GPT51Nano) WithSystemPrompt(s string) *GPT51Nano     { m.systemPrompt = s; return m }
+func (m *GPT51Nano) WithMaxCompletionTokens(n int) *GPT51Nano     { m.setMaxCompletionTokens(n); return m }
+func (m *GPT51Nano) WithMaxTokens(n int) *GPT51Nano               { m.setMaxCompletionTokens(n); return m }
+func (m *GPT51Nano) WithReasoningEffort(e string) *GPT51Nano      { m.reasoningEffort = e; return m }
+func (m *GPT51Nano) WithReasoningSummary(s string) *GPT51Nano     { m.reasoningSummary = s; return m }
+func (m *GPT51Nano) WithSystemPrompt(s string) *GPT51Nano         { m.systemPrompt = s; return m }
+func (m *GPT51Nano) WithServiceTier(t string) *GPT51Nano          { m.serviceTier = t; return m }
+func (m *GPT51Nano) WithStore(store bool) *GPT51Nano              { m.store = &store; return m }
+func (m *GPT51Nano) WithMetadata(md map[string]string) *GPT51Nano { m.metadata = md; return m }
 
 // NewGPT51Nano creates a new GPT-5.1-nano model with default options
 func NewGPT51Nano() *GPT51Nano {
-	return &GPT51Nano{openAIReasoningOptions{maxCompletionTokens: 4096, reasoningEffort: "medium"}}
+	return &GPT51Nano{openAIReasoningOptions{maxCompletionTokens: defaultMaxTokens("gpt-5.1-nano"), reasoningEffort: "medium"}}
 }
 
 // GPT51Codex represents the GPT-5.1-codex reasoning model
@@ -545,13 +828,21 @@ func (m *GPT51Codex) Provider() ProviderType { return ProviderOpenAI }
 func (m *GPT51Codex) SystemPrompt() string   { return m.systemPrompt }
 func (m *GPT51Codex) isReasoning() bool      { return true }
 
-func (m *GPT51Codex) WithMaxCompletionTokens(n int) *GPT51Codex { m.maxCompletionTokens = n; return m }
-func (m *GPT51Codex) WithReasoningEffort(e string) *GPT51Codex  { m.reasoningEffort = e; return m }
-func (m *GPT51Codex) WithSystemPrompt(s string) *GPT51Codex     { m.systemPrompt = s; return m }
+func (m *GPT51Codex) WithMaxCompletionTokens(n int) *GPT51Codex {
+	m.setMaxCompletionTokens(n)
+	return m
+}
+func (m *GPT51Codex) WithMaxTokens(n int) *GPT51Codex               { m.setMaxCompletionTokens(n); return m }
+func (m *GPT51Codex) WithReasoningEffort(e string) *GPT51Codex      { m.reasoningEffort = e; return m }
+func (m *GPT51Codex) WithReasoningSummary(s string) *GPT51Codex     { m.reasoningSummary = s; return m }
+func (m *GPT51Codex) WithSystemPrompt(s string) *GPT51Codex         { m.systemPrompt = s; return m }
+func (m *GPT51Codex) WithServiceTier(t string) *GPT51Codex          { m.serviceTier = t; return m }
+func (m *GPT51Codex) WithStore(store bool) *GPT51Codex              { m.store = &store; return m }
+func (m *GPT51Codex) WithMetadata(md map[string]string) *GPT51Codex { m.metadata = md; return m }
 
 // NewGPT51Codex creates a new GPT-5.1-codex model with default options
 func NewGPT51Codex() *GPT51Codex {
-	return &GPT51Codex{openAIReasoningOptions{maxCompletionTokens: 8192, reasoningEffort: "medium"}}
+	return &GPT51Codex{openAIReasoningOptions{maxCompletionTokens: defaultMaxTokens("gpt-5.1-codex"), reasoningEffort: "medium"}}
 }
 
 // GPT51CodexMini represents the GPT-5.1-codex-mini reasoning model
@@ -563,18 +854,25 @@ func (m *GPT51CodexMini) SystemPrompt() string   { return m.systemPrompt }
 func (m *GPT51CodexMini) isReasoning() bool      { return true }
 
 func (m *GPT51CodexMini) WithMaxCompletionTokens(n int) *GPT51CodexMini {
-	m.maxCompletionTokens = n
+	m.setMaxCompletionTokens(n)
 	return m
 }
+func (m *GPT51CodexMini) WithMaxTokens(n int) *GPT51CodexMini { m.setMaxCompletionTokens(n); return m }
 func (m *GPT51CodexMini) WithReasoningEffort(e string) *GPT51CodexMini {
 	m.reasoningEffort = e
 	return m
 }
 func (m *GPT51CodexMini) WithSystemPrompt(s string) *GPT51CodexMini { m.systemPrompt = s; return m }
+func (m *GPT51CodexMini) WithServiceTier(t string) *GPT51CodexMini  { m.serviceTier = t; return m }
+func (m *GPT51CodexMini) WithStore(store bool) *GPT51CodexMini      { m.store = &store; return m }
+func (m *GPT51CodexMini) WithMetadata(md map[string]string) *GPT51CodexMini {
+	m.metadata = md
+	return m
+}
 
 // NewGPT51CodexMini creates a new GPT-5.1-codex-mini model with default options
 func NewGPT51CodexMini() *GPT51CodexMini {
-	return &GPT51CodexMini{openAIReasoningOptions{maxCompletionTokens: 4096, reasoningEffort: "medium"}}
+	return &GPT51CodexMini{openAIReasoningOptions{maxCompletionTokens: defaultMaxTokens("gpt-5.1-codex-mini"), reasoningEffort: "medium"}}
 }
 
 // O3Pro represents the O3-pro reasoning model
@@ -585,13 +883,18 @@ func (m *O3Pro) Provider() ProviderType { return ProviderOpenAI }
 func (m *O3Pro) SystemPrompt() string   { return m.systemPrompt }
 func (m *O3Pro) isReasoning() bool      { return true }
 
-func (m *O3Pro) WithMaxCompletionTokens(n int) *O3Pro { m.maxCompletionTokens = n; return m }
-func (m *O3Pro) WithReasoningEffort(e string) *O3Pro  { m.reasoningEffort = e; return m }
-func (m *O3Pro) WithSystemPrompt(s string) *O3Pro     { m.systemPrompt = s; return m }
+func (m *O3Pro) WithMaxCompletionTokens(n int) *O3Pro     { m.setMaxCompletionTokens(n); return m }
+func (m *O3Pro) WithMaxTokens(n int) *O3Pro               { m.setMaxCompletionTokens(n); return m }
+func (m *O3Pro) WithReasoningEffort(e string) *O3Pro      { m.reasoningEffort = e; return m }
+func (m *O3Pro) WithReasoningSummary(s string) *O3Pro     { m.reasoningSummary = s; return m }
+func (m *O3Pro) WithSystemPrompt(s string) *O3Pro         { m.systemPrompt = s; return m }
+func (m *O3Pro) WithServiceTier(t string) *O3Pro          { m.serviceTier = t; return m }
+func (m *O3Pro) WithStore(store bool) *O3Pro              { m.store = &store; return m }
+func (m *O3Pro) WithMetadata(md map[string]string) *O3Pro { m.metadata = md; return m }
 
 // NewO3Pro creates a new O3-pro model with default options
 func NewO3Pro() *O3Pro {
-	return &O3Pro{openAIReasoningOptions{maxCompletionTokens: 8192, reasoningEffort: "high"}}
+	return &O3Pro{openAIReasoningOptions{maxCompletionTokens: defaultMaxTokens("o3-pro"), reasoningEffort: "high"}}
 }
 
 // O1Preview represents the O1-preview reasoning model
@@ -608,38 +911,247 @@ func (m *O1Preview) Provider() ProviderType { return ProviderOpenAI }
 func (m *O1Preview) SystemPrompt() string   { return m.systemPrompt }
 func (m *O1Preview) isReasoning() bool      { return true }
 
-func (m *O1Preview) WithVersion(v string) *O1Preview          { m.modelVersion = v; return m }
-func (m *O1Preview) WithMaxCompletionTokens(n int) *O1Preview { m.maxCompletionTokens = n; return m }
-func (m *O1Preview) WithReasoningEffort(e string) *O1Preview  { m.reasoningEffort = e; return m }
-func (m *O1Preview) WithSystemPrompt(s string) *O1Preview     { m.systemPrompt = s; return m }
+func (m *O1Preview) WithVersion(v string) *O1Preview              { m.modelVersion = v; return m }
+func (m *O1Preview) WithMaxCompletionTokens(n int) *O1Preview     { m.setMaxCompletionTokens(n); return m }
+func (m *O1Preview) WithMaxTokens(n int) *O1Preview               { m.setMaxCompletionTokens(n); return m }
+func (m *O1Preview) WithReasoningEffort(e string) *O1Preview      { m.reasoningEffort = e; return m }
+func (m *O1Preview) WithReasoningSummary(s string) *O1Preview     { m.reasoningSummary = s; return m }
+func (m *O1Preview) WithSystemPrompt(s string) *O1Preview         { m.systemPrompt = s; return m }
+func (m *O1Preview) WithServiceTier(t string) *O1Preview          { m.serviceTier = t; return m }
+func (m *O1Preview) WithStore(store bool) *O1Preview              { m.store = &store; return m }
+func (m *O1Preview) WithMetadata(md map[string]string) *O1Preview { m.metadata = md; return m }
 
 // NewO1Preview creates a new O1-preview model with default options
 func NewO1Preview() *O1Preview {
-	return &O1Preview{openAIReasoningOptions{maxCompletionTokens: 8192, reasoningEffort: "medium"}}
+	return &O1Preview{openAIReasoningOptions{maxCompletionTokens: defaultMaxTokens("o1-preview"), reasoningEffort: "medium"}}
+}
+
+// ============================================================================
+// TRANSCRIPTION MODELS (Whisper, GPT-4o Transcribe)
+// ============================================================================
+
+// Whisper1 represents the whisper-1 transcription model
+type Whisper1 struct{ openAITranscriptionOptions }
+
+func (m *Whisper1) ModelName() string                    { return "whisper-1" }
+func (m *Whisper1) Provider() ProviderType               { return ProviderOpenAI }
+func (m *Whisper1) options() *openAITranscriptionOptions { return &m.openAITranscriptionOptions }
+
+func (m *Whisper1) WithLanguage(l string) *Whisper1     { m.language = l; return m }
+func (m *Whisper1) WithPrompt(p string) *Whisper1       { m.prompt = p; return m }
+func (m *Whisper1) WithTemperature(t float64) *Whisper1 { m.temperature = t; return m }
+func (m *Whisper1) WithFilename(name string) *Whisper1  { m.filename = name; return m }
+
+// NewWhisper1 creates a new whisper-1 model with default options
+func NewWhisper1() *Whisper1 {
+	return &Whisper1{openAITranscriptionOptions{filename: "audio.mp3"}}
+}
+
+// GPT4oTranscribe represents the gpt-4o-transcribe model
+type GPT4oTranscribe struct{ openAITranscriptionOptions }
+
+func (m *GPT4oTranscribe) ModelName() string                    { return "gpt-4o-transcribe" }
+func (m *GPT4oTranscribe) Provider() ProviderType               { return ProviderOpenAI }
+func (m *GPT4oTranscribe) options() *openAITranscriptionOptions { return &m.openAITranscriptionOptions }
+
+func (m *GPT4oTranscribe) WithLanguage(l string) *GPT4oTranscribe     { m.language = l; return m }
+func (m *GPT4oTranscribe) WithPrompt(p string) *GPT4oTranscribe       { m.prompt = p; return m }
+func (m *GPT4oTranscribe) WithTemperature(t float64) *GPT4oTranscribe { m.temperature = t; return m }
+func (m *GPT4oTranscribe) WithFilename(name string) *GPT4oTranscribe  { m.filename = name; return m }
+
+// NewGPT4oTranscribe creates a new gpt-4o-transcribe model with default options
+func NewGPT4oTranscribe() *GPT4oTranscribe {
+	return &GPT4oTranscribe{openAITranscriptionOptions{filename: "audio.mp3"}}
+}
+
+// GPT4oMiniTranscribe represents the gpt-4o-mini-transcribe model
+type GPT4oMiniTranscribe struct{ openAITranscriptionOptions }
+
+func (m *GPT4oMiniTranscribe) ModelName() string      { return "gpt-4o-mini-transcribe" }
+func (m *GPT4oMiniTranscribe) Provider() ProviderType { return ProviderOpenAI }
+func (m *GPT4oMiniTranscribe) options() *openAITranscriptionOptions {
+	return &m.openAITranscriptionOptions
+}
+
+func (m *GPT4oMiniTranscribe) WithLanguage(l string) *GPT4oMiniTranscribe { m.language = l; return m }
+func (m *GPT4oMiniTranscribe) WithPrompt(p string) *GPT4oMiniTranscribe   { m.prompt = p; return m }
+func (m *GPT4oMiniTranscribe) WithTemperature(t float64) *GPT4oMiniTranscribe {
+	m.temperature = t
+	return m
+}
+func (m *GPT4oMiniTranscribe) WithFilename(name string) *GPT4oMiniTranscribe {
+	m.filename = name
+	return m
+}
+
+// NewGPT4oMiniTranscribe creates a new gpt-4o-mini-transcribe model with default options
+func NewGPT4oMiniTranscribe() *GPT4oMiniTranscribe {
+	return &GPT4oMiniTranscribe{openAITranscriptionOptions{filename: "audio.mp3"}}
+}
+
+// ============================================================================
+// IMAGE MODELS (DALL-E)
+// ============================================================================
+
+// DallE3 represents the DALL-E 3 image generation model
+type DallE3 struct{ openAIImageOptions }
+
+func (m *DallE3) ModelName() string                 { return "dall-e-3" }
+func (m *DallE3) Provider() ProviderType            { return ProviderOpenAI }
+func (m *DallE3) imageOptions() *openAIImageOptions { return &m.openAIImageOptions }
+
+func (m *DallE3) WithSize(s string) *DallE3                        { m.size = s; return m }
+func (m *DallE3) WithQuality(q string) *DallE3                     { m.quality = q; return m }
+func (m *DallE3) WithStyle(s string) *DallE3                       { m.style = s; return m }
+func (m *DallE3) WithResponseFormat(f ImageResponseFormat) *DallE3 { m.responseFormat = f; return m }
+
+// NewDallE3 creates a new DALL-E 3 model with default options
+func NewDallE3() *DallE3 {
+	return &DallE3{openAIImageOptions{size: "1024x1024", responseFormat: ImageResponseURL}}
 }
 
 // ============================================================================
 // OPENAI PROVIDER CLIENT
 // ============================================================================
 
+// openAIImageModel is an interface for image generation models
+type openAIImageModel interface {
+	ImageModel
+	imageOptions() *openAIImageOptions
+}
+
+// openAITranscriptionModel is an interface for transcription models
+type openAITranscriptionModel interface {
+	TranscriptionModel
+	options() *openAITranscriptionOptions
+}
+
 // openAIStandardModel is an interface for standard models
 type openAIStandardModel interface {
 	Model
 	isStandard() bool
+	standardOptions() *openAIStandardOptions
+}
+
+// openAIServiceTierModel is an interface for models that select a service tier
+type openAIServiceTierModel interface {
+	tier() string
+}
+
+// openAIAudioOutputModel is an interface for models that can answer with speech
+type openAIAudioOutputModel interface {
+	audioOutput() (voice, format string)
+}
+
+// openAIAudioParam validates the audio output settings of a request
+func openAIAudioParam(voice, format string) (openai.ChatCompletionAudioParam, error) {
+	switch format {
+	case "wav", "mp3", "aac", "flac", "opus", "pcm16":
+	default:
+		return openai.ChatCompletionAudioParam{}, fmt.Errorf("invalid OpenAI audio format %q: must be wav, mp3, aac, flac, opus or pcm16", format)
+	}
+	return openai.ChatCompletionAudioParam{
+		Voice:  openai.ChatCompletionAudioParamVoice(voice),
+		Format: openai.ChatCompletionAudioParamFormat(format),
+	}, nil
+}
+
+// openAIStorageModel is an interface for models that can have their
+// completions stored by OpenAI
+type openAIStorageModel interface {
+	storage() (store *bool, metadata map[string]string)
+}
+
+// validateOpenAIMetadata checks metadata against OpenAI's limits of 16 pairs,
+// 64 character keys and 512 character values
+func validateOpenAIMetadata(metadata map[string]string) error {
+	if len(metadata) > 16 {
+		return fmt.Errorf("%w: OpenAI accepts at most 16 metadata pairs, got %d", ErrOptionOutOfRange, len(metadata))
+	}
+	for k, v := range metadata {
+		if len(k) > 64 {
+			return fmt.Errorf("%w: OpenAI metadata key %q is longer than 64 characters", ErrOptionOutOfRange, k)
+		}
+		if len(v) > 512 {
+			return fmt.Errorf("%w: OpenAI metadata value for %q is longer than 512 characters", ErrOptionOutOfRange, k)
+		}
+	}
+	return nil
+}
+
+// openAIServiceTier validates a service tier
+func openAIServiceTier(tier string) (string, error) {
+	switch tier {
+	case "auto", "default", "flex", "priority":
+		return tier, nil
+	default:
+		return "", fmt.Errorf("invalid OpenAI service tier %q: must be auto, default, flex or priority", tier)
+	}
+}
+
+// openAIRejectsSystemRoles reports whether a model accepts neither the
+// "system" nor the "developer" role (o1-mini and o1-preview)
+func openAIRejectsSystemRoles(modelName string) bool {
+	return strings.HasPrefix(modelName, "o1-mini") || strings.HasPrefix(modelName, "o1-preview")
+}
+
+// openAIUsesMaxCompletionTokens reports whether a model takes its output limit
+// as max_completion_tokens. OpenAI deprecated max_tokens and rejects it for
+// reasoning models; only the legacy gpt-4 and gpt-3.5 models keep using it so
+// older OpenAI-compatible endpoints continue to work.
+func openAIUsesMaxCompletionTokens(modelName string) bool {
+	legacy := modelName == "gpt-4" ||
+		strings.HasPrefix(modelName, "gpt-4-") ||
+		strings.HasPrefix(modelName, "gpt-3.5")
+	return !legacy
+}
+
+// openAIRequestOptions returns the SDK request options for a generation with
+// the given params: capturing the HTTP response into httpResp, adding the
+// WithExtraBody fields and disabling the SDK's own retries when the request
+// opted out of them
+func openAIRequestOptions(reqOpts *generateOptions, params any, httpResp **http.Response) ([]option.RequestOption, error) {
+	opts := []option.RequestOption{option.WithResponseInto(httpResp)}
+	if reqOpts.noRetry {
+		opts = append(opts, option.WithMaxRetries(0))
+	}
+	extra, err := reqOpts.extraBodyFields(params)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range extra {
+		opts = append(opts, option.WithJSONSet(k, v))
+	}
+	return opts, nil
+}
+
+// setOpenAIMaxTokens sets the output token limit in the field the model expects
+func setOpenAIMaxTokens(params *openai.ChatCompletionNewParams, maxTokens int) {
+	if openAIUsesMaxCompletionTokens(string(params.Model)) {
+		params.MaxCompletionTokens = openai.Int(int64(maxTokens))
+	} else {
+		params.MaxTokens = openai.Int(int64(maxTokens))
+	}
 }
 
 // openAIReasoningModel is an interface for reasoning models
 type openAIReasoningModel interface {
 	Model
 	isReasoning() bool
+	summary() string
+	reasoningOptions() *openAIReasoningOptions
 }
 
 // openAIClient implements the Provider interface for OpenAI
 type openAIClient struct {
 	client      openai.Client
-	timeout     time.Duration
+	timeout     time.Duration // Zero uses the model's recommended timeout
 	logger      Logger
 	rateLimiter *rateLimiter
+
+	defaultMaxTokens   int
+	defaultTemperature *float64
+	useResponsesAPI    bool
 }
 
 // newOpenAIClient creates a new OpenAI client using the official SDK
@@ -652,46 +1164,146 @@ func newOpenAIClient(config *OpenAIConfig, logger Logger) (*openAIClient, error)
 	if config.BaseURL != "" {
 		opts = append(opts, option.WithBaseURL(config.BaseURL))
 	}
+	for key, value := range config.Headers {
+		opts = append(opts, option.WithHeader(key, value))
+	}
 
 	client := openai.NewClient(opts...)
 
-	timeout := config.Timeout
-	if timeout == 0 {
-		timeout = defaultTimeout()
-	}
-
 	return &openAIClient{
-		client:      client,
-		timeout:     timeout,
-		logger:      logger,
-		rateLimiter: newRateLimiter(config.RateLimiter, logger),
+		client:             client,
+		timeout:            config.Timeout,
+		logger:             logger,
+		rateLimiter:        newRateLimiter(ProviderOpenAI, config.RateLimiter, logger),
+		defaultMaxTokens:   config.DefaultMaxTokens,
+		defaultTemperature: config.DefaultTemperature,
+		useResponsesAPI:    config.UseResponsesAPI,
 	}, nil
 }
 
+// openAILogitBias validates a logit bias map and converts it to the request format
+func openAILogitBias(bias map[int]int) (map[string]int64, error) {
+	result := make(map[string]int64, len(bias))
+	for token, value := range bias {
+		if value < -100 || value > 100 {
+			return nil, fmt.Errorf("logit bias for token %d must be between -100 and 100, got %d", token, value)
+		}
+		result[strconv.Itoa(token)] = int64(value)
+	}
+	return result, nil
+}
+
 // Generate generates text using OpenAI's API
-func (c *openAIClient) Generate(ctx context.Context, model Model, prompt string) (*GenerationResponse, error) {
+func (c *openAIClient) Generate(ctx context.Context, model Model, prompt string, genOpts ...GenerateOption) (*GenerationResponse, error) {
 	// Verify model is for OpenAI
 	if model.Provider() != ProviderOpenAI {
 		return nil, fmt.Errorf("model %s is not an OpenAI model", model.ModelName())
 	}
 
+	reqOpts := newGenerateOptions(genOpts)
+	logger := reqOpts.logger(c.logger)
+
 	// Set timeout
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(c.timeout, ProviderOpenAI, model.ModelName()))
 	defer cancel()
 
+	if c.useResponsesAPI {
+		return c.generateWithResponses(ctx, model, prompt, reqOpts)
+	}
+
+	// Determine if this is a reasoning model
+	_, isReasoning := model.(openAIReasoningModel)
+
+	params, err := c.buildParams(model, reqOpts.systemPromptFor(model), prompt)
+	if err != nil {
+		return nil, err
+	}
+	c.applyDeterminism(&params, model, reqOpts)
+	if len(reqOpts.stopSequences) > 0 {
+		params.Stop = openai.ChatCompletionNewParamsStopUnion{OfStringArray: reqOpts.stopSequences}
+	}
+	body, err := reqOpts.encodeRequest(params)
+	if err != nil {
+		return nil, err
+	}
+	reqOpts.inspectRequest(ProviderOpenAI, body)
+	if reqOpts.dryRun {
+		return dryRunResponse(model, body)
+	}
+
+	logger.Debug().
+		Str("model", model.ModelName()).
+		Bool("is_reasoning_model", isReasoning).
+		Float("temperature", params.Temperature.Value).
+		Float("top_p", params.TopP.Value).
+		Msg("Making OpenAI API request")
+
+	// Make request with rate limit handling
+	var resp *openai.ChatCompletion
+	var httpResp *http.Response
+	reqOptions, err := openAIRequestOptions(reqOpts, params, &httpResp)
+	if err != nil {
+		return nil, err
+	}
+	err = c.rateLimiter.ExecuteRequest(ctx, model.ModelName(), reqOpts, func() error {
+		var reqErr error
+		resp, reqErr = c.client.Chat.Completions.New(ctx, params, reqOptions...)
+		return reqErr
+	})
+	if err != nil {
+		logger.Error().
+			Err(err).
+			Str("model", model.ModelName()).
+			Bool("is_reasoning_model", isReasoning).
+			Str("prompt_preview", reqOpts.promptPreview(prompt)).
+			Msg("OpenAI generation failed")
+		return nil, fmt.Errorf("OpenAI generation failed: %w", err)
+	}
+
+	response, err := buildOpenAIResponse(resp, isReasoning, reqOpts.minimalResponse)
+	if err != nil {
+		return nil, err
+	}
+	if httpResp != nil {
+		addRateLimitHeaders(response.Metadata, httpResp.Header)
+	}
+
+	if reqOpts.includeRaw {
+		response.RawJSON = json.RawMessage(resp.RawJSON())
+	}
+
+	logger.Debug().
+		Str("model", resp.Model).
+		Bool("is_reasoning_model", isReasoning).
+		Int64("prompt_tokens", resp.Usage.PromptTokens).
+		Int64("completion_tokens", resp.Usage.CompletionTokens).
+		Int64("total_tokens", resp.Usage.TotalTokens).
+		Msg("OpenAI generation completed")
+
+	return response, nil
+}
+
+// buildParams builds the Chat Completions parameters for a model, system
+// prompt and prompt
+func (c *openAIClient) buildParams(model Model, system, prompt string) (openai.ChatCompletionNewParams, error) {
 	// Determine if this is a reasoning model
 	_, isReasoning := model.(openAIReasoningModel)
 
 	// Build messages with optional system prompt
 	var messages []openai.ChatCompletionMessageParamUnion
 
-	if model.SystemPrompt() != "" {
-		if isReasoning {
+	if system != "" {
+		switch {
+		case isReasoning && openAIRejectsSystemRoles(model.ModelName()):
+			// Early reasoning models reject both "system" and "developer",
+			// so the system prompt is folded into the user message
+			prompt = system + "\n\n" + prompt
+		case isReasoning:
 			// Reasoning models use "developer" role instead of "system"
-			messages = append(messages, openai.DeveloperMessage(model.SystemPrompt()))
-		} else {
+			messages = append(messages, openai.DeveloperMessage(system))
+		default:
 			// Standard models use "system" role
-			messages = append(messages, openai.SystemMessage(model.SystemPrompt()))
+			messages = append(messages, openai.SystemMessage(system))
 		}
 	}
 	messages = append(messages, openai.UserMessage(prompt))
@@ -702,278 +1314,757 @@ func (c *openAIClient) Generate(ctx context.Context, model Model, prompt string)
 		Messages: messages,
 	}
 
-	// Apply options based on model type
+	// Apply the model's own options
 	switch m := model.(type) {
-	// Standard models
-	case *GPT4o:
-		if m.maxTokens > 0 {
-			params.MaxTokens = openai.Int(int64(m.maxTokens))
-		}
-		if m.temperature > 0 {
-			params.Temperature = openai.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = openai.Float(m.topP)
-		}
-	case *GPT4oMini:
-		if m.maxTokens > 0 {
-			params.MaxTokens = openai.Int(int64(m.maxTokens))
-		}
-		if m.temperature > 0 {
-			params.Temperature = openai.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = openai.Float(m.topP)
-		}
-	case *GPT4Turbo:
-		if m.maxTokens > 0 {
-			params.MaxTokens = openai.Int(int64(m.maxTokens))
-		}
-		if m.temperature > 0 {
-			params.Temperature = openai.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = openai.Float(m.topP)
-		}
-	case *GPT4:
-		if m.maxTokens > 0 {
-			params.MaxTokens = openai.Int(int64(m.maxTokens))
-		}
-		if m.temperature > 0 {
-			params.Temperature = openai.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = openai.Float(m.topP)
-		}
-	case *GPT41:
-		if m.maxTokens > 0 {
-			params.MaxTokens = openai.Int(int64(m.maxTokens))
-		}
-		if m.temperature > 0 {
-			params.Temperature = openai.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = openai.Float(m.topP)
-		}
-	case *GPT41Mini:
-		if m.maxTokens > 0 {
-			params.MaxTokens = openai.Int(int64(m.maxTokens))
-		}
-		if m.temperature > 0 {
-			params.Temperature = openai.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = openai.Float(m.topP)
-		}
-	case *GPT41Nano:
-		if m.maxTokens > 0 {
-			params.MaxTokens = openai.Int(int64(m.maxTokens))
-		}
-		if m.temperature > 0 {
-			params.Temperature = openai.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = openai.Float(m.topP)
-		}
-	case *GPT35Turbo:
-		if m.maxTokens > 0 {
-			params.MaxTokens = openai.Int(int64(m.maxTokens))
-		}
-		if m.temperature > 0 {
-			params.Temperature = openai.Float(m.temperature)
-		}
-		if m.topP > 0 {
-			params.TopP = openai.Float(m.topP)
+	case openAIStandardModel:
+		if err := m.standardOptions().applyTo(&params); err != nil {
+			return openai.ChatCompletionNewParams{}, err
 		}
+	case openAIReasoningModel:
+		m.reasoningOptions().applyTo(&params)
+	}
 
-	// Reasoning models
-	case *O1:
-		if m.maxCompletionTokens > 0 {
-			params.MaxCompletionTokens = openai.Int(int64(m.maxCompletionTokens))
-		}
-		if m.reasoningEffort != "" {
-			params.ReasoningEffort = shared.ReasoningEffort(m.reasoningEffort)
-		}
-	case *O1Mini:
-		if m.maxCompletionTokens > 0 {
-			params.MaxCompletionTokens = openai.Int(int64(m.maxCompletionTokens))
+	// Apply the requested service tier
+	if m, ok := model.(openAIServiceTierModel); ok && m.tier() != "" {
+		tier, err := openAIServiceTier(m.tier())
+		if err != nil {
+			return openai.ChatCompletionNewParams{}, err
 		}
-		if m.reasoningEffort != "" {
-			params.ReasoningEffort = shared.ReasoningEffort(m.reasoningEffort)
-		}
-	case *O1Pro:
-		if m.maxCompletionTokens > 0 {
-			params.MaxCompletionTokens = openai.Int(int64(m.maxCompletionTokens))
-		}
-		if m.reasoningEffort != "" {
-			params.ReasoningEffort = shared.ReasoningEffort(m.reasoningEffort)
-		}
-	case *O3:
-		if m.maxCompletionTokens > 0 {
-			params.MaxCompletionTokens = openai.Int(int64(m.maxCompletionTokens))
-		}
-		if m.reasoningEffort != "" {
-			params.ReasoningEffort = shared.ReasoningEffort(m.reasoningEffort)
-		}
-	case *O3Mini:
-		if m.maxCompletionTokens > 0 {
-			params.MaxCompletionTokens = openai.Int(int64(m.maxCompletionTokens))
-		}
-		if m.reasoningEffort != "" {
-			params.ReasoningEffort = shared.ReasoningEffort(m.reasoningEffort)
-		}
-	case *O4Mini:
-		if m.maxCompletionTokens > 0 {
-			params.MaxCompletionTokens = openai.Int(int64(m.maxCompletionTokens))
-		}
-		if m.reasoningEffort != "" {
-			params.ReasoningEffort = shared.ReasoningEffort(m.reasoningEffort)
-		}
-	case *GPT5:
-		if m.maxCompletionTokens > 0 {
-			params.MaxCompletionTokens = openai.Int(int64(m.maxCompletionTokens))
-		}
-		if m.reasoningEffort != "" {
-			params.ReasoningEffort = shared.ReasoningEffort(m.reasoningEffort)
-		}
-	case *GPT5Mini:
-		if m.maxCompletionTokens > 0 {
-			params.MaxCompletionTokens = openai.Int(int64(m.maxCompletionTokens))
+		params.ServiceTier = openai.ChatCompletionNewParamsServiceTier(tier)
+	}
+
+	// Ask for a spoken reply alongside the text
+	if m, ok := model.(openAIAudioOutputModel); ok {
+		if voice, format := m.audioOutput(); voice != "" {
+			audio, err := openAIAudioParam(voice, format)
+			if err != nil {
+				return openai.ChatCompletionNewParams{}, err
+			}
+			params.Modalities = []string{"text", "audio"}
+			params.Audio = audio
 		}
-		if m.reasoningEffort != "" {
-			params.ReasoningEffort = shared.ReasoningEffort(m.reasoningEffort)
+	}
+
+	// Store the completion for evals and distillation, tagged with metadata
+	if m, ok := model.(openAIStorageModel); ok {
+		store, metadata := m.storage()
+		if store != nil {
+			params.Store = openai.Bool(*store)
 		}
-	case *GPT5Nano:
-		if m.maxCompletionTokens > 0 {
-			params.MaxCompletionTokens = openai.Int(int64(m.maxCompletionTokens))
+		if len(metadata) > 0 {
+			if err := validateOpenAIMetadata(metadata); err != nil {
+				return openai.ChatCompletionNewParams{}, err
+			}
+			params.Metadata = shared.Metadata(metadata)
 		}
-		if m.reasoningEffort != "" {
-			params.ReasoningEffort = shared.ReasoningEffort(m.reasoningEffort)
+	}
+
+	// Apply config-level defaults to options the model didn't set explicitly
+	if m, ok := model.(explicitOptionsModel); ok {
+		explicit := m.explicitOptions()
+		if !explicit.maxTokens && c.defaultMaxTokens > 0 {
+			setOpenAIMaxTokens(&params, c.defaultMaxTokens)
 		}
-	case *GPT5Pro:
-		if m.maxCompletionTokens > 0 {
-			params.MaxCompletionTokens = openai.Int(int64(m.maxCompletionTokens))
+		if !explicit.temperature && c.defaultTemperature != nil {
+			params.Temperature = openai.Float(*c.defaultTemperature)
 		}
-		if m.reasoningEffort != "" {
-			params.ReasoningEffort = shared.ReasoningEffort(m.reasoningEffort)
+	}
+
+	if err := validateSampling(ProviderOpenAI, params.Temperature.Value, 2, params.TopP.Value); err != nil {
+		return openai.ChatCompletionNewParams{}, err
+	}
+	return params, nil
+}
+
+// applyTo sets the options of a standard model on the request
+func (o *openAIStandardOptions) applyTo(params *openai.ChatCompletionNewParams) error {
+	if o.maxTokens > 0 {
+		setOpenAIMaxTokens(params, o.maxTokens)
+	}
+	if o.temperatureSet || o.temperature > 0 {
+		params.Temperature = openai.Float(o.temperature)
+	}
+	if o.topP > 0 {
+		params.TopP = openai.Float(o.topP)
+	}
+	if len(o.logitBias) > 0 {
+		bias, err := openAILogitBias(o.logitBias)
+		if err != nil {
+			return err
 		}
-	case *GPT5Turbo:
-		if m.maxCompletionTokens > 0 {
-			params.MaxCompletionTokens = openai.Int(int64(m.maxCompletionTokens))
+		params.LogitBias = bias
+	}
+	return nil
+}
+
+// applyTo sets the options of a reasoning model on the request
+func (o *openAIReasoningOptions) applyTo(params *openai.ChatCompletionNewParams) {
+	if o.maxCompletionTokens > 0 {
+		setOpenAIMaxTokens(params, o.maxCompletionTokens)
+	}
+	if o.reasoningEffort != "" {
+		params.ReasoningEffort = shared.ReasoningEffort(o.reasoningEffort)
+	}
+}
+
+// applyDeterminism sets the request's seed and, in deterministic mode, a
+// temperature of 0, which reasoning models don't accept
+func (c *openAIClient) applyDeterminism(params *openai.ChatCompletionNewParams, model Model, reqOpts *generateOptions) {
+	if seed, ok := reqOpts.samplingSeed(); ok {
+		params.Seed = openai.Int(seed)
+	}
+	if !reqOpts.deterministic {
+		return
+	}
+	if _, isReasoning := model.(openAIReasoningModel); isReasoning {
+		warnNotDeterministic(c.logger, model, "reasoning models don't accept a temperature")
+		return
+	}
+	params.Temperature = openai.Float(0)
+}
+
+// buildOpenAIResponse converts a Chat Completions response into a GenerationResponse
+func buildOpenAIResponse(resp *openai.ChatCompletion, isReasoning, minimal bool) (*GenerationResponse, error) {
+	usage := TokenUsage{
+		PromptTokens:       int(resp.Usage.PromptTokens),
+		CompletionTokens:   int(resp.Usage.CompletionTokens),
+		TotalTokens:        int(resp.Usage.TotalTokens),
+		CachedPromptTokens: int(resp.Usage.PromptTokensDetails.CachedTokens),
+		ReasoningTokens:    int(resp.Usage.CompletionTokensDetails.ReasoningTokens),
+	}
+
+	if len(resp.Choices) == 0 {
+		return nil, withPartialUsage(fmt.Errorf("no response choices returned from OpenAI"), usage)
+	}
+
+	choice := resp.Choices[0]
+
+	// Build response
+	response := &GenerationResponse{
+		Text:         choice.Message.Content,
+		Model:        resp.Model,
+		FinishReason: string(choice.FinishReason),
+		Usage:        usage,
+	}
+
+	// OpenAI reports refusals with a "stop" finish reason, so the refusal
+	// message is what tells them apart
+	if choice.Message.Refusal != "" {
+		response.Refusal = choice.Message.Refusal
+		response.FinishReason = "refusal"
+	}
+
+	// Spoken replies carry their text as the audio transcript
+	if choice.Message.Audio.Data != "" {
+		audio, err := base64.StdEncoding.DecodeString(choice.Message.Audio.Data)
+		if err != nil {
+			return nil, withPartialUsage(fmt.Errorf("failed to decode OpenAI audio output: %w", err), usage)
 		}
-		if m.reasoningEffort != "" {
-			params.ReasoningEffort = shared.ReasoningEffort(m.reasoningEffort)
+		response.Audio = audio
+		if response.Text == "" {
+			response.Text = choice.Message.Audio.Transcript
 		}
-	case *GPT51:
-		if m.maxCompletionTokens > 0 {
-			params.MaxCompletionTokens = openai.Int(int64(m.maxCompletionTokens))
+	}
+	if minimal {
+		return response, nil
+	}
+	response.Metadata = map[string]string{
+		"provider":           "openai",
+		"model":              resp.Model,
+		"is_reasoning_model": fmt.Sprintf("%t", isReasoning),
+	}
+
+	// Add reasoning tokens to metadata if available
+	if resp.Usage.CompletionTokensDetails.ReasoningTokens > 0 {
+		response.Metadata["reasoning_tokens"] = fmt.Sprintf("%d", resp.Usage.CompletionTokensDetails.ReasoningTokens)
+	}
+
+	// Record the tier that actually served the request
+	if resp.ServiceTier != "" {
+		response.Metadata["service_tier"] = string(resp.ServiceTier)
+	}
+
+	return response, nil
+}
+
+// generateWithResponses generates a response through the Responses API
+func (c *openAIClient) generateWithResponses(ctx context.Context, model Model, prompt string, reqOpts *generateOptions) (*GenerationResponse, error) {
+	logger := reqOpts.logger(c.logger)
+	_, isReasoning := model.(openAIReasoningModel)
+
+	params, err := c.buildResponsesParams(model, reqOpts.systemPromptFor(model), prompt)
+	if err != nil {
+		return nil, err
+	}
+	c.applyResponsesDeterminism(&params, model, reqOpts)
+	if len(reqOpts.stopSequences) > 0 {
+		warnStopSequencesIgnored(c.logger, model, "the Responses API doesn't accept stop sequences")
+	}
+	body, err := reqOpts.encodeRequest(params)
+	if err != nil {
+		return nil, err
+	}
+	reqOpts.inspectRequest(ProviderOpenAI, body)
+	if reqOpts.dryRun {
+		return dryRunResponse(model, body)
+	}
+
+	logger.Debug().
+		Str("model", model.ModelName()).
+		Bool("is_reasoning_model", isReasoning).
+		Msg("Making OpenAI Responses API request")
+
+	var resp *responses.Response
+	var httpResp *http.Response
+	reqOptions, err := openAIRequestOptions(reqOpts, params, &httpResp)
+	if err != nil {
+		return nil, err
+	}
+	err = c.rateLimiter.ExecuteRequest(ctx, model.ModelName(), reqOpts, func() error {
+		var reqErr error
+		resp, reqErr = c.client.Responses.New(ctx, params, reqOptions...)
+		return reqErr
+	})
+	if err != nil {
+		logger.Error().
+			Err(err).
+			Str("model", model.ModelName()).
+			Bool("is_reasoning_model", isReasoning).
+			Str("prompt_preview", reqOpts.promptPreview(prompt)).
+			Msg("OpenAI generation failed")
+		return nil, fmt.Errorf("OpenAI generation failed: %w", err)
+	}
+
+	response, err := buildOpenAIResponsesResponse(resp, isReasoning, reqOpts.minimalResponse)
+	if err != nil {
+		return nil, err
+	}
+	if httpResp != nil {
+		addRateLimitHeaders(response.Metadata, httpResp.Header)
+	}
+
+	if reqOpts.includeRaw {
+		response.RawJSON = json.RawMessage(resp.RawJSON())
+	}
+
+	logger.Debug().
+		Str("model", string(resp.Model)).
+		Bool("is_reasoning_model", isReasoning).
+		Int64("prompt_tokens", resp.Usage.InputTokens).
+		Int64("completion_tokens", resp.Usage.OutputTokens).
+		Int64("total_tokens", resp.Usage.TotalTokens).
+		Msg("OpenAI generation completed")
+
+	return response, nil
+}
+
+// applyResponsesDeterminism is applyDeterminism for the Responses API, which
+// has no seed parameter
+func (c *openAIClient) applyResponsesDeterminism(params *responses.ResponseNewParams, model Model, reqOpts *generateOptions) {
+	if _, ok := reqOpts.samplingSeed(); ok {
+		warnNotDeterministic(c.logger, model, "the Responses API doesn't accept a seed")
+	}
+	if !reqOpts.deterministic {
+		return
+	}
+	if _, isReasoning := model.(openAIReasoningModel); isReasoning {
+		warnNotDeterministic(c.logger, model, "reasoning models don't accept a temperature")
+		return
+	}
+	params.Temperature = openai.Float(0)
+}
+
+// buildResponsesParams converts the Chat Completions parameters for a model
+// into Responses API parameters
+func (c *openAIClient) buildResponsesParams(model Model, system, prompt string) (responses.ResponseNewParams, error) {
+	chat, err := c.buildParams(model, system, prompt)
+	if err != nil {
+		return responses.ResponseNewParams{}, err
+	}
+	if len(chat.LogitBias) > 0 {
+		return responses.ResponseNewParams{}, fmt.Errorf("logit bias is not supported by the OpenAI Responses API")
+	}
+	if len(chat.Modalities) > 0 {
+		return responses.ResponseNewParams{}, fmt.Errorf("audio output is not supported by the OpenAI Responses API")
+	}
+
+	params := responses.ResponseNewParams{
+		Model:       shared.ResponsesModel(model.ModelName()),
+		Temperature: chat.Temperature,
+		TopP:        chat.TopP,
+		ServiceTier: responses.ResponseNewParamsServiceTier(chat.ServiceTier),
+		Store:       chat.Store,
+		Metadata:    chat.Metadata,
+	}
+
+	if system != "" {
+		if openAIRejectsSystemRoles(model.ModelName()) {
+			prompt = system + "\n\n" + prompt
+		} else {
+			params.Instructions = openai.String(system)
 		}
-		if m.reasoningEffort != "" {
-			params.ReasoningEffort = shared.ReasoningEffort(m.reasoningEffort)
+	}
+	params.Input = responses.ResponseNewParamsInputUnion{OfString: openai.String(prompt)}
+
+	if chat.MaxCompletionTokens.Valid() {
+		params.MaxOutputTokens = chat.MaxCompletionTokens
+	} else if chat.MaxTokens.Valid() {
+		params.MaxOutputTokens = chat.MaxTokens
+	}
+
+	if m, ok := model.(openAIReasoningModel); ok {
+		params.Reasoning = shared.ReasoningParam{
+			Effort:  chat.ReasoningEffort,
+			Summary: shared.ReasoningSummary(m.summary()),
 		}
-	case *GPT51Mini:
-		if m.maxCompletionTokens > 0 {
-			params.MaxCompletionTokens = openai.Int(int64(m.maxCompletionTokens))
+	}
+
+	return params, nil
+}
+
+// buildOpenAIResponsesResponse converts a Responses API response into a GenerationResponse.
+// Reasoning summaries are returned in Metadata["thinking"].
+func buildOpenAIResponsesResponse(resp *responses.Response, isReasoning, minimal bool) (*GenerationResponse, error) {
+	usage := TokenUsage{
+		PromptTokens:       int(resp.Usage.InputTokens),
+		CompletionTokens:   int(resp.Usage.OutputTokens),
+		TotalTokens:        int(resp.Usage.TotalTokens),
+		CachedPromptTokens: int(resp.Usage.InputTokensDetails.CachedTokens),
+		ReasoningTokens:    int(resp.Usage.OutputTokensDetails.ReasoningTokens),
+	}
+
+	if resp.Status == responses.ResponseStatusFailed {
+		return nil, withPartialUsage(fmt.Errorf("OpenAI response failed: %s", resp.Error.Message), usage)
+	}
+
+	// Map the response status onto Chat Completions finish reasons
+	finishReason := string(resp.Status)
+	switch {
+	case resp.Status == responses.ResponseStatusCompleted:
+		finishReason = "stop"
+	case resp.Status == responses.ResponseStatusIncomplete && resp.IncompleteDetails.Reason == "max_output_tokens":
+		finishReason = "length"
+	case resp.Status == responses.ResponseStatusIncomplete && resp.IncompleteDetails.Reason != "":
+		finishReason = resp.IncompleteDetails.Reason
+	}
+
+	response := &GenerationResponse{
+		Text:         resp.OutputText(),
+		Model:        string(resp.Model),
+		FinishReason: finishReason,
+		Usage:        usage,
+	}
+	if refusal := openAIResponsesRefusal(resp); refusal != "" {
+		response.Refusal = refusal
+		response.FinishReason = "refusal"
+	}
+	if minimal {
+		return response, nil
+	}
+	response.Metadata = map[string]string{
+		"provider":           "openai",
+		"model":              string(resp.Model),
+		"is_reasoning_model": fmt.Sprintf("%t", isReasoning),
+		"response_id":        resp.ID,
+	}
+
+	if resp.Usage.OutputTokensDetails.ReasoningTokens > 0 {
+		response.Metadata["reasoning_tokens"] = fmt.Sprintf("%d", resp.Usage.OutputTokensDetails.ReasoningTokens)
+	}
+	if resp.ServiceTier != "" {
+		response.Metadata["service_tier"] = string(resp.ServiceTier)
+	}
+
+	// Collect reasoning summaries
+	var summaries []string
+	for _, item := range resp.Output {
+		if item.Type != "reasoning" {
+			continue
 		}
-		if m.reasoningEffort != "" {
-			params.ReasoningEffort = shared.ReasoningEffort(m.reasoningEffort)
+		for _, part := range item.Summary {
+			summaries = append(summaries, part.Text)
 		}
-	case *GPT51Nano:
-		if m.maxCompletionTokens > 0 {
-			params.MaxCompletionTokens = openai.Int(int64(m.maxCompletionTokens))
+	}
+	if len(summaries) > 0 {
+		response.Metadata["thinking"] = strings.Join(summaries, "\n\n")
+	}
+
+	return response, nil
+}
+
+// openAIResponsesRefusal returns the refusal messages of a Responses API response
+func openAIResponsesRefusal(resp *responses.Response) string {
+	var refusals []string
+	for _, item := range resp.Output {
+		if item.Type != "message" {
+			continue
 		}
-		if m.reasoningEffort != "" {
-			params.ReasoningEffort = shared.ReasoningEffort(m.reasoningEffort)
+		for _, part := range item.Content {
+			if part.Type == "refusal" {
+				refusals = append(refusals, part.Refusal)
+			}
 		}
-	case *GPT51Codex:
-		if m.maxCompletionTokens > 0 {
-			params.MaxCompletionTokens = openai.Int(int64(m.maxCompletionTokens))
+	}
+	return strings.Join(refusals, "\n")
+}
+
+// openAIBatchLine is a single request line in a Batch API input file
+type openAIBatchLine struct {
+	CustomID string                         `json:"custom_id"`
+	Method   string                         `json:"method"`
+	URL      string                         `json:"url"`
+	Body     openai.ChatCompletionNewParams `json:"body"`
+}
+
+// openAIBatchOutputLine is a single result line in a Batch API output or error file
+type openAIBatchOutputLine struct {
+	CustomID string `json:"custom_id"`
+	Response *struct {
+		StatusCode int             `json:"status_code"`
+		Body       json.RawMessage `json:"body"`
+	} `json:"response"`
+	Error *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// SubmitBatch uploads the requests as a JSONL file and starts a Batch API job
+func (c *openAIClient) SubmitBatch(ctx context.Context, requests []BatchRequest) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(c.timeout, ProviderOpenAI, ""))
+	defer cancel()
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, req := range requests {
+		if req.Model.Provider() != ProviderOpenAI {
+			return "", fmt.Errorf("model %s is not an OpenAI model", req.Model.ModelName())
 		}
-		if m.reasoningEffort != "" {
-			params.ReasoningEffort = shared.ReasoningEffort(m.reasoningEffort)
+
+		params, err := c.buildParams(req.Model, req.Model.SystemPrompt(), req.Prompt)
+		if err != nil {
+			return "", err
 		}
-	case *GPT51CodexMini:
-		if m.maxCompletionTokens > 0 {
-			params.MaxCompletionTokens = openai.Int(int64(m.maxCompletionTokens))
+
+		line := openAIBatchLine{
+			CustomID: req.CustomID,
+			Method:   "POST",
+			URL:      string(openai.BatchNewParamsEndpointV1ChatCompletions),
+			Body:     params,
 		}
-		if m.reasoningEffort != "" {
-			params.ReasoningEffort = shared.ReasoningEffort(m.reasoningEffort)
+		if err := enc.Encode(line); err != nil {
+			return "", fmt.Errorf("failed to encode batch request %s: %w", req.CustomID, err)
 		}
-	case *O3Pro:
-		if m.maxCompletionTokens > 0 {
-			params.MaxCompletionTokens = openai.Int(int64(m.maxCompletionTokens))
+	}
+
+	c.logger.Debug().
+		Int("requests", len(requests)).
+		Msg("Submitting OpenAI batch")
+
+	// Upload the input file once, so retrying the batch creation doesn't
+	// leave orphaned copies behind
+	var file *openai.FileObject
+	err := c.rateLimiter.Execute(ctx, "", func() error {
+		var reqErr error
+		file, reqErr = c.client.Files.New(ctx, openai.FileNewParams{
+			File:    openai.File(bytes.NewReader(buf.Bytes()), "batch.jsonl", "application/jsonl"),
+			Purpose: openai.FilePurposeBatch,
+		})
+		return reqErr
+	})
+	if err != nil {
+		c.logger.Error().
+			Err(err).
+			Int("requests", len(requests)).
+			Msg("OpenAI batch file upload failed")
+		return "", fmt.Errorf("OpenAI batch file upload failed: %w", err)
+	}
+
+	var batch *openai.Batch
+	err = c.rateLimiter.Execute(ctx, "", func() error {
+		var reqErr error
+		batch, reqErr = c.client.Batches.New(ctx, openai.BatchNewParams{
+			CompletionWindow: openai.BatchNewParamsCompletionWindow24h,
+			Endpoint:         openai.BatchNewParamsEndpointV1ChatCompletions,
+			InputFileID:      file.ID,
+		})
+		return reqErr
+	})
+	if err != nil {
+		c.logger.Error().
+			Err(err).
+			Int("requests", len(requests)).
+			Msg("OpenAI batch submission failed")
+		return "", fmt.Errorf("OpenAI batch submission failed: %w", err)
+	}
+
+	c.logger.Debug().
+		Str("batch_id", batch.ID).
+		Msg("OpenAI batch submitted")
+
+	return batch.ID, nil
+}
+
+// GetBatchStatus returns the status of a Batch API job
+func (c *openAIClient) GetBatchStatus(ctx context.Context, batchID string) (*BatchStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(c.timeout, ProviderOpenAI, ""))
+	defer cancel()
+
+	batch, err := c.getBatch(ctx, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OpenAI batch %s: %w", batchID, err)
+	}
+
+	state := BatchInProgress
+	switch batch.Status {
+	case openai.BatchStatusCompleted:
+		state = BatchCompleted
+	case openai.BatchStatusFailed:
+		state = BatchFailed
+	case openai.BatchStatusExpired:
+		state = BatchExpired
+	case openai.BatchStatusCancelled:
+		state = BatchCancelled
+	}
+
+	return &BatchStatus{
+		ID:             batch.ID,
+		Provider:       ProviderOpenAI,
+		State:          state,
+		ProviderStatus: string(batch.Status),
+		Total:          int(batch.RequestCounts.Total),
+		Completed:      int(batch.RequestCounts.Completed),
+		Failed:         int(batch.RequestCounts.Failed),
+	}, nil
+}
+
+// GetBatchResults downloads and parses the output and error files of a Batch API job
+func (c *openAIClient) GetBatchResults(ctx context.Context, batchID string) ([]BatchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(c.timeout, ProviderOpenAI, ""))
+	defer cancel()
+
+	batch, err := c.getBatch(ctx, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OpenAI batch %s: %w", batchID, err)
+	}
+
+	if batch.OutputFileID == "" && batch.ErrorFileID == "" {
+		return nil, fmt.Errorf("OpenAI batch %s has no results yet (status: %s)", batchID, batch.Status)
+	}
+
+	var results []BatchResult
+	for _, fileID := range []string{batch.OutputFileID, batch.ErrorFileID} {
+		if fileID == "" {
+			continue
 		}
-		if m.reasoningEffort != "" {
-			params.ReasoningEffort = shared.ReasoningEffort(m.reasoningEffort)
+
+		fileResults, err := c.readBatchFile(ctx, fileID)
+		if err != nil {
+			return nil, err
 		}
-	case *O1Preview:
-		if m.maxCompletionTokens > 0 {
-			params.MaxCompletionTokens = openai.Int(int64(m.maxCompletionTokens))
+		results = append(results, fileResults...)
+	}
+
+	return results, nil
+}
+
+// getBatch retrieves a Batch API job
+func (c *openAIClient) getBatch(ctx context.Context, batchID string) (*openai.Batch, error) {
+	var batch *openai.Batch
+	err := c.rateLimiter.Execute(ctx, "", func() error {
+		var reqErr error
+		batch, reqErr = c.client.Batches.Get(ctx, batchID)
+		return reqErr
+	})
+	return batch, err
+}
+
+// readBatchFile downloads a Batch API result file and converts each line into a BatchResult
+func (c *openAIClient) readBatchFile(ctx context.Context, fileID string) ([]BatchResult, error) {
+	var resp *http.Response
+	err := c.rateLimiter.Execute(ctx, "", func() error {
+		var reqErr error
+		resp, reqErr = c.client.Files.Content(ctx, fileID)
+		return reqErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download OpenAI batch file %s: %w", fileID, err)
+	}
+	defer resp.Body.Close()
+
+	var results []BatchResult
+	dec := json.NewDecoder(resp.Body)
+	for dec.More() {
+		var line openAIBatchOutputLine
+		if err := dec.Decode(&line); err != nil {
+			return nil, fmt.Errorf("failed to decode OpenAI batch file %s: %w", fileID, err)
 		}
-		if m.reasoningEffort != "" {
-			params.ReasoningEffort = shared.ReasoningEffort(m.reasoningEffort)
+
+		result := BatchResult{CustomID: line.CustomID}
+		switch {
+		case line.Error != nil:
+			result.Error = fmt.Sprintf("%s: %s", line.Error.Code, line.Error.Message)
+		case line.Response == nil:
+			result.Error = "missing response"
+		case line.Response.StatusCode != 200:
+			result.Error = fmt.Sprintf("status %d: %s", line.Response.StatusCode, string(line.Response.Body))
+		default:
+			var completion openai.ChatCompletion
+			if err := json.Unmarshal(line.Response.Body, &completion); err != nil {
+				result.Error = fmt.Sprintf("failed to decode response: %v", err)
+				break
+			}
+			// The model type isn't known here; reasoning tokens are the best signal
+			isReasoning := completion.Usage.CompletionTokensDetails.ReasoningTokens > 0
+			response, err := buildOpenAIResponse(&completion, isReasoning, false)
+			if err != nil {
+				result.Error = err.Error()
+				break
+			}
+			result.Response = response
 		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// Transcribe converts speech to text using OpenAI's audio transcription API
+func (c *openAIClient) Transcribe(ctx context.Context, model TranscriptionModel, audio []byte) (string, error) {
+	m, ok := model.(openAITranscriptionModel)
+	if !ok || model.Provider() != ProviderOpenAI {
+		return "", fmt.Errorf("model %s is not an OpenAI transcription model", model.ModelName())
+	}
+	opts := m.options()
+
+	// Set timeout
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(c.timeout, ProviderOpenAI, model.ModelName()))
+	defer cancel()
+
+	filename := opts.filename
+	if filename == "" {
+		filename = "audio.mp3"
+	}
+
+	params := openai.AudioTranscriptionNewParams{
+		Model: openai.AudioModel(model.ModelName()),
+		File:  openai.File(bytes.NewReader(audio), filename, ""),
+	}
+	if opts.language != "" {
+		params.Language = openai.String(opts.language)
+	}
+	if opts.prompt != "" {
+		params.Prompt = openai.String(opts.prompt)
+	}
+	if opts.temperature > 0 {
+		params.Temperature = openai.Float(opts.temperature)
 	}
 
 	c.logger.Debug().
 		Str("model", model.ModelName()).
-		Bool("is_reasoning_model", isReasoning).
-		Msg("Making OpenAI API request")
+		Int("audio_bytes", len(audio)).
+		Msg("Making OpenAI transcription request")
 
 	// Make request with rate limit handling
-	var resp *openai.ChatCompletion
-	err := c.rateLimiter.Execute(ctx, func() error {
+	var resp *openai.Transcription
+	err := c.rateLimiter.Execute(ctx, model.ModelName(), func() error {
 		var reqErr error
-		resp, reqErr = c.client.Chat.Completions.New(ctx, params)
+		resp, reqErr = c.client.Audio.Transcriptions.New(ctx, params)
 		return reqErr
 	})
 	if err != nil {
 		c.logger.Error().
 			Err(err).
 			Str("model", model.ModelName()).
-			Bool("is_reasoning_model", isReasoning).
-			Str("prompt_preview", truncateString(prompt, 100)).
-			Msg("OpenAI generation failed")
-		return nil, fmt.Errorf("OpenAI generation failed: %w", err)
+			Msg("OpenAI transcription failed")
+		return "", fmt.Errorf("OpenAI transcription failed: %w", err)
 	}
 
-	if len(resp.Choices) == 0 {
-		return nil, fmt.Errorf("no response choices returned from OpenAI")
+	c.logger.Debug().
+		Str("model", model.ModelName()).
+		Int("text_length", len(resp.Text)).
+		Msg("OpenAI transcription completed")
+
+	return resp.Text, nil
+}
+
+// GenerateImage generates images using OpenAI's image generation API
+func (c *openAIClient) GenerateImage(ctx context.Context, model ImageModel, prompt string) ([]ImageResult, error) {
+	m, ok := model.(openAIImageModel)
+	if !ok || model.Provider() != ProviderOpenAI {
+		return nil, fmt.Errorf("model %s is not an OpenAI image model", model.ModelName())
 	}
+	opts := m.imageOptions()
 
-	choice := resp.Choices[0]
+	// Set timeout
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(c.timeout, ProviderOpenAI, model.ModelName()))
+	defer cancel()
 
-	// Build response
-	response := &GenerationResponse{
-		Text:         choice.Message.Content,
-		Model:        resp.Model,
-		FinishReason: string(choice.FinishReason),
-		Usage: TokenUsage{
-			PromptTokens:     int(resp.Usage.PromptTokens),
-			CompletionTokens: int(resp.Usage.CompletionTokens),
-			TotalTokens:      int(resp.Usage.TotalTokens),
-		},
-		Metadata: map[string]string{
-			"provider":           "openai",
-			"model":              resp.Model,
-			"is_reasoning_model": fmt.Sprintf("%t", isReasoning),
-		},
+	params := openai.ImageGenerateParams{
+		Model:  openai.ImageModel(model.ModelName()),
+		Prompt: prompt,
+	}
+	if opts.size != "" {
+		params.Size = openai.ImageGenerateParamsSize(opts.size)
+	}
+	if opts.quality != "" {
+		params.Quality = openai.ImageGenerateParamsQuality(opts.quality)
+	}
+	if opts.style != "" {
+		params.Style = openai.ImageGenerateParamsStyle(opts.style)
+	}
+	if opts.responseFormat != "" {
+		params.ResponseFormat = openai.ImageGenerateParamsResponseFormat(opts.responseFormat)
 	}
 
-	// Add reasoning tokens to metadata if available
-	if resp.Usage.CompletionTokensDetails.ReasoningTokens > 0 {
-		response.Metadata["reasoning_tokens"] = fmt.Sprintf("%d", resp.Usage.CompletionTokensDetails.ReasoningTokens)
+	c.logger.Debug().
+		Str("model", model.ModelName()).
+		Msg("Making OpenAI image request")
+
+	// Make request with rate limit handling
+	var resp *openai.ImagesResponse
+	err := c.rateLimiter.Execute(ctx, model.ModelName(), func() error {
+		var reqErr error
+		resp, reqErr = c.client.Images.Generate(ctx, params)
+		return reqErr
+	})
+	if err != nil {
+		c.logger.Error().
+			Err(err).
+			Str("model", model.ModelName()).
+			Msg("OpenAI image generation failed")
+		return nil, fmt.Errorf("OpenAI image generation failed: %w", err)
+	}
+
+	results := make([]ImageResult, len(resp.Data))
+	for i, img := range resp.Data {
+		results[i] = ImageResult{
+			URL:           img.URL,
+			B64JSON:       img.B64JSON,
+			RevisedPrompt: img.RevisedPrompt,
+		}
 	}
 
 	c.logger.Debug().
-		Str("model", resp.Model).
-		Bool("is_reasoning_model", isReasoning).
-		Int64("prompt_tokens", resp.Usage.PromptTokens).
-		Int64("completion_tokens", resp.Usage.CompletionTokens).
-		Int64("total_tokens", resp.Usage.TotalTokens).
-		Msg("OpenAI generation completed")
+		Str("model", model.ModelName()).
+		Int("images", len(results)).
+		Msg("OpenAI image generation completed")
 
-	return response, nil
+	return results, nil
+}
+
+// rateLimitStats returns the rate limit counters of the OpenAI client
+func (c *openAIClient) rateLimitStats() RateLimitStats { return c.rateLimiter.Stats() }
+
+// Ping checks connectivity and credentials by listing the available models
+func (c *openAIClient) Ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if _, err := c.client.Models.List(ctx); err != nil {
+		return fmt.Errorf("OpenAI ping failed: %w", err)
+	}
+	return nil
 }
 
 // Health checks the health of the OpenAI client
@@ -0,0 +1,138 @@
+package lingo
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestOpenAIMaxTokensField(t *testing.T) {
+	tests := []struct {
+		model         Model
+		wantMaxTokens bool // max_tokens rather than max_completion_tokens
+	}{
+		{NewGPT35Turbo().WithMaxTokens(100), true},
+		{NewGPT4().WithMaxTokens(100), true},
+		{NewGPT4Turbo().WithMaxTokens(100), true},
+		{NewGPT4o().WithMaxTokens(100), false},
+		{NewGPT4oMini().WithMaxTokens(100), false},
+		{NewGPT41().WithMaxTokens(100), false},
+		{NewO1().WithMaxCompletionTokens(100), false},
+		{NewO3Mini().WithMaxCompletionTokens(100), false},
+		{NewO4Mini().WithMaxCompletionTokens(100), false},
+		{NewGPT5().WithMaxCompletionTokens(100), false},
+		{NewGPT5Mini().WithMaxTokens(100), false},
+	}
+
+	c := &openAIClient{}
+	for _, tt := range tests {
+		t.Run(tt.model.ModelName(), func(t *testing.T) {
+			params, err := c.buildParams(tt.model, "", "hi")
+			if err != nil {
+				t.Fatalf("buildParams: %v", err)
+			}
+
+			maxTokens, maxCompletionTokens := params.MaxTokens, params.MaxCompletionTokens
+			if tt.wantMaxTokens {
+				if !maxTokens.Valid() || maxTokens.Value != 100 {
+					t.Errorf("MaxTokens = %v, want 100", maxTokens)
+				}
+				if maxCompletionTokens.Valid() {
+					t.Errorf("MaxCompletionTokens = %v, want unset", maxCompletionTokens)
+				}
+			} else {
+				if !maxCompletionTokens.Valid() || maxCompletionTokens.Value != 100 {
+					t.Errorf("MaxCompletionTokens = %v, want 100", maxCompletionTokens)
+				}
+				if maxTokens.Valid() {
+					t.Errorf("MaxTokens = %v, want unset", maxTokens)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenAITemperature(t *testing.T) {
+	zero, low := 0.0, 0.3
+	tests := []struct {
+		name        string
+		model       Model
+		defaultTemp *float64
+		want        float64
+		sent        bool
+	}{
+		{"default", NewGPT4o(), nil, 1, true},
+		{"zero value", &GPT4o{}, nil, 0, false},
+		{"explicit zero", NewGPT4o().WithTemperature(0), nil, 0, true},
+		{"non-zero", NewGPT4o().WithTemperature(0.7), nil, 0.7, true},
+		{"config default", NewGPT4o(), &low, 0.3, true},
+		{"config default of zero", NewGPT4o(), &zero, 0, true},
+		{"explicit over config default", NewGPT4o().WithTemperature(0.7), &zero, 0.7, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &openAIClient{defaultTemperature: tt.defaultTemp}
+			params, err := c.buildParams(tt.model, "", "hi")
+			if err != nil {
+				t.Fatalf("buildParams: %v", err)
+			}
+			if params.Temperature.Valid() != tt.sent || params.Temperature.Value != tt.want {
+				t.Errorf("Temperature = %v, want %v (sent %t)", params.Temperature, tt.want, tt.sent)
+			}
+		})
+	}
+}
+
+func TestOpenAIUsesMaxCompletionTokens(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"gpt-3.5-turbo", false},
+		{"gpt-3.5-turbo-0125", false},
+		{"gpt-4", false},
+		{"gpt-4-turbo", false},
+		{"gpt-4-0613", false},
+		{"gpt-4o", true},
+		{"gpt-4o-mini", true},
+		{"gpt-4.1", true},
+		{"o1", true},
+		{"o3-mini", true},
+		{"gpt-5", true},
+	}
+	for _, tt := range tests {
+		if got := openAIUsesMaxCompletionTokens(tt.name); got != tt.want {
+			t.Errorf("openAIUsesMaxCompletionTokens(%q) = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOpenAIDryRunIncludesExtraBody(t *testing.T) {
+	g, err := New([]ProviderConfig{&OpenAIConfig{APIKey: "test"}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer g.Close()
+
+	var inspected []byte
+	resp, err := g.Generate(context.Background(), NewGPT4o(), "Hi", WithDryRun(true),
+		WithExtraBody(map[string]any{"prediction": map[string]any{"type": "content"}}),
+		withRequestInspector(func(_ ProviderType, body []byte) { inspected = body }))
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	for name, body := range map[string]string{"dry run": resp.Metadata["dry_run_request"], "inspected": string(inspected)} {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(body), &fields); err != nil {
+			t.Fatalf("%s body: %v", name, err)
+		}
+		if string(fields["prediction"]) != `{"type":"content"}` {
+			t.Errorf("%s body has prediction %s, want the extra body field", name, fields["prediction"])
+		}
+		if _, ok := fields["messages"]; !ok {
+			t.Errorf("%s body is missing the messages", name)
+		}
+	}
+}
@@ -0,0 +1,497 @@
+package lingo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ============================================================================
+// GENERATE OPTIONS (per-request)
+// ============================================================================
+
+// GenerateOption is a functional option that applies to a single Generate call.
+// Unlike model options, which travel with the model, generate options only
+// affect the request they are passed to.
+type GenerateOption func(*generateOptions)
+
+// defaultPromptPreviewLength is the number of characters of a prompt included in error logs
+const defaultPromptPreviewLength = 100
+
+// generateOptions holds the resolved per-request settings
+type generateOptions struct {
+	includeRaw      bool
+	dryRun          bool
+	minimalResponse bool
+
+	// Extra top-level request body fields
+	extraBody          map[string]any
+	extraBodyOverrides bool
+
+	// Pre-flight input guard
+	maxInputChars   int
+	inputTruncation TruncationStrategy
+
+	// Client-side stop pattern, applied by the gateway
+	stopPattern string
+
+	// Provider-side stop sequences
+	stopSequences []string
+
+	// Replaces the model's system prompt when set, either as is or rendered
+	// from a template
+	systemPrompt         string
+	systemPromptSet      bool
+	systemPromptTemplate *SystemPromptTemplate
+	systemPromptVars     map[string]string
+	systemPromptErr      error
+
+	// Disables the provider's rate limit retries
+	noRetry bool
+
+	// Keeps the request out of the gateway's singleflight group
+	unshared bool
+
+	// Reproducibility settings; deterministic is filled in by the gateway
+	seed          int64
+	seedSet       bool
+	deterministic bool
+	forceCache    bool
+
+	// Fallback policy, read by GenerateWithFallback
+	fallbackOnContentFilter bool
+	fallbackRetryableOnly   bool
+
+	// Logging settings, filled in by the gateway
+	promptRedactor      func(string) string
+	promptPreviewLength int
+	requestInspector    func(provider ProviderType, body []byte)
+}
+
+// newGenerateOptions resolves the given options into a generateOptions value
+func newGenerateOptions(opts []GenerateOption) *generateOptions {
+	o := &generateOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// WithIncludeRaw populates GenerationResponse.RawJSON with the untouched provider
+// payload. Off by default to avoid retaining large response bodies.
+func WithIncludeRaw(include bool) GenerateOption {
+	return func(o *generateOptions) {
+		o.includeRaw = include
+	}
+}
+
+// WithMinimalResponse trims the per-request overhead for high-throughput
+// callers: the response carries only the text, model, finish reason and usage,
+// with no Metadata, and the request's debug logs are skipped. Features that
+// read metadata, such as GetOllamaTimings, GetPerplexityCitations and thinking
+// text, find nothing on minimal responses.
+func WithMinimalResponse() GenerateOption {
+	return func(o *generateOptions) {
+		o.minimalResponse = true
+	}
+}
+
+// WithSystemPromptOpt sets the system prompt for this request, replacing the
+// model's own. Code that only holds a Model can attach a system prompt this
+// way without type-asserting to the concrete model. An empty prompt sends the
+// request without one.
+func WithSystemPromptOpt(prompt string) GenerateOption {
+	return func(o *generateOptions) {
+		o.systemPrompt = prompt
+		o.systemPromptSet = true
+		o.systemPromptTemplate, o.systemPromptVars, o.systemPromptErr = nil, nil, nil
+	}
+}
+
+// WithSystemPromptTemplate sets the system prompt for this request from a
+// text/template with variables referenced as {{.name}}, replacing the model's
+// own. The template is rendered when the request is made; a template that
+// doesn't parse or references a variable missing from vars makes the request
+// fail before anything is sent.
+func WithSystemPromptTemplate(tmpl string, vars map[string]string) GenerateOption {
+	return func(o *generateOptions) {
+		o.systemPromptSet = true
+		o.systemPromptVars = vars
+		o.systemPromptTemplate, o.systemPromptErr = ParseSystemPromptTemplate(tmpl)
+	}
+}
+
+// renderSystemPrompt returns the request's own system prompt, rendering its
+// template if it has one
+func (o *generateOptions) renderSystemPrompt() (string, error) {
+	if o.systemPromptErr != nil {
+		return "", o.systemPromptErr
+	}
+	if o.systemPromptTemplate != nil {
+		return o.systemPromptTemplate.Render(o.systemPromptVars)
+	}
+	return o.systemPrompt, nil
+}
+
+// systemPromptFor returns the system prompt to send with a request to model.
+// The gateway rejects requests whose template fails to render before they
+// reach a provider.
+func (o *generateOptions) systemPromptFor(model Model) string {
+	if o.systemPromptSet {
+		system, _ := o.renderSystemPrompt()
+		return system
+	}
+	return model.SystemPrompt()
+}
+
+// logger returns the logger for a request, which skips debug logs for
+// minimal responses
+func (o *generateOptions) logger(l Logger) Logger {
+	if o.minimalResponse {
+		return quietLogger{l}
+	}
+	return l
+}
+
+// WithDryRun builds and validates the provider request without sending it. The
+// response carries the serialized request body in Metadata["dry_run_request"]
+// and has no text or usage. Useful for checking prompt formatting and option
+// plumbing without spending tokens.
+func WithDryRun(dryRun bool) GenerateOption {
+	return func(o *generateOptions) {
+		o.dryRun = dryRun
+	}
+}
+
+// dryRunResponse returns the response for a dry run holding the serialized
+// request that would have been sent
+func dryRunResponse(model Model, request any) (*GenerationResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode dry run request: %w", err)
+	}
+	return &GenerationResponse{
+		Model:        model.ModelName(),
+		FinishReason: "dry_run",
+		Metadata: map[string]string{
+			"dry_run_request": string(body),
+		},
+	}, nil
+}
+
+// WithExtraBody adds fields to the top level of the provider's JSON request
+// body, to use provider parameters lingo doesn't model yet. Fields lingo
+// already sets are left alone unless WithExtraBodyOverrides is also passed.
+// Google takes fields in its REST API format; for the other providers they
+// are added to the body as is.
+func WithExtraBody(fields map[string]any) GenerateOption {
+	return func(o *generateOptions) {
+		if o.extraBody == nil {
+			o.extraBody = make(map[string]any, len(fields))
+		}
+		for k, v := range fields {
+			o.extraBody[k] = v
+		}
+	}
+}
+
+// WithExtraBodyOverrides lets WithExtraBody fields replace fields lingo sets
+func WithExtraBodyOverrides() GenerateOption {
+	return func(o *generateOptions) {
+		o.extraBodyOverrides = true
+	}
+}
+
+// extraBodyFields returns the WithExtraBody fields to add to request, leaving
+// out the ones its JSON encoding already sets unless overrides are allowed.
+// request may be an encoded body or a value to encode.
+func (o *generateOptions) extraBodyFields(request any) (map[string]any, error) {
+	if len(o.extraBody) == 0 {
+		return nil, nil
+	}
+	if o.extraBodyOverrides {
+		return o.extraBody, nil
+	}
+
+	body, ok := request.([]byte)
+	if !ok {
+		var err error
+		if body, err = json.Marshal(request); err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+	var set map[string]json.RawMessage
+	if err := json.Unmarshal(body, &set); err != nil {
+		return nil, fmt.Errorf("failed to decode request: %w", err)
+	}
+
+	fields := make(map[string]any, len(o.extraBody))
+	for k, v := range o.extraBody {
+		if _, exists := set[k]; !exists {
+			fields[k] = v
+		}
+	}
+	return fields, nil
+}
+
+// applyExtraBody merges the WithExtraBody fields into an encoded request body
+func (o *generateOptions) applyExtraBody(body []byte) ([]byte, error) {
+	fields, err := o.extraBodyFields(body)
+	if err != nil || len(fields) == 0 {
+		return body, err
+	}
+
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(body, &merged); err != nil {
+		return nil, fmt.Errorf("failed to decode request: %w", err)
+	}
+	for k, v := range fields {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode extra body field %q: %w", k, err)
+		}
+		merged[k] = raw
+	}
+	body, err = json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request with extra body: %w", err)
+	}
+	return body, nil
+}
+
+// encodeRequest returns the body an SDK will send for request, with the
+// WithExtraBody fields merged in, for the request inspector and dry runs.
+// Without either it returns nil and skips the encoding.
+func (o *generateOptions) encodeRequest(request any) (json.RawMessage, error) {
+	if o.requestInspector == nil && !o.dryRun {
+		return nil, nil
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+	return o.applyExtraBody(body)
+}
+
+// ErrInputTooLong is returned when a prompt exceeds WithMaxInputChars and no
+// truncation strategy was set
+var ErrInputTooLong = errors.New("prompt exceeds maximum input length")
+
+// TruncationStrategy controls how a prompt longer than WithMaxInputChars is shortened
+type TruncationStrategy string
+
+const (
+	// TruncateNone rejects prompts that are too long with ErrInputTooLong
+	TruncateNone TruncationStrategy = ""
+	// TruncateHead drops the beginning of the prompt, keeping the most recent context
+	TruncateHead TruncationStrategy = "head"
+	// TruncateTail drops the end of the prompt, keeping the leading instructions
+	TruncateTail TruncationStrategy = "tail"
+	// TruncateMiddle drops the middle of the prompt, keeping both the leading
+	// instructions and the most recent context
+	TruncateMiddle TruncationStrategy = "middle"
+)
+
+// truncationMarker replaces the text removed by TruncateMiddle
+const truncationMarker = "\n[...]\n"
+
+// WithMaxInputChars limits the prompt to n characters. Longer prompts are
+// rejected with ErrInputTooLong unless WithInputTruncation is also set. For
+// chat requests the limit applies to the final user turn.
+func WithMaxInputChars(n int) GenerateOption {
+	return func(o *generateOptions) {
+		o.maxInputChars = n
+	}
+}
+
+// WithInputTruncation sets how prompts longer than WithMaxInputChars are shortened
+func WithInputTruncation(strategy TruncationStrategy) GenerateOption {
+	return func(o *generateOptions) {
+		o.inputTruncation = strategy
+	}
+}
+
+// fitInput applies the input length guard to prompt
+func (o *generateOptions) fitInput(prompt string) (string, error) {
+	if o.maxInputChars <= 0 {
+		return prompt, nil
+	}
+
+	runes := []rune(prompt)
+	n := o.maxInputChars
+	if len(runes) <= n {
+		return prompt, nil
+	}
+
+	switch o.inputTruncation {
+	case TruncateNone:
+		return "", fmt.Errorf("%w: %d characters, limit is %d", ErrInputTooLong, len(runes), n)
+	case TruncateHead:
+		return string(runes[len(runes)-n:]), nil
+	case TruncateTail:
+		return string(runes[:n]), nil
+	case TruncateMiddle:
+		marker := []rune(truncationMarker)
+		if n <= len(marker) {
+			return string(runes[:n]), nil
+		}
+		keep := n - len(marker)
+		head := keep - keep/2
+		return string(runes[:head]) + truncationMarker + string(runes[len(runes)-keep/2:]), nil
+	default:
+		return "", fmt.Errorf("unknown truncation strategy %q", o.inputTruncation)
+	}
+}
+
+// WithStopOnRegex trims the generated text at the first match of pattern, for
+// stops that provider stop sequences can't express (e.g. a closing markdown
+// fence). It is applied client-side once the text has been received; with
+// streaming, chunks stop being emitted once the pattern matches.
+func WithStopOnRegex(pattern string) GenerateOption {
+	return func(o *generateOptions) {
+		o.stopPattern = pattern
+	}
+}
+
+// WithStopSequences ends generation as soon as the model produces one of
+// seqs; the sequence itself is left out of the text. It is sent to Anthropic
+// (also on Bedrock), OpenAI Chat Completions, Gemini and Ollama, and Claude
+// reports the sequence that matched in GenerationResponse.StopSequence. Other
+// providers ignore it and log a warning; WithStopOnRegex works everywhere.
+func WithStopSequences(seqs ...string) GenerateOption {
+	return func(o *generateOptions) {
+		o.stopSequences = seqs
+	}
+}
+
+// warnStopSequencesIgnored logs that a request's stop sequences weren't sent
+func warnStopSequencesIgnored(logger Logger, model Model, reason string) {
+	logger.Warn().
+		Str("provider", string(model.Provider())).
+		Str("model", model.ModelName()).
+		Str("reason", reason).
+		Msg("Stop sequences ignored")
+}
+
+// stopRegexp compiles the stop pattern, returning nil if none was set
+func (o *generateOptions) stopRegexp() (*regexp.Regexp, error) {
+	if o.stopPattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(o.stopPattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stop pattern %q: %w", o.stopPattern, err)
+	}
+	return re, nil
+}
+
+// WithNoRetry disables the rate limit retries for this request, both lingo's
+// own backoff and the provider SDK's. A rate limited request fails at once
+// with a ProviderError of kind KindRateLimit whose RetryAfter holds the wait
+// the provider asked for, so callers such as job queues can reschedule the
+// work themselves instead of blocking in backoff.
+func WithNoRetry(noRetry bool) GenerateOption {
+	return func(o *generateOptions) {
+		o.noRetry = noRetry
+	}
+}
+
+// WithFallbackOnContentFilter makes GenerateWithFallback try the next model
+// when a response was blocked by a safety filter (FinishContentFilter), not
+// only when a model returns an error. If every model is filtered, the last
+// filtered response is returned.
+func WithFallbackOnContentFilter() GenerateOption {
+	return func(o *generateOptions) {
+		o.fallbackOnContentFilter = true
+	}
+}
+
+// WithFallbackOnRetryableOnly makes GenerateWithFallback fall back only on
+// retryable errors (rate limits, timeouts and server errors). Any other error,
+// such as an auth failure, an invalid request, a missing model or an
+// unclassified error, ends the chain instead of trying the remaining models.
+func WithFallbackOnRetryableOnly() GenerateOption {
+	return func(o *generateOptions) {
+		o.fallbackRetryableOnly = true
+	}
+}
+
+// withPromptLogging carries the gateway's prompt logging settings to the provider
+func withPromptLogging(redactor func(string) string, previewLength int) GenerateOption {
+	return func(o *generateOptions) {
+		o.promptRedactor = redactor
+		o.promptPreviewLength = previewLength
+	}
+}
+
+// promptLoggingProvider is implemented by providers that log prompts outside a
+// Generate request and take the gateway's redactor and preview length up front
+type promptLoggingProvider interface {
+	setPromptLogging(redactor func(string) string, previewLength int)
+}
+
+// withRequestInspector carries the gateway's request inspector to the provider
+func withRequestInspector(inspector func(provider ProviderType, body []byte)) GenerateOption {
+	return func(o *generateOptions) {
+		o.requestInspector = inspector
+	}
+}
+
+// inspectRequest passes the request about to be sent to the request inspector,
+// if one is set. Requests that can't be encoded are skipped.
+func (o *generateOptions) inspectRequest(provider ProviderType, request any) {
+	if o.requestInspector == nil {
+		return
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return
+	}
+	o.requestInspector(provider, body)
+}
+
+// promptPreview returns the redacted, truncated form of prompt that is safe to log
+func (o *generateOptions) promptPreview(prompt string) string {
+	if o.promptRedactor != nil {
+		prompt = o.promptRedactor(prompt)
+	}
+
+	n := o.promptPreviewLength
+	if n == 0 {
+		n = defaultPromptPreviewLength
+	}
+	if n < 0 {
+		return ""
+	}
+	return truncateString(prompt, n)
+}
+
+// ============================================================================
+// MODEL OPTION TRACKING
+// ============================================================================
+
+// explicitOptions reports which model options were set through a With* setter
+// rather than left at the constructor default
+type explicitOptions struct {
+	maxTokens   bool
+	temperature bool
+}
+
+// explicitOptionsModel is implemented by models that track explicitly set options
+type explicitOptionsModel interface {
+	explicitOptions() explicitOptions
+}
+
+// explicitTemperatureModel is implemented by models that send an explicitly
+// set temperature, including 0
+type explicitTemperatureModel interface {
+	explicitTemperature() (float64, bool)
+}
+
+// seededModel is implemented by models that carry their own sampling seed
+type seededModel interface {
+	modelSeed() (int, bool)
+}
@@ -0,0 +1,116 @@
+package lingo
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/openai/openai-go"
+)
+
+// The benchmarks compare response building with and without WithMinimalResponse
+
+func BenchmarkBuildOpenAIResponse(b *testing.B) {
+	var resp openai.ChatCompletion
+	if err := json.Unmarshal([]byte(`{
+		"model": "gpt-4o-2024-08-06",
+		"service_tier": "default",
+		"choices": [{"finish_reason": "stop", "message": {"role": "assistant", "content": "Hello there"}}],
+		"usage": {"prompt_tokens": 12, "completion_tokens": 3, "total_tokens": 15,
+			"completion_tokens_details": {"reasoning_tokens": 2}}
+	}`), &resp); err != nil {
+		b.Fatal(err)
+	}
+
+	for _, minimal := range []bool{false, true} {
+		b.Run(benchmarkModeName(minimal), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := buildOpenAIResponse(&resp, false, minimal); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBuildAnthropicResponse(b *testing.B) {
+	var resp anthropic.Message
+	if err := json.Unmarshal([]byte(`{
+		"id": "msg_01",
+		"model": "claude-sonnet-4-5-20250929",
+		"stop_reason": "end_turn",
+		"content": [{"type": "thinking", "thinking": "Greet back."}, {"type": "text", "text": "Hello there"}],
+		"usage": {"input_tokens": 12, "output_tokens": 3, "cache_read_input_tokens": 4}
+	}`), &resp); err != nil {
+		b.Fatal(err)
+	}
+
+	for _, minimal := range []bool{false, true} {
+		b.Run(benchmarkModeName(minimal), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := buildAnthropicResponse(&resp, minimal); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func benchmarkModeName(minimal bool) string {
+	if minimal {
+		return "minimal"
+	}
+	return "full"
+}
+
+func TestFitInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		prompt   string
+		max      int
+		strategy TruncationStrategy
+		want     string
+	}{
+		{"no limit", "hello world", 0, TruncateNone, "hello world"},
+		{"fits", "hello", 5, TruncateNone, "hello"},
+		{"head", "hello world", 5, TruncateHead, "world"},
+		{"tail", "hello world", 5, TruncateTail, "hello"},
+		{"head keeps whole runes", "日本語のテキスト", 3, TruncateHead, "キスト"},
+		{"tail keeps whole runes", "🙂🙃😉😊", 2, TruncateTail, "🙂🙃"},
+		{"middle", "abcdefghijklmnopqrstuvwxyz", 13, TruncateMiddle, "abc" + truncationMarker + "xyz"},
+		{"middle odd split favours head", "abcdefghijklmnopqrstuvwxyz", 12, TruncateMiddle, "abc" + truncationMarker + "yz"},
+		{"middle keeps whole runes", "日本語のテキストです", 9, TruncateMiddle, "日" + truncationMarker + "す"},
+		{"middle shorter than marker", "abcdefghijklmnopqrstuvwxyz", 4, TruncateMiddle, "abcd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newGenerateOptions([]GenerateOption{WithMaxInputChars(tt.max), WithInputTruncation(tt.strategy)})
+			got, err := o.fitInput(tt.prompt)
+			if err != nil {
+				t.Fatalf("fitInput: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("fitInput = %q, want %q", got, tt.want)
+			}
+			if tt.max > 0 && utf8.RuneCountInString(got) > tt.max {
+				t.Errorf("fitInput kept %d characters, limit is %d", utf8.RuneCountInString(got), tt.max)
+			}
+		})
+	}
+}
+
+func TestFitInputErrors(t *testing.T) {
+	o := newGenerateOptions([]GenerateOption{WithMaxInputChars(3)})
+	if _, err := o.fitInput("hello"); !errors.Is(err, ErrInputTooLong) {
+		t.Errorf("fitInput error = %v, want ErrInputTooLong", err)
+	}
+
+	o = newGenerateOptions([]GenerateOption{WithMaxInputChars(3), WithInputTruncation("sideways")})
+	if _, err := o.fitInput("hello"); err == nil {
+		t.Error("fitInput succeeded with an unknown strategy, want an error")
+	}
+}
@@ -27,7 +27,8 @@ func init() {
 type PerplexityConfig struct {
 	// APIKey is the Perplexity API key (required)
 	APIKey string
-	// Timeout is the request timeout (default: 60s)
+	// Timeout is the request timeout (default: the model's recommended timeout,
+	// reported by Describe)
 	Timeout time.Duration
 	// RateLimiter is the optional rate limit configuration
 	RateLimiter *RateLimitConfig
@@ -54,6 +55,52 @@ type perplexityOptions struct {
 	searchDomainFilter     []string // Limit search to specific domains
 	returnImages           bool
 	returnRelatedQuestions bool
+	searchMode             string // "web" or "academic"
+	searchContextSize      string // "low", "medium", "high"
+	searchAfter            time.Time
+	searchBefore           time.Time
+	responseSchema         any // JSON schema for structured output
+
+	temperatureSet bool // Distinguishes an explicit temperature of 0 from unset
+}
+
+func (o *perplexityOptions) generationOptions() *perplexityOptions { return o }
+func (o *perplexityOptions) setTemperature(t float64)              { o.temperature = t; o.temperatureSet = true }
+func (o *perplexityOptions) explicitTemperature() (float64, bool) {
+	return o.temperature, o.temperatureSet
+}
+
+// applyTo sets the sampling and search options of a model on the request
+func (o *perplexityOptions) applyTo(req *perplexity.ChatCompletionRequest) error {
+	if o.maxTokens > 0 {
+		req.MaxTokens = o.maxTokens
+	}
+	if o.temperatureSet || o.temperature > 0 {
+		req.Temperature = &o.temperature
+	}
+	if o.topP > 0 {
+		req.TopP = &o.topP
+	}
+	if o.topK > 0 {
+		req.TopK = o.topK
+	}
+	if o.searchRecencyFilter != "" {
+		req.SearchRecencyFilter = o.searchRecencyFilter
+	}
+	if len(o.searchDomainFilter) > 0 {
+		req.SearchDomainFilter = o.searchDomainFilter
+	}
+	req.ReturnImages = o.returnImages
+	req.ReturnRelatedQuestions = o.returnRelatedQuestions
+	req.SearchMode = o.searchMode
+	if o.searchContextSize != "" {
+		req.WebSearchOptions = &perplexity.WebSearchOptions{SearchContextSize: o.searchContextSize}
+	}
+	if err := setPerplexityDateRange(req, o.searchAfter, o.searchBefore); err != nil {
+		return err
+	}
+	req.ResponseFormat = perplexityResponseFormat(o.responseSchema)
+	return nil
 }
 
 // ============================================================================
@@ -68,7 +115,7 @@ func (m *Sonar) Provider() ProviderType { return ProviderPerplexity }
 func (m *Sonar) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *Sonar) WithMaxTokens(n int) *Sonar              { m.maxTokens = n; return m }
-func (m *Sonar) WithTemperature(t float64) *Sonar        { m.temperature = t; return m }
+func (m *Sonar) WithTemperature(t float64) *Sonar        { m.setTemperature(t); return m }
 func (m *Sonar) WithTopP(p float64) *Sonar               { m.topP = p; return m }
 func (m *Sonar) WithTopK(k int) *Sonar                   { m.topK = k; return m }
 func (m *Sonar) WithSystemPrompt(s string) *Sonar        { m.systemPrompt = s; return m }
@@ -79,6 +126,13 @@ func (m *Sonar) WithSearchDomainFilter(domains []string) *Sonar {
 }
 func (m *Sonar) WithReturnImages(b bool) *Sonar           { m.returnImages = b; return m }
 func (m *Sonar) WithReturnRelatedQuestions(b bool) *Sonar { m.returnRelatedQuestions = b; return m }
+func (m *Sonar) WithSearchMode(mode string) *Sonar        { m.searchMode = mode; return m }
+func (m *Sonar) WithSearchContextSize(size string) *Sonar { m.searchContextSize = size; return m }
+func (m *Sonar) WithSearchDateRange(after, before time.Time) *Sonar {
+	m.searchAfter, m.searchBefore = after, before
+	return m
+}
+func (m *Sonar) WithResponseSchema(schema any) *Sonar { m.responseSchema = schema; return m }
 
 // NewSonar creates a new Sonar model with default options
 func NewSonar() *Sonar {
@@ -93,7 +147,7 @@ func (m *SonarPro) Provider() ProviderType { return ProviderPerplexity }
 func (m *SonarPro) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *SonarPro) WithMaxTokens(n int) *SonarPro              { m.maxTokens = n; return m }
-func (m *SonarPro) WithTemperature(t float64) *SonarPro        { m.temperature = t; return m }
+func (m *SonarPro) WithTemperature(t float64) *SonarPro        { m.setTemperature(t); return m }
 func (m *SonarPro) WithTopP(p float64) *SonarPro               { m.topP = p; return m }
 func (m *SonarPro) WithTopK(k int) *SonarPro                   { m.topK = k; return m }
 func (m *SonarPro) WithSystemPrompt(s string) *SonarPro        { m.systemPrompt = s; return m }
@@ -107,6 +161,13 @@ func (m *SonarPro) WithReturnRelatedQuestions(b bool) *SonarPro {
 	m.returnRelatedQuestions = b
 	return m
 }
+func (m *SonarPro) WithSearchMode(mode string) *SonarPro        { m.searchMode = mode; return m }
+func (m *SonarPro) WithSearchContextSize(size string) *SonarPro { m.searchContextSize = size; return m }
+func (m *SonarPro) WithSearchDateRange(after, before time.Time) *SonarPro {
+	m.searchAfter, m.searchBefore = after, before
+	return m
+}
+func (m *SonarPro) WithResponseSchema(schema any) *SonarPro { m.responseSchema = schema; return m }
 
 // NewSonarPro creates a new Sonar Pro model with default options
 func NewSonarPro() *SonarPro {
@@ -121,7 +182,7 @@ func (m *SonarReasoning) Provider() ProviderType { return ProviderPerplexity }
 func (m *SonarReasoning) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *SonarReasoning) WithMaxTokens(n int) *SonarReasoning       { m.maxTokens = n; return m }
-func (m *SonarReasoning) WithTemperature(t float64) *SonarReasoning { m.temperature = t; return m }
+func (m *SonarReasoning) WithTemperature(t float64) *SonarReasoning { m.setTemperature(t); return m }
 func (m *SonarReasoning) WithTopP(p float64) *SonarReasoning        { m.topP = p; return m }
 func (m *SonarReasoning) WithTopK(k int) *SonarReasoning            { m.topK = k; return m }
 func (m *SonarReasoning) WithSystemPrompt(s string) *SonarReasoning { m.systemPrompt = s; return m }
@@ -138,6 +199,19 @@ func (m *SonarReasoning) WithReturnRelatedQuestions(b bool) *SonarReasoning {
 	m.returnRelatedQuestions = b
 	return m
 }
+func (m *SonarReasoning) WithSearchMode(mode string) *SonarReasoning { m.searchMode = mode; return m }
+func (m *SonarReasoning) WithSearchContextSize(size string) *SonarReasoning {
+	m.searchContextSize = size
+	return m
+}
+func (m *SonarReasoning) WithSearchDateRange(after, before time.Time) *SonarReasoning {
+	m.searchAfter, m.searchBefore = after, before
+	return m
+}
+func (m *SonarReasoning) WithResponseSchema(schema any) *SonarReasoning {
+	m.responseSchema = schema
+	return m
+}
 
 // NewSonarReasoning creates a new Sonar Reasoning model with default options
 func NewSonarReasoning() *SonarReasoning {
@@ -153,7 +227,7 @@ func (m *SonarReasoningPro) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *SonarReasoningPro) WithMaxTokens(n int) *SonarReasoningPro { m.maxTokens = n; return m }
 func (m *SonarReasoningPro) WithTemperature(t float64) *SonarReasoningPro {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *SonarReasoningPro) WithTopP(p float64) *SonarReasoningPro { m.topP = p; return m }
@@ -175,6 +249,22 @@ func (m *SonarReasoningPro) WithReturnRelatedQuestions(b bool) *SonarReasoningPr
 	m.returnRelatedQuestions = b
 	return m
 }
+func (m *SonarReasoningPro) WithSearchMode(mode string) *SonarReasoningPro {
+	m.searchMode = mode
+	return m
+}
+func (m *SonarReasoningPro) WithSearchContextSize(size string) *SonarReasoningPro {
+	m.searchContextSize = size
+	return m
+}
+func (m *SonarReasoningPro) WithSearchDateRange(after, before time.Time) *SonarReasoningPro {
+	m.searchAfter, m.searchBefore = after, before
+	return m
+}
+func (m *SonarReasoningPro) WithResponseSchema(schema any) *SonarReasoningPro {
+	m.responseSchema = schema
+	return m
+}
 
 // NewSonarReasoningPro creates a new Sonar Reasoning Pro model with default options
 func NewSonarReasoningPro() *SonarReasoningPro {
@@ -190,7 +280,7 @@ func (m *SonarDeepResearch) SystemPrompt() string   { return m.systemPrompt }
 
 func (m *SonarDeepResearch) WithMaxTokens(n int) *SonarDeepResearch { m.maxTokens = n; return m }
 func (m *SonarDeepResearch) WithTemperature(t float64) *SonarDeepResearch {
-	m.temperature = t
+	m.setTemperature(t)
 	return m
 }
 func (m *SonarDeepResearch) WithTopP(p float64) *SonarDeepResearch { m.topP = p; return m }
@@ -212,22 +302,79 @@ func (m *SonarDeepResearch) WithReturnRelatedQuestions(b bool) *SonarDeepResearc
 	m.returnRelatedQuestions = b
 	return m
 }
+func (m *SonarDeepResearch) WithSearchMode(mode string) *SonarDeepResearch {
+	m.searchMode = mode
+	return m
+}
+func (m *SonarDeepResearch) WithSearchContextSize(size string) *SonarDeepResearch {
+	m.searchContextSize = size
+	return m
+}
+func (m *SonarDeepResearch) WithSearchDateRange(after, before time.Time) *SonarDeepResearch {
+	m.searchAfter, m.searchBefore = after, before
+	return m
+}
+func (m *SonarDeepResearch) WithResponseSchema(schema any) *SonarDeepResearch {
+	m.responseSchema = schema
+	return m
+}
 
 // NewSonarDeepResearch creates a new Sonar Deep Research model with default options
 func NewSonarDeepResearch() *SonarDeepResearch {
 	return &SonarDeepResearch{perplexityOptions{maxTokens: 16384, temperature: 0.2}}
 }
 
+// perplexityResponseFormat builds the structured output format for a schema
+// given as a value to encode, raw JSON bytes or a JSON string. It returns nil
+// if no schema was set.
+func perplexityResponseFormat(schema any) *perplexity.ResponseFormat {
+	switch s := schema.(type) {
+	case nil:
+		return nil
+	case []byte:
+		schema = json.RawMessage(s)
+	case string:
+		schema = json.RawMessage(s)
+	}
+	return &perplexity.ResponseFormat{
+		Type:       "json_schema",
+		JSONSchema: &perplexity.JSONSchema{Schema: schema},
+	}
+}
+
+// setPerplexityDateRange sets the search date filters of a request, leaving
+// out bounds that are zero. The API compares whole days.
+func setPerplexityDateRange(req *perplexity.ChatCompletionRequest, after, before time.Time) error {
+	if !after.IsZero() && !before.IsZero() && after.After(before) {
+		return fmt.Errorf("%w: search date range starts %s, after it ends %s", ErrOptionOutOfRange,
+			after.Format(time.DateOnly), before.Format(time.DateOnly))
+	}
+	if !after.IsZero() {
+		req.SearchAfterDateFilter = after.Format(perplexity.DateFormat)
+	}
+	if !before.IsZero() {
+		req.SearchBeforeDateFilter = before.Format(perplexity.DateFormat)
+	}
+	return nil
+}
+
 // ============================================================================
 // PERPLEXITY PROVIDER CLIENT
 // ============================================================================
 
+// perplexityOptionsModel is implemented by Sonar models through their
+// embedded perplexityOptions
+type perplexityOptionsModel interface {
+	generationOptions() *perplexityOptions
+}
+
 // perplexityClient implements the Provider interface for Perplexity
 type perplexityClient struct {
-	client      *perplexity.Client
-	timeout     time.Duration
-	logger      Logger
-	rateLimiter *rateLimiter
+	client        *perplexity.Client
+	timeout       time.Duration // Zero uses the model's recommended timeout
+	logger        Logger
+	rateLimiter   *rateLimiter
+	promptLogging *generateOptions // The gateway's redactor and preview length, for Search
 }
 
 // newPerplexityClient creates a new Perplexity client
@@ -236,54 +383,88 @@ func newPerplexityClient(config *PerplexityConfig, logger Logger) (*perplexityCl
 		return nil, fmt.Errorf("perplexity API key is required")
 	}
 
-	timeout := config.Timeout
-	if timeout == 0 {
-		timeout = defaultTimeout()
+	// Without a configured timeout, each request is bounded by its model's
+	// recommended timeout instead of the HTTP client's
+	httpTimeout := config.Timeout
+	if httpTimeout == 0 {
+		httpTimeout = -1
 	}
 
 	client, err := perplexity.NewClient(perplexity.ClientConfig{
 		APIKey:  config.APIKey,
-		Timeout: timeout,
+		Timeout: httpTimeout,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create perplexity client: %w", err)
 	}
 
 	return &perplexityClient{
-		client:      client,
-		timeout:     timeout,
-		logger:      logger,
-		rateLimiter: newRateLimiter(config.RateLimiter, logger),
+		client:        client,
+		timeout:       config.Timeout,
+		logger:        logger,
+		rateLimiter:   newRateLimiter(ProviderPerplexity, config.RateLimiter, logger),
+		promptLogging: newGenerateOptions(nil),
 	}, nil
 }
 
+// setPromptLogging applies the gateway's prompt redactor and preview length to
+// the Search queries this client logs
+func (c *perplexityClient) setPromptLogging(redactor func(string) string, previewLength int) {
+	c.promptLogging = newGenerateOptions([]GenerateOption{withPromptLogging(redactor, previewLength)})
+}
+
 // Generate generates text using Perplexity's Grounded LLM API (Chat Completions)
-func (c *perplexityClient) Generate(ctx context.Context, model Model, prompt string) (*GenerationResponse, error) {
+func (c *perplexityClient) Generate(ctx context.Context, model Model, prompt string, genOpts ...GenerateOption) (*GenerationResponse, error) {
 	// Verify model is for Perplexity
 	if model.Provider() != ProviderPerplexity {
 		return nil, fmt.Errorf("model %s is not a Perplexity model", model.ModelName())
 	}
 
+	return c.generate(ctx, model, []ChatMessage{{Role: RoleUser, Content: prompt}}, newGenerateOptions(genOpts))
+}
+
+// GenerateChat continues a multi-turn conversation. Each turn is grounded in
+// a fresh web search, informed by the earlier turns, and the response carries
+// the citations for that turn.
+func (c *perplexityClient) GenerateChat(ctx context.Context, model Model, messages []ChatMessage, genOpts ...GenerateOption) (*GenerationResponse, error) {
+	// Verify model is for Perplexity
+	if model.Provider() != ProviderPerplexity {
+		return nil, fmt.Errorf("model %s is not a Perplexity model", model.ModelName())
+	}
+	if err := validateChat(messages); err != nil {
+		return nil, err
+	}
+
+	return c.generate(ctx, model, messages, newGenerateOptions(genOpts))
+}
+
+// generate sends a conversation to the Chat Completions API
+func (c *perplexityClient) generate(ctx context.Context, model Model, turns []ChatMessage, reqOpts *generateOptions) (*GenerationResponse, error) {
+	logger := reqOpts.logger(c.logger)
+
 	// Set timeout
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(c.timeout, ProviderPerplexity, model.ModelName()))
 	defer cancel()
 
 	// Build messages
 	var messages []perplexity.Message
 
 	// Add system message if provided
-	if model.SystemPrompt() != "" {
+	if system := reqOpts.systemPromptFor(model); system != "" {
 		messages = append(messages, perplexity.Message{
 			Role:    "system",
-			Content: model.SystemPrompt(),
+			Content: system,
 		})
 	}
 
-	// Add user message
-	messages = append(messages, perplexity.Message{
-		Role:    "user",
-		Content: prompt,
-	})
+	// Add the conversation
+	for _, turn := range turns {
+		messages = append(messages, perplexity.Message{
+			Role:    string(turn.Role),
+			Content: turn.Content,
+		})
+	}
+	prompt := turns[len(turns)-1].Content
 
 	// Build request
 	req := perplexity.ChatCompletionRequest{
@@ -291,142 +472,75 @@ func (c *perplexityClient) Generate(ctx context.Context, model Model, prompt str
 		Messages: messages,
 	}
 
-	// Apply options based on model type
-	switch m := model.(type) {
-	case *Sonar:
-		if m.maxTokens > 0 {
-			req.MaxTokens = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = &m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = &m.topP
-		}
-		if m.topK > 0 {
-			req.TopK = m.topK
-		}
-		if m.searchRecencyFilter != "" {
-			req.SearchRecencyFilter = m.searchRecencyFilter
-		}
-		if len(m.searchDomainFilter) > 0 {
-			req.SearchDomainFilter = m.searchDomainFilter
-		}
-		req.ReturnImages = m.returnImages
-		req.ReturnRelatedQuestions = m.returnRelatedQuestions
-
-	case *SonarPro:
-		if m.maxTokens > 0 {
-			req.MaxTokens = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = &m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = &m.topP
+	// Apply the model's own options
+	if m, ok := model.(perplexityOptionsModel); ok {
+		if err := m.generationOptions().applyTo(&req); err != nil {
+			return nil, err
 		}
-		if m.topK > 0 {
-			req.TopK = m.topK
-		}
-		if m.searchRecencyFilter != "" {
-			req.SearchRecencyFilter = m.searchRecencyFilter
-		}
-		if len(m.searchDomainFilter) > 0 {
-			req.SearchDomainFilter = m.searchDomainFilter
-		}
-		req.ReturnImages = m.returnImages
-		req.ReturnRelatedQuestions = m.returnRelatedQuestions
-
-	case *SonarReasoning:
-		if m.maxTokens > 0 {
-			req.MaxTokens = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = &m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = &m.topP
-		}
-		if m.topK > 0 {
-			req.TopK = m.topK
-		}
-		if m.searchRecencyFilter != "" {
-			req.SearchRecencyFilter = m.searchRecencyFilter
-		}
-		if len(m.searchDomainFilter) > 0 {
-			req.SearchDomainFilter = m.searchDomainFilter
-		}
-		req.ReturnImages = m.returnImages
-		req.ReturnRelatedQuestions = m.returnRelatedQuestions
+	}
 
-	case *SonarReasoningPro:
-		if m.maxTokens > 0 {
-			req.MaxTokens = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = &m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = &m.topP
-		}
-		if m.topK > 0 {
-			req.TopK = m.topK
-		}
-		if m.searchRecencyFilter != "" {
-			req.SearchRecencyFilter = m.searchRecencyFilter
-		}
-		if len(m.searchDomainFilter) > 0 {
-			req.SearchDomainFilter = m.searchDomainFilter
-		}
-		req.ReturnImages = m.returnImages
-		req.ReturnRelatedQuestions = m.returnRelatedQuestions
+	// Search results change between requests, so answers can't be fully
+	// reproducible even at a temperature of 0
+	if _, ok := reqOpts.samplingSeed(); ok {
+		warnNotDeterministic(c.logger, model, "Perplexity doesn't accept a seed and search results vary")
+	}
+	if reqOpts.deterministic {
+		zero := 0.0
+		req.Temperature = &zero
+	}
+	if len(reqOpts.stopSequences) > 0 {
+		warnStopSequencesIgnored(c.logger, model, "Perplexity doesn't accept stop sequences")
+	}
 
-	case *SonarDeepResearch:
-		if m.maxTokens > 0 {
-			req.MaxTokens = m.maxTokens
-		}
-		if m.temperature > 0 {
-			req.Temperature = &m.temperature
-		}
-		if m.topP > 0 {
-			req.TopP = &m.topP
-		}
-		if m.topK > 0 {
-			req.TopK = m.topK
-		}
-		if m.searchRecencyFilter != "" {
-			req.SearchRecencyFilter = m.searchRecencyFilter
-		}
-		if len(m.searchDomainFilter) > 0 {
-			req.SearchDomainFilter = m.searchDomainFilter
-		}
-		req.ReturnImages = m.returnImages
-		req.ReturnRelatedQuestions = m.returnRelatedQuestions
+	var temperature, topP float64
+	if req.Temperature != nil {
+		temperature = *req.Temperature
+	}
+	if req.TopP != nil {
+		topP = *req.TopP
+	}
+	if err := validateSampling(ProviderPerplexity, temperature, 2, topP); err != nil {
+		return nil, err
+	}
+	extra, err := reqOpts.extraBodyFields(req)
+	if err != nil {
+		return nil, err
+	}
+	req.ExtraBody = extra
+	reqOpts.inspectRequest(ProviderPerplexity, req)
+	if reqOpts.dryRun {
+		return dryRunResponse(model, req)
 	}
 
-	c.logger.Debug().
+	logger.Debug().
 		Str("model", model.ModelName()).
 		Int("message_count", len(messages)).
 		Msg("Making Perplexity API request")
 
 	// Make request with rate limit handling
 	var resp *perplexity.ChatCompletionResponse
-	err := c.rateLimiter.Execute(ctx, func() error {
+	err = c.rateLimiter.ExecuteRequest(ctx, model.ModelName(), reqOpts, func() error {
 		var reqErr error
 		resp, reqErr = c.client.ChatCompletions(ctx, req)
 		return reqErr
 	})
 	if err != nil {
-		c.logger.Error().
+		logger.Error().
 			Err(err).
 			Str("model", model.ModelName()).
-			Str("prompt_preview", truncateString(prompt, 100)).
+			Str("prompt_preview", reqOpts.promptPreview(prompt)).
 			Msg("Perplexity generation failed")
 		return nil, fmt.Errorf("perplexity generation failed: %w", err)
 	}
 
+	usage := TokenUsage{
+		PromptTokens:     resp.Usage.PromptTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+		TotalTokens:      resp.Usage.TotalTokens,
+	}
+
 	if len(resp.Choices) == 0 {
-		return nil, fmt.Errorf("no response choices returned from Perplexity")
+		return nil, withPartialUsage(fmt.Errorf("no response choices returned from Perplexity"), usage)
 	}
 
 	choice := resp.Choices[0]
@@ -436,51 +550,62 @@ func (c *perplexityClient) Generate(ctx context.Context, model Model, prompt str
 		Text:         choice.Message.Content,
 		Model:        resp.Model,
 		FinishReason: choice.FinishReason,
-		Usage: TokenUsage{
-			PromptTokens:     resp.Usage.PromptTokens,
-			CompletionTokens: resp.Usage.CompletionTokens,
-			TotalTokens:      resp.Usage.TotalTokens,
-		},
-		Metadata: map[string]string{
-			"provider": "perplexity",
-			"model":    resp.Model,
-			"id":       resp.ID,
-		},
+		Usage:        usage,
+	}
+	if !reqOpts.minimalResponse {
+		response.Metadata = perplexityMetadata(resp)
+	}
+
+	if reqOpts.includeRaw {
+		response.RawJSON = resp.Raw
+	}
+
+	logger.Debug().
+		Str("model", resp.Model).
+		Int("prompt_tokens", resp.Usage.PromptTokens).
+		Int("completion_tokens", resp.Usage.CompletionTokens).
+		Int("total_tokens", resp.Usage.TotalTokens).
+		Int("citations", len(resp.Citations)).
+		Msg("Perplexity generation completed")
+
+	return response, nil
+}
+
+// perplexityMetadata collects the metadata of a Chat Completions response,
+// including its citations, related questions and images
+func perplexityMetadata(resp *perplexity.ChatCompletionResponse) map[string]string {
+	metadata := map[string]string{
+		"provider": "perplexity",
+		"model":    resp.Model,
+		"id":       resp.ID,
 	}
 
 	// Add citations to metadata if present
 	if len(resp.Citations) > 0 {
 		citationsJSON, _ := json.Marshal(resp.Citations)
-		response.Metadata["citations"] = string(citationsJSON)
-		response.Metadata["citations_count"] = fmt.Sprintf("%d", len(resp.Citations))
+		metadata["citations"] = string(citationsJSON)
+		metadata["citations_count"] = fmt.Sprintf("%d", len(resp.Citations))
 	}
 
 	// Add related questions to metadata if present
 	if len(resp.RelatedQuestions) > 0 {
 		questionsJSON, _ := json.Marshal(resp.RelatedQuestions)
-		response.Metadata["related_questions"] = string(questionsJSON)
+		metadata["related_questions"] = string(questionsJSON)
 	}
 
 	// Add images to metadata if present
 	if len(resp.Images) > 0 {
 		imagesJSON, _ := json.Marshal(resp.Images)
-		response.Metadata["images"] = string(imagesJSON)
+		metadata["images"] = string(imagesJSON)
 	}
 
-	c.logger.Debug().
-		Str("model", resp.Model).
-		Int("prompt_tokens", resp.Usage.PromptTokens).
-		Int("completion_tokens", resp.Usage.CompletionTokens).
-		Int("total_tokens", resp.Usage.TotalTokens).
-		Int("citations", len(resp.Citations)).
-		Msg("Perplexity generation completed")
-
-	return response, nil
+	addRateLimitHeaders(metadata, resp.Header)
+	return metadata
 }
 
 // Search performs a web search using Perplexity's Search API
 func (c *perplexityClient) Search(ctx context.Context, query string, options *SearchOptions) (*SearchResponse, error) {
-	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout(c.timeout, ProviderPerplexity, ""))
 	defer cancel()
 
 	req := perplexity.SearchRequest{
@@ -497,11 +622,11 @@ func (c *perplexityClient) Search(ctx context.Context, query string, options *Se
 	}
 
 	c.logger.Debug().
-		Str("query", truncateString(query, 100)).
+		Str("query_preview", c.promptLogging.promptPreview(query)).
 		Msg("Making Perplexity Search API request")
 
 	var resp *perplexity.SearchResponse
-	err := c.rateLimiter.Execute(ctx, func() error {
+	err := c.rateLimiter.Execute(ctx, "", func() error {
 		var reqErr error
 		resp, reqErr = c.client.Search(ctx, req)
 		return reqErr
@@ -509,7 +634,7 @@ func (c *perplexityClient) Search(ctx context.Context, query string, options *Se
 	if err != nil {
 		c.logger.Error().
 			Err(err).
-			Str("query", truncateString(query, 100)).
+			Str("query_preview", c.promptLogging.promptPreview(query)).
 			Msg("Perplexity search failed")
 		return nil, fmt.Errorf("perplexity search failed: %w", err)
 	}
@@ -520,25 +645,13 @@ func (c *perplexityClient) Search(ctx context.Context, query string, options *Se
 	}
 
 	for i, r := range resp.Results {
-		result.Results[i] = SearchResult{
-			Title:         r.Title,
-			URL:           r.URL,
-			Snippet:       r.Snippet,
-			DatePublished: r.DatePublished,
-			Author:        r.Author,
-		}
+		result.Results[i] = toSearchResult(r)
 	}
 
 	if len(resp.Images) > 0 {
 		result.Images = make([]ImageResult, len(resp.Images))
 		for i, img := range resp.Images {
-			result.Images[i] = ImageResult{
-				URL:       img.URL,
-				SourceURL: img.SourceURL,
-				Alt:       img.Alt,
-				Width:     img.Width,
-				Height:    img.Height,
-			}
+			result.Images[i] = toImageResult(img)
 		}
 	}
 
@@ -550,6 +663,9 @@ func (c *perplexityClient) Search(ctx context.Context, query string, options *Se
 	return result, nil
 }
 
+// rateLimitStats returns the rate limit counters of the Perplexity client
+func (c *perplexityClient) rateLimitStats() RateLimitStats { return c.rateLimiter.Stats() }
+
 // Health checks the health of the Perplexity client
 func (c *perplexityClient) Health(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
@@ -591,46 +707,53 @@ type SearchResponse struct {
 	Images []ImageResult
 }
 
-// SearchResult represents a single search result
-type SearchResult struct {
-	// Title is the page title
-	Title string
-	// URL is the result URL
-	URL string
-	// Snippet is the text snippet from the page
-	Snippet string
-	// DatePublished is when the content was published
-	DatePublished string
-	// Author is the content author if available
-	Author string
-}
-
-// ImageResult represents an image search result
-type ImageResult struct {
-	// URL is the image URL
-	URL string
-	// SourceURL is the page where the image was found
-	SourceURL string
-	// Alt is the image alt text
-	Alt string
-	// Width is the image width
-	Width int
-	// Height is the image height
-	Height int
-}
-
 // ============================================================================
 // HELPER FUNCTIONS
 // ============================================================================
 
+// toSearchResult converts a Perplexity search result into the shared SearchResult type
+func toSearchResult(r perplexity.SearchResult) SearchResult {
+	return SearchResult{
+		Title:         r.Title,
+		URL:           r.URL,
+		Snippet:       r.Snippet,
+		DatePublished: r.DatePublished,
+		Author:        r.Author,
+	}
+}
+
+// GetPerplexityCitations returns the URLs a Perplexity response was grounded
+// in. The second result is false for responses from other providers.
+func GetPerplexityCitations(resp *GenerationResponse) ([]string, bool) {
+	if resp == nil || resp.Metadata["provider"] != "perplexity" {
+		return nil, false
+	}
+
+	var citations []string
+	if raw := resp.Metadata["citations"]; raw != "" {
+		if err := json.Unmarshal([]byte(raw), &citations); err != nil {
+			return nil, false
+		}
+	}
+	return citations, true
+}
+
+// toImageResult converts a Perplexity image result into the shared ImageResult type
+func toImageResult(img perplexity.ImageResult) ImageResult {
+	return ImageResult{
+		URL:       img.URL,
+		SourceURL: img.SourceURL,
+		Alt:       img.Alt,
+		Width:     img.Width,
+		Height:    img.Height,
+	}
+}
+
 // GetPerplexityClient returns the underlying Perplexity client for Search API access
 func GetPerplexityClient(g *LLMGateway) (*perplexityClient, error) {
-	g.mu.RLock()
-	provider, exists := g.providers[ProviderPerplexity]
-	g.mu.RUnlock()
-
-	if !exists {
-		return nil, fmt.Errorf("perplexity provider is not registered")
+	provider, err := g.lookupProvider(ProviderPerplexity)
+	if err != nil {
+		return nil, err
 	}
 
 	client, ok := provider.(*perplexityClient)
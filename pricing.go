@@ -0,0 +1,273 @@
+package lingo
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ============================================================================
+// COST ESTIMATION
+// ============================================================================
+
+// ModelPricing describes what a model charges, in USD per million tokens.
+// Lingo doesn't ship a price list since rates change frequently; fill this in
+// from the provider's pricing page.
+type ModelPricing struct {
+	// InputPerMillion is the price of uncached prompt tokens
+	InputPerMillion float64
+	// CachedInputPerMillion is the reduced price of prompt tokens served from
+	// the prompt cache. Zero means cached tokens are billed at InputPerMillion.
+	CachedInputPerMillion float64
+	// CacheWriteInputPerMillion is the price of prompt tokens written to the
+	// prompt cache, which Anthropic bills at a premium. Zero means they are
+	// billed at InputPerMillion.
+	CacheWriteInputPerMillion float64
+	// OutputPerMillion is the price of completion tokens (including reasoning tokens)
+	OutputPerMillion float64
+}
+
+// EstimateCost returns the estimated cost in USD of the given usage, billing
+// cached prompt tokens at the reduced cached rate and cache writes at the
+// cache write rate
+func (p ModelPricing) EstimateCost(usage TokenUsage) float64 {
+	cachedRate := p.CachedInputPerMillion
+	if cachedRate == 0 {
+		cachedRate = p.InputPerMillion
+	}
+	writeRate := p.CacheWriteInputPerMillion
+	if writeRate == 0 {
+		writeRate = p.InputPerMillion
+	}
+
+	cached := min(usage.CachedPromptTokens, usage.PromptTokens)
+	written := min(usage.CacheWriteTokens, usage.PromptTokens-cached)
+	uncached := usage.PromptTokens - cached - written
+
+	cost := float64(uncached)*p.InputPerMillion +
+		float64(cached)*cachedRate +
+		float64(written)*writeRate +
+		float64(usage.CompletionTokens)*p.OutputPerMillion
+
+	return cost / 1_000_000
+}
+
+// ============================================================================
+// STREAMING COST
+// ============================================================================
+
+// charsPerToken is the rough ratio used to estimate token counts mid-stream
+const charsPerToken = 4
+
+// UsageUpdate reports running token counts and cost while a response streams
+type UsageUpdate struct {
+	// Usage holds the token counts so far. Until the final update these are
+	// estimates of about 4 characters per token.
+	Usage TokenUsage
+	// Cost is the estimated cost of Usage in USD
+	Cost float64
+	// Final is true for the last update, which carries the provider's reported usage
+	Final bool
+}
+
+// UsageCallback receives running usage while a response streams
+type UsageCallback func(UsageUpdate)
+
+// GenerateWithUsageCallback streams a response like GenerateStream and calls
+// onUsage after every chunk with the estimated tokens and cost so far, then
+// once more with the provider's reported usage when the stream completes.
+// handler may be nil if only usage is needed.
+func (g *LLMGateway) GenerateWithUsageCallback(ctx context.Context, model Model, prompt string, pricing ModelPricing, handler StreamHandler, onUsage UsageCallback, opts ...GenerateOption) (*GenerationResponse, error) {
+	system := newGenerateOptions(opts).systemPromptFor(model)
+	promptTokens := estimateTokens(utf8.RuneCountInString(system) + utf8.RuneCountInString(prompt))
+	completionChars := 0
+
+	wrapped := func(chunk StreamChunk) error {
+		if handler != nil {
+			if err := handler(chunk); err != nil {
+				return err
+			}
+		}
+		if onUsage == nil {
+			return nil
+		}
+
+		completionChars += utf8.RuneCountInString(chunk.Text)
+		usage := TokenUsage{
+			PromptTokens:     promptTokens,
+			CompletionTokens: estimateTokens(completionChars),
+		}
+		usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+		onUsage(UsageUpdate{Usage: usage, Cost: pricing.EstimateCost(usage)})
+		return nil
+	}
+
+	resp, err := g.GenerateStream(ctx, model, prompt, wrapped, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if onUsage != nil {
+		onUsage(UsageUpdate{Usage: resp.Usage, Cost: pricing.EstimateCost(resp.Usage), Final: true})
+	}
+	return resp, nil
+}
+
+// estimateTokens approximates the number of tokens in the given number of characters
+func estimateTokens(chars int) int {
+	return (chars + charsPerToken - 1) / charsPerToken
+}
+
+// ============================================================================
+// USAGE ACCUMULATION
+// ============================================================================
+
+// UsageTotals is the token usage and cost accumulated over a set of requests
+type UsageTotals struct {
+	Requests int
+	Usage    TokenUsage
+	// Cost is the estimated cost in USD of the requests whose model has pricing configured
+	Cost float64
+}
+
+// add records the usage and cost of one response
+func (t *UsageTotals) add(usage TokenUsage, cost float64) {
+	t.Requests++
+	t.Usage.PromptTokens += usage.PromptTokens
+	t.Usage.CompletionTokens += usage.CompletionTokens
+	t.Usage.TotalTokens += usage.TotalTokens
+	t.Usage.CachedPromptTokens += usage.CachedPromptTokens
+	t.Usage.CacheWriteTokens += usage.CacheWriteTokens
+	t.Usage.ReasoningTokens += usage.ReasoningTokens
+	t.Cost += cost
+}
+
+// UsageAccumulator keeps a running total of token usage and cost across many
+// requests, broken down by provider and by model. It is safe for concurrent use.
+type UsageAccumulator struct {
+	mu         sync.Mutex
+	pricing    map[string]ModelPricing
+	total      UsageTotals
+	byProvider map[ProviderType]UsageTotals
+	byModel    map[string]UsageTotals
+}
+
+// NewUsageAccumulator creates an empty usage accumulator
+func NewUsageAccumulator() *UsageAccumulator {
+	return &UsageAccumulator{
+		pricing:    make(map[string]ModelPricing),
+		byProvider: make(map[ProviderType]UsageTotals),
+		byModel:    make(map[string]UsageTotals),
+	}
+}
+
+// SetPricing configures the pricing used to cost responses from the given
+// model name. Providers often report a dated snapshot in
+// GenerationResponse.Model, such as "gpt-4o-2024-08-06" for "gpt-4o", so a
+// response without pricing for its exact name uses the longest configured
+// name it starts with, followed by a "-". Responses from models without
+// pricing count toward token totals only. Pricing applies to responses added
+// afterwards.
+func (a *UsageAccumulator) SetPricing(modelName string, pricing ModelPricing) *UsageAccumulator {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pricing[modelName] = pricing
+	return a
+}
+
+// Add records the usage of a response. Nil responses are ignored.
+func (a *UsageAccumulator) Add(resp *GenerationResponse) {
+	if resp == nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var cost float64
+	if pricing, ok := a.pricingFor(resp.Model); ok {
+		cost = pricing.EstimateCost(resp.Usage)
+	}
+
+	a.total.add(resp.Usage, cost)
+
+	provider := a.byProvider[resp.Provider]
+	provider.add(resp.Usage, cost)
+	a.byProvider[resp.Provider] = provider
+
+	model := a.byModel[resp.Model]
+	model.add(resp.Usage, cost)
+	a.byModel[resp.Model] = model
+}
+
+// pricingFor returns the pricing of a model name, falling back to the longest
+// configured name that is a prefix of it up to a "-"
+func (a *UsageAccumulator) pricingFor(modelName string) (ModelPricing, bool) {
+	if pricing, ok := a.pricing[modelName]; ok {
+		return pricing, true
+	}
+	var best string
+	for name := range a.pricing {
+		if len(name) > len(best) && strings.HasPrefix(modelName, name+"-") {
+			best = name
+		}
+	}
+	if best == "" {
+		return ModelPricing{}, false
+	}
+	return a.pricing[best], true
+}
+
+// Total returns the token usage accumulated across all responses
+func (a *UsageAccumulator) Total() TokenUsage {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.total.Usage
+}
+
+// Totals returns the request count, token usage and cost accumulated across
+// all responses
+func (a *UsageAccumulator) Totals() UsageTotals {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.total
+}
+
+// Cost returns the estimated cost in USD accumulated across all responses
+func (a *UsageAccumulator) Cost() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.total.Cost
+}
+
+// ByProvider returns a snapshot of the totals for each provider
+func (a *UsageAccumulator) ByProvider() map[ProviderType]UsageTotals {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := make(map[ProviderType]UsageTotals, len(a.byProvider))
+	for provider, totals := range a.byProvider {
+		out[provider] = totals
+	}
+	return out
+}
+
+// ByModel returns a snapshot of the totals for each model name
+func (a *UsageAccumulator) ByModel() map[string]UsageTotals {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := make(map[string]UsageTotals, len(a.byModel))
+	for model, totals := range a.byModel {
+		out[model] = totals
+	}
+	return out
+}
+
+// Reset clears all accumulated totals, keeping the configured pricing
+func (a *UsageAccumulator) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.total = UsageTotals{}
+	a.byProvider = make(map[ProviderType]UsageTotals)
+	a.byModel = make(map[string]UsageTotals)
+}
@@ -0,0 +1,52 @@
+package lingo
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// ============================================================================
+// SYSTEM PROMPT TEMPLATES
+// ============================================================================
+
+// SystemPromptTemplate is a system prompt with variables, such as the user's
+// name, the date or the locale of a persona. It uses text/template syntax with
+// variables referenced as {{.name}}, and is safe for concurrent use.
+type SystemPromptTemplate struct {
+	tmpl *template.Template
+}
+
+// ParseSystemPromptTemplate parses a system prompt template
+func ParseSystemPromptTemplate(text string) (*SystemPromptTemplate, error) {
+	tmpl, err := template.New("system_prompt").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid system prompt template: %w", err)
+	}
+	return &SystemPromptTemplate{tmpl: tmpl}, nil
+}
+
+// Render substitutes the variables into the template. Every variable the
+// template references must be provided; extra variables are ignored.
+func (t *SystemPromptTemplate) Render(vars map[string]string) (string, error) {
+	if vars == nil {
+		vars = map[string]string{}
+	}
+	var b strings.Builder
+	if err := t.tmpl.Execute(&b, vars); err != nil {
+		return "", fmt.Errorf("failed to render system prompt template: %w", err)
+	}
+	return b.String(), nil
+}
+
+// RenderSystemPrompt parses and renders a system prompt template in one step:
+//
+//	system, err := lingo.RenderSystemPrompt("You are helping {{.name}}.", map[string]string{"name": user.Name})
+//	model := lingo.NewGPT4o().WithSystemPrompt(system)
+func RenderSystemPrompt(text string, vars map[string]string) (string, error) {
+	tmpl, err := ParseSystemPromptTemplate(text)
+	if err != nil {
+		return "", err
+	}
+	return tmpl.Render(vars)
+}
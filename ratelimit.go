@@ -6,17 +6,41 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
+// RateLimitStats reports how often a provider has been rate limited
+type RateLimitStats struct {
+	// RateLimited is the number of rate limit errors received
+	RateLimited int64
+	// Retries is the number of retry attempts made after a rate limit error
+	Retries int64
+	// WaitTime is the total time spent backing off before retries
+	WaitTime time.Duration
+	// Exhausted is the number of requests that failed after running out of retries or retry budget
+	Exhausted int64
+}
+
+// rateLimitStatsProvider is implemented by providers that track rate limit stats
+type rateLimitStatsProvider interface {
+	rateLimitStats() RateLimitStats
+}
+
 // rateLimiter handles rate limit detection and retry logic
 type rateLimiter struct {
-	config *RateLimitConfig
-	logger Logger
+	provider ProviderType
+	config   *RateLimitConfig
+	logger   Logger
+
+	rateLimited atomic.Int64
+	retries     atomic.Int64
+	waitTime    atomic.Int64 // nanoseconds
+	exhausted   atomic.Int64
 }
 
-// newRateLimiter creates a new rate limiter with the given config
-func newRateLimiter(config *RateLimitConfig, logger Logger) *rateLimiter {
+// newRateLimiter creates a new rate limiter for a provider with the given config
+func newRateLimiter(provider ProviderType, config *RateLimitConfig, logger Logger) *rateLimiter {
 	if config == nil {
 		config = DefaultRateLimitConfig()
 	}
@@ -34,18 +58,32 @@ func newRateLimiter(config *RateLimitConfig, logger Logger) *rateLimiter {
 		config.BackoffMultiplier = 2.0
 	}
 	return &rateLimiter{
-		config: config,
-		logger: logger,
+		provider: provider,
+		config:   config,
+		logger:   logger,
+	}
+}
+
+// Stats returns a snapshot of the rate limiter's counters
+func (r *rateLimiter) Stats() RateLimitStats {
+	return RateLimitStats{
+		RateLimited: r.rateLimited.Load(),
+		Retries:     r.retries.Load(),
+		WaitTime:    time.Duration(r.waitTime.Load()),
+		Exhausted:   r.exhausted.Load(),
 	}
 }
 
 // RetryFunc is a function that can be retried
 type RetryFunc func() error
 
-// Execute executes the given function with retry logic for rate limits
-func (r *rateLimiter) Execute(ctx context.Context, fn RetryFunc) error {
+// Execute executes the given function with retry logic for rate limits. The
+// model is included in the logs and is empty for requests not tied to one,
+// such as batch submissions.
+func (r *rateLimiter) Execute(ctx context.Context, model string, fn RetryFunc) error {
 	var lastErr error
 	backoff := r.config.InitialBackoff
+	start := time.Now()
 
 	for attempt := 0; attempt <= r.config.MaxRetries; attempt++ {
 		// Check if context is cancelled before attempting
@@ -66,10 +104,12 @@ func (r *rateLimiter) Execute(ctx context.Context, fn RetryFunc) error {
 		if !isRateLimitError(err) {
 			return err // Not a rate limit error, don't retry
 		}
+		r.rateLimited.Add(1)
 
 		// Check if we've exhausted retries
 		if attempt >= r.config.MaxRetries {
-			r.logger.Error().
+			r.exhausted.Add(1)
+			r.withContext(r.logger.Error(), model).
 				Int("attempts", attempt+1).
 				Err(err).
 				Msg("Rate limit retries exhausted")
@@ -79,10 +119,28 @@ func (r *rateLimiter) Execute(ctx context.Context, fn RetryFunc) error {
 		// Calculate backoff with jitter
 		waitDuration := r.calculateBackoff(backoff, err)
 
-		r.logger.Debug().
+		// Stop if waiting would exceed the retry budget
+		if r.config.MaxElapsedTime > 0 && time.Since(start)+waitDuration > r.config.MaxElapsedTime {
+			r.exhausted.Add(1)
+			r.withContext(r.logger.Error(), model).
+				Int("attempts", attempt+1).
+				Dur("elapsed", time.Since(start)).
+				Dur("max_elapsed_time", r.config.MaxElapsedTime).
+				Err(err).
+				Msg("Rate limit retry budget exhausted")
+			return err
+		}
+
+		// The first retry is logged at Info so throttling shows up in
+		// production logs; later ones only at Debug
+		event := r.logger.Debug()
+		if attempt == 0 {
+			event = r.logger.Info()
+		}
+		r.withContext(event, model).
 			Int("attempt", attempt+1).
 			Int("max_retries", r.config.MaxRetries).
-			Str("wait_duration", waitDuration.String()).
+			Dur("wait_duration", waitDuration).
 			Msg("Rate limited, waiting before retry")
 
 		// Wait with context cancellation support
@@ -91,6 +149,8 @@ func (r *rateLimiter) Execute(ctx context.Context, fn RetryFunc) error {
 			return ctx.Err()
 		case <-time.After(waitDuration):
 		}
+		r.retries.Add(1)
+		r.waitTime.Add(int64(waitDuration))
 
 		// Increase backoff for next iteration
 		backoff = time.Duration(float64(backoff) * r.config.BackoffMultiplier)
@@ -102,6 +162,32 @@ func (r *rateLimiter) Execute(ctx context.Context, fn RetryFunc) error {
 	return lastErr
 }
 
+// ExecuteRequest executes a generation request like Execute, unless the
+// request disabled retries with WithNoRetry, in which case fn is called once
+func (r *rateLimiter) ExecuteRequest(ctx context.Context, model string, reqOpts *generateOptions, fn RetryFunc) error {
+	if !reqOpts.noRetry {
+		return r.Execute(ctx, model, fn)
+	}
+
+	err := fn()
+	if isRateLimitError(err) {
+		r.rateLimited.Add(1)
+		r.withContext(r.logger.Debug(), model).
+			Err(err).
+			Msg("Rate limited, retries disabled for request")
+	}
+	return err
+}
+
+// withContext adds the provider and, when set, the model to a log event
+func (r *rateLimiter) withContext(event LogEvent, model string) LogEvent {
+	event = event.Str("provider", string(r.provider))
+	if model != "" {
+		event = event.Str("model", model)
+	}
+	return event
+}
+
 // calculateBackoff calculates the wait duration, potentially using Retry-After header
 func (r *rateLimiter) calculateBackoff(baseBackoff time.Duration, err error) time.Duration {
 	// Try to extract Retry-After from error if available
@@ -188,6 +274,30 @@ func extractRetryAfter(err error) time.Duration {
 	return 0
 }
 
+// rateLimitHeaderPrefixes are the prefixes of provider-reported rate limit headers
+var rateLimitHeaderPrefixes = []string{"x-ratelimit-", "anthropic-ratelimit-"}
+
+// addRateLimitHeaders copies provider-reported rate limit headers (remaining
+// requests and tokens, reset times) into metadata, keyed by the lowercased
+// header name
+func addRateLimitHeaders(metadata map[string]string, header http.Header) {
+	if metadata == nil {
+		return
+	}
+	for name, values := range header {
+		if len(values) == 0 {
+			continue
+		}
+		key := strings.ToLower(name)
+		for _, prefix := range rateLimitHeaderPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				metadata[key] = values[0]
+				break
+			}
+		}
+	}
+}
+
 // HTTPStatusError wraps an HTTP status code error
 type HTTPStatusError struct {
 	StatusCode int
@@ -0,0 +1,41 @@
+package lingo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterMaxElapsedTime(t *testing.T) {
+	const budget = 100 * time.Millisecond
+	limiter := newRateLimiter(ProviderOpenAI, &RateLimitConfig{
+		MaxRetries:        10,
+		InitialBackoff:    20 * time.Millisecond,
+		MaxBackoff:        time.Second,
+		BackoffMultiplier: 2,
+		MaxElapsedTime:    budget,
+	}, &NopLogger{})
+
+	rateLimited := errors.New("429 too many requests")
+	attempts := 0
+	start := time.Now()
+	err := limiter.Execute(context.Background(), "gpt-4o", func() error {
+		attempts++
+		return rateLimited
+	})
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, rateLimited) {
+		t.Fatalf("Execute error = %v, want the rate limit error", err)
+	}
+	if attempts < 2 || attempts > 10 {
+		t.Errorf("made %d attempts, want the budget to stop retries early", attempts)
+	}
+	if elapsed > budget {
+		t.Errorf("took %v, want at most the %v budget", elapsed, budget)
+	}
+	if stats := limiter.Stats(); stats.Exhausted != 1 || stats.Retries != int64(attempts-1) {
+		t.Errorf("Stats() = %+v, want 1 exhausted and %d retries", stats, attempts-1)
+	}
+}
@@ -0,0 +1,124 @@
+package lingo
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sync"
+)
+
+// ============================================================================
+// MODEL ROUTING
+// ============================================================================
+
+// ModelHint describes the kind of model a request wants without naming one
+type ModelHint string
+
+const (
+	// HintCheap prefers the lowest cost models
+	HintCheap ModelHint = "cheap"
+	// HintFast prefers the lowest latency models
+	HintFast ModelHint = "fast"
+	// HintSmart prefers the most capable models
+	HintSmart ModelHint = "smart"
+)
+
+// RouteCandidate is a model the router may pick for a hint.
+// NewModel is called for every selection so each request gets a fresh model.
+type RouteCandidate struct {
+	// NewModel creates the candidate model
+	NewModel func() Model
+	// Weight is the relative chance of this candidate being picked.
+	// Candidates with a weight of zero or less are never picked.
+	Weight int
+}
+
+// ModelRouter picks a model for a hint using weighted random selection among
+// candidates whose provider is registered with the gateway
+type ModelRouter struct {
+	mu     sync.RWMutex
+	routes map[ModelHint][]RouteCandidate
+}
+
+// defaultRoutes is the default routing table. Providers that can be left out
+// of the build add their candidates in init() with addDefaultRoute.
+var defaultRoutes = map[ModelHint][]RouteCandidate{
+	HintCheap: {
+		{NewModel: func() Model { return NewGPT4oMini() }, Weight: 3},
+		{NewModel: func() Model { return NewClaude35Haiku() }, Weight: 2},
+	},
+	HintFast: {
+		{NewModel: func() Model { return NewGPT41Nano() }, Weight: 2},
+		{NewModel: func() Model { return NewClaudeHaiku45() }, Weight: 2},
+	},
+	HintSmart: {
+		{NewModel: func() Model { return NewO3() }, Weight: 2},
+		{NewModel: func() Model { return NewClaudeOpus45() }, Weight: 2},
+	},
+}
+
+// addDefaultRoute adds a candidate to the default routing table
+func addDefaultRoute(hint ModelHint, candidate RouteCandidate) {
+	defaultRoutes[hint] = append(defaultRoutes[hint], candidate)
+}
+
+// NewModelRouter creates a router populated with the default routing table
+func NewModelRouter() *ModelRouter {
+	routes := make(map[ModelHint][]RouteCandidate, len(defaultRoutes))
+	for hint, candidates := range defaultRoutes {
+		routes[hint] = append([]RouteCandidate(nil), candidates...)
+	}
+	return &ModelRouter{routes: routes}
+}
+
+// SetRoute replaces the candidates for a hint. Passing no candidates removes the hint.
+func (r *ModelRouter) SetRoute(hint ModelHint, candidates ...RouteCandidate) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(candidates) == 0 {
+		delete(r.routes, hint)
+		return
+	}
+	r.routes[hint] = append([]RouteCandidate(nil), candidates...)
+}
+
+// Select picks a model for the hint. isRegistered reports whether a provider
+// is available; candidates for unavailable providers are skipped.
+func (r *ModelRouter) Select(hint ModelHint, isRegistered func(ProviderType) bool) (Model, error) {
+	r.mu.RLock()
+	candidates := r.routes[hint]
+	r.mu.RUnlock()
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no route configured for hint %q", hint)
+	}
+
+	models := make([]Model, 0, len(candidates))
+	weights := make([]int, 0, len(candidates))
+	total := 0
+	for _, c := range candidates {
+		if c.Weight <= 0 || c.NewModel == nil {
+			continue
+		}
+		m := c.NewModel()
+		if m == nil || (isRegistered != nil && !isRegistered(m.Provider())) {
+			continue
+		}
+		models = append(models, m)
+		weights = append(weights, c.Weight)
+		total += c.Weight
+	}
+
+	if total == 0 {
+		return nil, fmt.Errorf("no registered provider can serve hint %q", hint)
+	}
+
+	n := rand.IntN(total)
+	for i, w := range weights {
+		if n < w {
+			return models[i], nil
+		}
+		n -= w
+	}
+	return models[len(models)-1], nil
+}
@@ -0,0 +1,41 @@
+package lingo
+
+import "unicode/utf8"
+
+// ============================================================================
+// MESSAGE TRIMMING
+// ============================================================================
+
+// messageOverheadTokens approximates the tokens a provider adds to each turn
+// for its role and delimiters
+const messageOverheadTokens = 4
+
+// TrimMessages drops the oldest turns of a conversation until it fits the
+// model's context window, after reserving reserveOutput tokens for the reply
+// and room for the system prompt. Pass the options the conversation will be
+// sent with so a system prompt set per request with WithSystemPromptOpt is
+// counted instead of the model's own. Tokens are estimated at about 4
+// characters each. The kept turns still start with a user turn, and the last
+// turn is always kept, even if it doesn't fit on its own. The dropped turns
+// are returned in their original order.
+func TrimMessages(model Model, messages []ChatMessage, reserveOutput int, opts ...GenerateOption) (kept, dropped []ChatMessage) {
+	system := newGenerateOptions(opts).systemPromptFor(model)
+	budget := contextWindow(model) - reserveOutput - estimateTokens(utf8.RuneCountInString(system))
+
+	used := 0
+	for _, msg := range messages {
+		used += messageTokens(msg)
+	}
+
+	start := 0
+	for start < len(messages)-1 && (used > budget || start > 0 && messages[start].Role != RoleUser) {
+		used -= messageTokens(messages[start])
+		start++
+	}
+	return messages[start:], messages[:start]
+}
+
+// messageTokens estimates the tokens a turn takes up in a request
+func messageTokens(msg ChatMessage) int {
+	return estimateTokens(utf8.RuneCountInString(msg.Content)) + messageOverheadTokens
+}
@@ -0,0 +1,65 @@
+package lingo
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTrimMessages(t *testing.T) {
+	// Each turn is 10 tokens of content plus the per-turn overhead
+	turn := func(role ChatRole, c string) ChatMessage {
+		return ChatMessage{Role: role, Content: strings.Repeat(c, 40)}
+	}
+	u1, a1 := turn(RoleUser, "a"), turn(RoleAssistant, "b")
+	u2, a2 := turn(RoleUser, "c"), turn(RoleAssistant, "d")
+	u3 := turn(RoleUser, "e")
+	history := []ChatMessage{u1, a1, u2, a2, u3}
+
+	tests := []struct {
+		name        string
+		numCtx      int
+		system      string
+		reserve     int
+		opts        []GenerateOption
+		wantKept    []ChatMessage
+		wantDropped []ChatMessage
+	}{
+		{"fits", 100, "", 30, nil, history, []ChatMessage{}},
+		{"drops oldest pair", 100, "", 50, nil, []ChatMessage{u2, a2, u3}, []ChatMessage{u1, a1}},
+		{"keeps user first", 100, "", 40, nil, []ChatMessage{u2, a2, u3}, []ChatMessage{u1, a1}},
+		{"counts system prompt", 100, strings.Repeat("s", 80), 30, nil, []ChatMessage{u2, a2, u3}, []ChatMessage{u1, a1}},
+		{"counts request system prompt", 100, "", 30, []GenerateOption{WithSystemPromptOpt(strings.Repeat("s", 80))}, []ChatMessage{u2, a2, u3}, []ChatMessage{u1, a1}},
+		{"request system prompt replaces model's", 100, strings.Repeat("s", 80), 30, []GenerateOption{WithSystemPromptOpt("")}, history, []ChatMessage{}},
+		{"keeps last turn", 10, "", 0, nil, []ChatMessage{u3}, []ChatMessage{u1, a1, u2, a2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := NewOllamaModel("llama3").WithNumCtx(tt.numCtx).WithSystemPrompt(tt.system)
+			kept, dropped := TrimMessages(model, history, tt.reserve, tt.opts...)
+			if !reflect.DeepEqual(kept, tt.wantKept) {
+				t.Errorf("kept %d turns, want %d", len(kept), len(tt.wantKept))
+			}
+			if !reflect.DeepEqual(dropped, tt.wantDropped) {
+				t.Errorf("dropped %d turns, want %d", len(dropped), len(tt.wantDropped))
+			}
+		})
+	}
+}
+
+func TestContextWindow(t *testing.T) {
+	tests := []struct {
+		model Model
+		want  int
+	}{
+		{NewGPT4o(), 128000},
+		{NewClaudeSonnet45(), 200000},
+		{NewOllamaModel("llama3").WithNumCtx(32768), 32768},
+		{NewOllamaModel("llama3"), fallbackContextWindow},
+	}
+	for _, tt := range tests {
+		if got := contextWindow(tt.model); got != tt.want {
+			t.Errorf("contextWindow(%s) = %d, want %d", tt.model.ModelName(), got, tt.want)
+		}
+	}
+}
@@ -4,6 +4,8 @@ package lingo
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -23,6 +25,37 @@ const (
 	ProviderBedrock    ProviderType = "bedrock"
 )
 
+// String returns the provider name
+func (p ProviderType) String() string { return string(p) }
+
+// KnownProviders returns the built-in provider types, whether or not they are
+// compiled into this binary (see RegisteredFactories)
+func KnownProviders() []ProviderType {
+	return []ProviderType{
+		ProviderOpenAI,
+		ProviderAnthropic,
+		ProviderGoogle,
+		ProviderPerplexity,
+		ProviderOllama,
+		ProviderBedrock,
+	}
+}
+
+// IsValid reports whether p names a built-in provider or one registered with
+// RegisterProvider, so provider names read from configuration files,
+// environment variables or flags can be checked before building a config
+func (p ProviderType) IsValid() bool {
+	for _, known := range KnownProviders() {
+		if p == known {
+			return true
+		}
+	}
+	providerFactoriesMu.RLock()
+	defer providerFactoriesMu.RUnlock()
+	_, ok := providerFactories[p]
+	return ok
+}
+
 // ProviderConfig is the interface that all provider configurations must implement
 type ProviderConfig interface {
 	providerType() ProviderType
@@ -41,6 +74,9 @@ type RateLimitConfig struct {
 	MaxBackoff time.Duration
 	// BackoffMultiplier is the multiplier for exponential backoff (default: 2.0)
 	BackoffMultiplier float64
+	// MaxElapsedTime bounds the total time spent on a request including
+	// retries and backoffs (default: 0, no limit)
+	MaxElapsedTime time.Duration
 }
 
 // DefaultRateLimitConfig returns the default rate limit configuration
@@ -75,8 +111,8 @@ type Model interface {
 // Gateway defines the interface for LLM operations
 type Gateway interface {
 	// Generate generates text using the specified model
-	// The model carries its own generation options
-	Generate(ctx context.Context, model Model, prompt string) (*GenerationResponse, error)
+	// The model carries its own generation options; opts apply to this request only
+	Generate(ctx context.Context, model Model, prompt string, opts ...GenerateOption) (*GenerationResponse, error)
 
 	// IsRegistered checks if a provider is registered
 	IsRegistered(provider ProviderType) bool
@@ -93,11 +129,84 @@ type Gateway interface {
 
 // Provider represents a single LLM provider implementation
 type Provider interface {
-	Generate(ctx context.Context, model Model, prompt string) (*GenerationResponse, error)
+	Generate(ctx context.Context, model Model, prompt string, opts ...GenerateOption) (*GenerationResponse, error)
 	Health(ctx context.Context) error
 	Close() error
 }
 
+// TranscriptionModel is implemented by speech-to-text models.
+// Like text models, each carries its own options.
+type TranscriptionModel interface {
+	// ModelName returns the API model identifier (e.g., "whisper-1")
+	ModelName() string
+	// Provider returns the provider type for this model
+	Provider() ProviderType
+}
+
+// Transcriber is implemented by providers that can transcribe audio
+type Transcriber interface {
+	Transcribe(ctx context.Context, model TranscriptionModel, audio []byte) (string, error)
+}
+
+// ImageModel is implemented by image generation models.
+// Like text models, each carries its own options.
+type ImageModel interface {
+	// ModelName returns the API model identifier (e.g., "dall-e-3")
+	ModelName() string
+	// Provider returns the provider type for this model
+	Provider() ProviderType
+}
+
+// ImageGenerator is implemented by providers that can generate images
+type ImageGenerator interface {
+	GenerateImage(ctx context.Context, model ImageModel, prompt string) ([]ImageResult, error)
+}
+
+// ChatRole is the author of a turn in a conversation
+type ChatRole string
+
+const (
+	RoleUser      ChatRole = "user"
+	RoleAssistant ChatRole = "assistant"
+)
+
+// ChatMessage is a turn in a conversation. The system prompt comes from the
+// model, so conversations only hold user and assistant turns.
+type ChatMessage struct {
+	Role    ChatRole
+	Content string
+}
+
+// ChatProvider is implemented by providers that accept a multi-turn
+// conversation instead of a single prompt
+type ChatProvider interface {
+	GenerateChat(ctx context.Context, model Model, messages []ChatMessage, opts ...GenerateOption) (*GenerationResponse, error)
+}
+
+// StreamingProvider is implemented by providers that can stream responses
+type StreamingProvider interface {
+	GenerateStream(ctx context.Context, model Model, prompt string, handler StreamHandler, opts ...GenerateOption) (*GenerationResponse, error)
+}
+
+// ChatStreamingProvider is implemented by providers that can stream the reply
+// to a multi-turn conversation
+type ChatStreamingProvider interface {
+	GenerateChatStream(ctx context.Context, model Model, messages []ChatMessage, handler StreamHandler, opts ...GenerateOption) (*GenerationResponse, error)
+}
+
+// Warmer is implemented by providers with a dedicated warm-up routine, such as
+// loading models into memory. Providers without one are warmed up with Health.
+type Warmer interface {
+	Warmup(ctx context.Context, models []Model) error
+}
+
+// Pinger is implemented by providers that can check connectivity and
+// credentials without running a generation, such as by listing models.
+// Providers without one are pinged with Health.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
 // ============================================================================
 // RESPONSE TYPES
 // ============================================================================
@@ -114,10 +223,150 @@ type GenerationResponse struct {
 	Usage TokenUsage `json:"usage"`
 	// FinishReason indicates why generation stopped
 	FinishReason string `json:"finish_reason"`
+	// StopSequence is the stop sequence that ended generation, when the
+	// provider reports it (Claude on Anthropic and Bedrock)
+	StopSequence string `json:"stop_sequence,omitempty"`
+	// Refusal is the model's explanation when it declined the request on
+	// safety grounds (OpenAI). Text is empty for a refusal.
+	Refusal string `json:"refusal,omitempty"`
 	// Metadata contains additional provider-specific information
 	Metadata map[string]string `json:"metadata,omitempty"`
+	// Audio is the spoken reply in the format chosen with WithAudioOutput
+	// (OpenAI audio models only)
+	Audio []byte `json:"audio,omitempty"`
+	// RawJSON is the untouched provider response payload (only set with WithIncludeRaw)
+	RawJSON json.RawMessage `json:"raw_json,omitempty"`
+	// Source describes where the response came from
+	Source ResponseSource `json:"source,omitempty"`
+	// FallbackIndex is the index of the model that answered in a fallback chain (0 otherwise)
+	FallbackIndex int `json:"fallback_index,omitempty"`
+	// Latency is the wall-clock time of the provider call
+	Latency time.Duration `json:"latency"`
+
+	// ollamaTimings is the timing breakdown of an Ollama response, kept even
+	// when WithMinimalResponse skips the metadata
+	ollamaTimings *OllamaTimings
+}
+
+// ResponseSource describes where a response came from
+type ResponseSource string
+
+const (
+	// SourceProvider means the response came directly from the requested model's provider
+	SourceProvider ResponseSource = "provider"
+	// SourceCache means the response was served from a cache
+	SourceCache ResponseSource = "cache"
+	// SourceFallback means the response came from a fallback model after earlier models failed
+	SourceFallback ResponseSource = "fallback"
+)
+
+// FinishReasonKind is a provider-independent classification of FinishReason
+type FinishReasonKind string
+
+const (
+	// FinishStop means the model finished naturally or hit a stop sequence
+	FinishStop FinishReasonKind = "stop"
+	// FinishLength means the output was cut off by the token limit
+	FinishLength FinishReasonKind = "length"
+	// FinishContentFilter means the output was blocked or cut off by a safety filter
+	FinishContentFilter FinishReasonKind = "content_filter"
+	// FinishToolCalls means the model stopped to call a tool: OpenAI's
+	// "tool_calls", Anthropic's and Bedrock's "tool_use", and the legacy
+	// "function_call"
+	FinishToolCalls FinishReasonKind = "tool_calls"
+	// FinishToolUse is an alias of FinishToolCalls
+	FinishToolUse = FinishToolCalls
+	// FinishOther covers any reason not listed above
+	FinishOther FinishReasonKind = "other"
+)
+
+// Refused reports whether the model declined the request on safety grounds:
+// OpenAI returned a refusal message, or Claude stopped with the "refusal" stop
+// reason. Refusals classify as FinishContentFilter.
+func (r *GenerationResponse) Refused() bool {
+	return r.Refusal != "" || strings.EqualFold(r.FinishReason, "refusal")
+}
+
+// FinishKind classifies the provider's FinishReason
+func (r *GenerationResponse) FinishKind() FinishReasonKind {
+	switch strings.ToLower(r.FinishReason) {
+	case "stop", "end_turn", "stop_sequence", "finish":
+		return FinishStop
+	case "length", "max_tokens", "max_output_tokens":
+		return FinishLength
+	case "content_filter", "content_filtered", "refusal", "safety", "recitation",
+		"blocklist", "prohibited_content", "spii", "image_safety":
+		return FinishContentFilter
+	case "tool_calls", "tool_use", "function_call":
+		return FinishToolCalls
+	default:
+		return FinishOther
+	}
+}
+
+// ChatMessage returns the response as an assistant turn, ready to be appended
+// to the conversation it answers
+func (r *GenerationResponse) ChatMessage() ChatMessage {
+	return ChatMessage{Role: RoleAssistant, Content: r.Text}
+}
+
+// ImageResponseFormat controls how generated images are returned
+type ImageResponseFormat string
+
+const (
+	// ImageResponseURL returns a temporary URL for each image
+	ImageResponseURL ImageResponseFormat = "url"
+	// ImageResponseB64JSON returns each image as base64-encoded data
+	ImageResponseB64JSON ImageResponseFormat = "b64_json"
+)
+
+// SearchResult represents a single web search or grounding result
+type SearchResult struct {
+	// Title is the page title
+	Title string
+	// URL is the result URL
+	URL string
+	// Snippet is the text snippet from the page
+	Snippet string
+	// DatePublished is when the content was published
+	DatePublished string
+	// Author is the content author if available
+	Author string
+}
+
+// ImageResult represents an image, either found by a search or generated by a model
+type ImageResult struct {
+	// URL is the image URL
+	URL string
+	// SourceURL is the page where the image was found (search results only)
+	SourceURL string
+	// Alt is the image alt text
+	Alt string
+	// Width is the image width
+	Width int
+	// Height is the image height
+	Height int
+	// B64JSON is the base64-encoded image data (generated images only)
+	B64JSON string
+	// MIMEType is the image content type, when known
+	MIMEType string
+	// RevisedPrompt is the prompt the model actually used, if it rewrote it
+	RevisedPrompt string
 }
 
+// StreamChunk is an incremental piece of a streamed response
+type StreamChunk struct {
+	// Text is the newly generated text since the previous chunk
+	Text string `json:"text"`
+}
+
+// StreamHandler is called for each chunk of a streamed response, on the
+// goroutine that called GenerateStream. Returning an error aborts the stream.
+// Once the stream ends, is aborted or its context is canceled, the handler is
+// not called again and the underlying connection has been closed, so no
+// goroutine is left reading it.
+type StreamHandler func(chunk StreamChunk) error
+
 // TokenUsage contains token usage information
 type TokenUsage struct {
 	// PromptTokens is the number of tokens in the prompt
@@ -126,6 +375,16 @@ type TokenUsage struct {
 	CompletionTokens int `json:"completion_tokens"`
 	// TotalTokens is the total number of tokens used
 	TotalTokens int `json:"total_tokens"`
+	// CachedPromptTokens is the number of prompt tokens served from the provider's
+	// prompt cache (already included in PromptTokens)
+	CachedPromptTokens int `json:"cached_prompt_tokens,omitempty"`
+	// CacheWriteTokens is the number of prompt tokens written to the provider's
+	// prompt cache, when the provider bills them separately (already included
+	// in PromptTokens)
+	CacheWriteTokens int `json:"cache_write_tokens,omitempty"`
+	// ReasoningTokens is the number of completion tokens spent on internal
+	// reasoning, when the provider reports it (already included in CompletionTokens)
+	ReasoningTokens int `json:"reasoning_tokens,omitempty"`
 }
 
 // ============================================================================
@@ -136,6 +395,7 @@ type TokenUsage struct {
 type Logger interface {
 	Debug() LogEvent
 	Info() LogEvent
+	Warn() LogEvent
 	Error() LogEvent
 }
 
@@ -145,6 +405,8 @@ type LogEvent interface {
 	Str(key, val string) LogEvent
 	Int(key string, val int) LogEvent
 	Int64(key string, val int64) LogEvent
+	Float(key string, val float64) LogEvent
+	Dur(key string, val time.Duration) LogEvent
 	Bool(key string, val bool) LogEvent
 	Err(err error) LogEvent
 }